@@ -0,0 +1,81 @@
+package bytebuffers
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"errors"
+)
+
+var (
+	ErrInvalidIV      = errors.New("bytebuffers.Crypto: invalid iv")
+	ErrInvalidPadding = errors.New("bytebuffers.Crypto: invalid padding")
+)
+
+// AESEncrypt
+// 以 AES-CBC 加密可读内容并替换之，明文以 PKCS7 填充。
+//
+// key 为 16、24 或 32 字节（AES-128/192/256），iv 为 16 字节。
+func AESEncrypt(buf Buffer, key []byte, iv []byte) (err error) {
+	block, blockErr := newAESCBCBlock(key, iv)
+	if blockErr != nil {
+		err = blockErr
+		return
+	}
+	plaintext := buf.Peek(buf.Len())
+	padding := aes.BlockSize - len(plaintext)%aes.BlockSize
+	dst := make([]byte, len(plaintext)+padding)
+	copy(dst, plaintext)
+	for i := len(plaintext); i < len(dst); i++ {
+		dst[i] = byte(padding)
+	}
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(dst, dst)
+	err = buf.Set(dst)
+	return
+}
+
+// AESDecrypt
+// 以 AES-CBC 解密可读内容并去除 PKCS7 填充后替换之。
+//
+// 当填充无效时返回 ErrInvalidPadding，且可读内容不变。
+func AESDecrypt(buf Buffer, key []byte, iv []byte) (err error) {
+	block, blockErr := newAESCBCBlock(key, iv)
+	if blockErr != nil {
+		err = blockErr
+		return
+	}
+	ciphertext := buf.Peek(buf.Len())
+	if len(ciphertext) == 0 || len(ciphertext)%aes.BlockSize != 0 {
+		err = ErrInvalidPadding
+		return
+	}
+	dst := make([]byte, len(ciphertext))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(dst, ciphertext)
+	padding := int(dst[len(dst)-1])
+	if padding == 0 || padding > aes.BlockSize {
+		err = ErrInvalidPadding
+		return
+	}
+	for _, b := range dst[len(dst)-padding:] {
+		if int(b) != padding {
+			err = ErrInvalidPadding
+			return
+		}
+	}
+	err = buf.Set(dst[:len(dst)-padding])
+	return
+}
+
+func newAESCBCBlock(key []byte, iv []byte) (block cipher.Block, err error) {
+	switch len(key) {
+	case 16, 24, 32:
+	default:
+		err = ErrInvalidKey
+		return
+	}
+	if len(iv) != aes.BlockSize {
+		err = ErrInvalidIV
+		return
+	}
+	block, err = aes.NewCipher(key)
+	return
+}
@@ -0,0 +1,70 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestAESEncrypt(t *testing.T) {
+	iv := make([]byte, 16)
+	_, _ = rand.Read(iv)
+	for _, keySize := range []int{16, 24, 32} {
+		key := make([]byte, keySize)
+		_, _ = rand.Read(key)
+		for _, size := range []int{0, 1, 15, 16, 17, 100} {
+			plaintext := bytes.Repeat([]byte{'a'}, size)
+			buf := bytebuffers.NewBuffer()
+			_, _ = buf.Write(plaintext)
+			if err := bytebuffers.AESEncrypt(buf, key, iv); err != nil {
+				t.Fatal(err)
+			}
+			padding := 16 - size%16
+			if buf.Len() != size+padding {
+				t.Fatal("unexpected ciphertext length", buf.Len())
+			}
+
+			block, _ := aes.NewCipher(key)
+			raw := buf.CloneBytes()
+			cipher.NewCBCDecrypter(block, iv).CryptBlocks(raw, raw)
+			if !bytes.Equal(raw[size:], bytes.Repeat([]byte{byte(padding)}, padding)) {
+				t.Fatal("unexpected pkcs7 padding", raw[size:])
+			}
+
+			if err := bytebuffers.AESDecrypt(buf, key, iv); err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(buf.CloneBytes(), plaintext) {
+				t.Fatal("round trip mismatch", keySize, size)
+			}
+		}
+	}
+}
+
+func TestAESDecrypt(t *testing.T) {
+	key, iv := make([]byte, 16), make([]byte, 16)
+	buf := bytebuffers.NewBuffer()
+	if err := bytebuffers.AESEncrypt(buf, make([]byte, 20), iv); !errors.Is(err, bytebuffers.ErrInvalidKey) {
+		t.Fatal("expected invalid key, got", err)
+	}
+	if err := bytebuffers.AESEncrypt(buf, key, iv[:8]); !errors.Is(err, bytebuffers.ErrInvalidIV) {
+		t.Fatal("expected invalid iv, got", err)
+	}
+
+	// a block whose plaintext ends with an invalid padding byte
+	block, _ := aes.NewCipher(key)
+	raw := bytes.Repeat([]byte{0x11}, 16)
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(raw, raw)
+	_, _ = buf.Write(raw)
+	if err := bytebuffers.AESDecrypt(buf, key, iv); !errors.Is(err, bytebuffers.ErrInvalidPadding) {
+		t.Fatal("expected invalid padding, got", err)
+	}
+	if !bytes.Equal(buf.CloneBytes(), raw) {
+		t.Fatal("buffer changed on failure")
+	}
+}
@@ -0,0 +1,64 @@
+package bytebuffers
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"errors"
+)
+
+var (
+	ErrInvalidNonce = errors.New("bytebuffers.Crypto: invalid nonce")
+)
+
+// AES256GCMEncrypt
+// 以 AES-256-GCM 加密可读内容并替换之，结果为 密文 + 认证标签（16 字节）。
+//
+// key 必须为 32 字节，nonce 必须为 12 字节，aad 为附加认证数据。
+func AES256GCMEncrypt(buf Buffer, key, nonce, aad []byte) (err error) {
+	aead, aeadErr := newAES256GCM(key, nonce)
+	if aeadErr != nil {
+		err = aeadErr
+		return
+	}
+	plaintext := buf.Peek(buf.Len())
+	dst := aead.Seal(make([]byte, 0, len(plaintext)+aead.Overhead()), nonce, plaintext, aad)
+	err = buf.Set(dst)
+	return
+}
+
+// AES256GCMDecrypt
+// 以 AES-256-GCM 解密并校验可读内容后替换之。
+//
+// 当认证失败时返回 ErrDecryptionFailed，且可读内容不变。
+func AES256GCMDecrypt(buf Buffer, key, nonce, aad []byte) (err error) {
+	aead, aeadErr := newAES256GCM(key, nonce)
+	if aeadErr != nil {
+		err = aeadErr
+		return
+	}
+	plaintext, openErr := aead.Open(nil, nonce, buf.Peek(buf.Len()), aad)
+	if openErr != nil {
+		err = ErrDecryptionFailed
+		return
+	}
+	err = buf.Set(plaintext)
+	return
+}
+
+func newAES256GCM(key, nonce []byte) (aead cipher.AEAD, err error) {
+	if len(key) != 32 {
+		err = ErrInvalidKey
+		return
+	}
+	if len(nonce) != 12 {
+		err = ErrInvalidNonce
+		return
+	}
+	block, blockErr := aes.NewCipher(key)
+	if blockErr != nil {
+		err = blockErr
+		return
+	}
+	aead, err = cipher.NewGCM(block)
+	return
+}
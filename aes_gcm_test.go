@@ -0,0 +1,66 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestAES256GCMEncrypt(t *testing.T) {
+	key, nonce := make([]byte, 32), make([]byte, 12)
+	_, _ = rand.Read(key)
+	_, _ = rand.Read(nonce)
+	aad := []byte("header")
+	plaintext := []byte("0123456789")
+
+	buf := bytebuffers.NewBuffer()
+	_, _ = buf.Write(plaintext)
+	if err := bytebuffers.AES256GCMEncrypt(buf, key, nonce, aad); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != len(plaintext)+16 {
+		t.Fatal("unexpected ciphertext length", buf.Len())
+	}
+	if err := bytebuffers.AES256GCMDecrypt(buf, key, nonce, aad); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.CloneBytes(), plaintext) {
+		t.Fatal("round trip mismatch")
+	}
+}
+
+func TestAES256GCMDecrypt(t *testing.T) {
+	key, nonce := make([]byte, 32), make([]byte, 12)
+	_, _ = rand.Read(key)
+	aad := []byte("header")
+
+	buf := bytebuffers.NewBuffer()
+	_, _ = buf.Write([]byte("0123456789"))
+	_ = bytebuffers.AES256GCMEncrypt(buf, key, nonce, aad)
+	sealed := buf.CloneBytes()
+
+	tampered := bytes.Clone(sealed)
+	tampered[0] ^= 0x01
+	_ = buf.Set(tampered)
+	if err := bytebuffers.AES256GCMDecrypt(buf, key, nonce, aad); !errors.Is(err, bytebuffers.ErrDecryptionFailed) {
+		t.Fatal("expected decryption failed on tampered ciphertext, got", err)
+	}
+	if !bytes.Equal(buf.CloneBytes(), tampered) {
+		t.Fatal("buffer changed on failure")
+	}
+
+	_ = buf.Set(sealed)
+	if err := bytebuffers.AES256GCMDecrypt(buf, key, nonce, []byte("Header")); !errors.Is(err, bytebuffers.ErrDecryptionFailed) {
+		t.Fatal("expected decryption failed on tampered aad, got", err)
+	}
+
+	if err := bytebuffers.AES256GCMEncrypt(buf, key[:16], nonce, nil); !errors.Is(err, bytebuffers.ErrInvalidKey) {
+		t.Fatal("expected invalid key, got", err)
+	}
+	if err := bytebuffers.AES256GCMDecrypt(buf, key, nonce[:8], nil); !errors.Is(err, bytebuffers.ErrInvalidNonce) {
+		t.Fatal("expected invalid nonce, got", err)
+	}
+}
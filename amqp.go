@@ -0,0 +1,72 @@
+package bytebuffers
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+const (
+	AMQPFrameMethod    = 1
+	AMQPFrameHeader    = 2
+	AMQPFrameBody      = 3
+	AMQPFrameHeartbeat = 8
+
+	amqpFrameHeaderLen = 7
+	amqpFrameEnd       = 0xCE
+)
+
+var (
+	ErrAMQPFrameEnd = errors.New("bytebuffers.AMQP: invalid frame end")
+)
+
+// WriteAMQP091Frame
+// 写入 AMQP 0-9-1 帧：类型（1）、通道（2）、长度（4）、负载与结束符 0xCE。
+func WriteAMQP091Frame(buf Buffer, frameType byte, channel uint16, payload []byte) (err error) {
+	if uint64(len(payload)) > math.MaxUint32 {
+		err = ErrTooLarge
+		return
+	}
+	size := amqpFrameHeaderLen + len(payload) + 1
+	p, borrowErr := buf.Borrow(size)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	p[0] = frameType
+	binary.BigEndian.PutUint16(p[1:], channel)
+	binary.BigEndian.PutUint32(p[3:], uint32(len(payload)))
+	copy(p[amqpFrameHeaderLen:], payload)
+	p[size-1] = amqpFrameEnd
+	buf.Return(size)
+	return
+}
+
+// ReadAMQP091Frame
+// 读取 AMQP 0-9-1 帧，结束符不为 0xCE 时返回 ErrAMQPFrameEnd。失败或不完整时不读掉。
+func ReadAMQP091Frame(buf Buffer) (frameType byte, channel uint16, payload []byte, err error) {
+	p, peekErr := peekFull(buf, amqpFrameHeaderLen)
+	if peekErr != nil {
+		err = peekErr
+		return
+	}
+	length := uint64(binary.BigEndian.Uint32(p[3:]))
+	if length > uint64(maxInt-amqpFrameHeaderLen-1) {
+		err = ErrTooLarge
+		return
+	}
+	size := amqpFrameHeaderLen + int(length) + 1
+	if p, err = peekFull(buf, size); err != nil {
+		return
+	}
+	if p[size-1] != amqpFrameEnd {
+		err = ErrAMQPFrameEnd
+		return
+	}
+	frameType = p[0]
+	channel = binary.BigEndian.Uint16(p[1:])
+	payload = make([]byte, length)
+	copy(payload, p[amqpFrameHeaderLen:])
+	buf.Discard(size)
+	return
+}
@@ -0,0 +1,64 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestWriteAMQP091Frame(t *testing.T) {
+	frames := []struct {
+		frameType byte
+		channel   uint16
+		payload   []byte
+	}{
+		{bytebuffers.AMQPFrameMethod, 1, []byte{0x00, 0x0A, 0x00, 0x0B}},
+		{bytebuffers.AMQPFrameHeader, 1, []byte{0x00, 0x3C, 0x00, 0x00}},
+		{bytebuffers.AMQPFrameBody, 1, []byte("hello")},
+		{bytebuffers.AMQPFrameHeartbeat, 0, []byte{}},
+	}
+	buf := bytebuffers.NewBuffer()
+	_ = bytebuffers.WriteAMQP091Frame(buf, bytebuffers.AMQPFrameBody, 0x0102, []byte("hi"))
+	if !bytes.Equal(buf.CloneBytes(), []byte{3, 0x01, 0x02, 0, 0, 0, 2, 'h', 'i', 0xCE}) {
+		t.Fatal("unexpected frame", buf.CloneBytes())
+	}
+	buf.Reset()
+
+	for _, f := range frames {
+		if err := bytebuffers.WriteAMQP091Frame(buf, f.frameType, f.channel, f.payload); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, f := range frames {
+		frameType, channel, payload, err := bytebuffers.ReadAMQP091Frame(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if frameType != f.frameType || channel != f.channel || !bytes.Equal(payload, f.payload) {
+			t.Fatal("round trip mismatch", frameType, channel, payload)
+		}
+	}
+}
+
+func TestReadAMQP091Frame(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	_ = bytebuffers.WriteAMQP091Frame(buf, bytebuffers.AMQPFrameBody, 1, []byte("hello"))
+	frame := buf.CloneBytes()
+
+	_ = buf.Set(frame[:len(frame)-1])
+	if _, _, _, err := bytebuffers.ReadAMQP091Frame(buf); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal("expected unexpected EOF, got", err)
+	}
+
+	frame[len(frame)-1] = 0xCD
+	_ = buf.Set(frame)
+	if _, _, _, err := bytebuffers.ReadAMQP091Frame(buf); !errors.Is(err, bytebuffers.ErrAMQPFrameEnd) {
+		t.Fatal("expected frame end error, got", err)
+	}
+	if buf.Len() != len(frame) {
+		t.Fatal("invalid frame consumed")
+	}
+}
@@ -0,0 +1,92 @@
+package bytebuffers
+
+import (
+	"errors"
+	"net"
+)
+
+const (
+	BLEAdvInd        = 0x00
+	BLEAdvDirectInd  = 0x01
+	BLEAdvNonconnInd = 0x02
+	BLEScanReq       = 0x03
+	BLEScanRsp       = 0x04
+	BLEConnectInd    = 0x05
+	BLEAdvScanInd    = 0x06
+	BLETxAddRandom   = 0x40
+	BLERxAddRandom   = 0x80
+
+	BLEADFlags             = 0x01
+	BLEADShortLocalName    = 0x08
+	BLEADCompleteLocalName = 0x09
+	BLEADTxPowerLevel      = 0x0A
+	BLEADManufacturerData  = 0xFF
+
+	bleAddressLen = 6
+	bleMaxADData  = 31
+)
+
+var (
+	ErrBLEInvalidAddress = errors.New("bytebuffers.BLE: invalid address")
+	ErrBLEInvalid        = errors.New("bytebuffers.BLE: invalid advertising data")
+)
+
+// ADStructure
+// 广播数据中的一个 AD 结构（长度、类型与数据）。
+type ADStructure struct {
+	Type byte
+	Data []byte
+}
+
+// WriteBLEAdvertisingPDU
+// 写入 BLE 广播 PDU：2 字节头（pduType 含 TxAdd/RxAdd 标志，以及长度）、小端的 6 字节广播者地址与广播数据。
+//
+// address 按 net.HardwareAddr 的书写顺序给出，adData 不超过 31 字节。
+func WriteBLEAdvertisingPDU(buf Buffer, pduType byte, address net.HardwareAddr, adData []byte) (err error) {
+	if len(address) != bleAddressLen {
+		err = ErrBLEInvalidAddress
+		return
+	}
+	if len(adData) > bleMaxADData {
+		err = ErrTooLarge
+		return
+	}
+	size := 2 + bleAddressLen + len(adData)
+	p, borrowErr := buf.Borrow(size)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	p[0] = pduType
+	p[1] = byte(bleAddressLen + len(adData))
+	for i := 0; i < bleAddressLen; i++ {
+		p[2+i] = address[bleAddressLen-1-i]
+	}
+	copy(p[2+bleAddressLen:], adData)
+	buf.Return(size)
+	return
+}
+
+// ReadBLEADData
+// 将缓冲中的全部内容解析为 AD 结构并读掉，长度为 0 的结构视为有效数据的结尾。
+//
+// 结构被截断时返回 ErrBLEInvalid 且不读掉。
+func ReadBLEADData(buf Buffer) (structures []ADStructure, err error) {
+	p := buf.Peek(buf.Len())
+	for i := 0; i < len(p); {
+		length := int(p[i])
+		if length == 0 {
+			break
+		}
+		if i+1+length > len(p) {
+			structures, err = nil, ErrBLEInvalid
+			return
+		}
+		data := make([]byte, length-1)
+		copy(data, p[i+2:])
+		structures = append(structures, ADStructure{Type: p[i+1], Data: data})
+		i += 1 + length
+	}
+	buf.Discard(len(p))
+	return
+}
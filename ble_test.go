@@ -0,0 +1,52 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestBLEAdvertisingPDU(t *testing.T) {
+	address, _ := net.ParseMAC("c0:11:22:33:44:55")
+	adData := []byte{
+		0x02, bytebuffers.BLEADFlags, 0x06, // LE General Discoverable, BR/EDR not supported
+		0x05, bytebuffers.BLEADCompleteLocalName, 'b', 'e', 'a', 'n',
+	}
+	buf := bytebuffers.NewBuffer()
+	if err := bytebuffers.WriteBLEAdvertisingPDU(buf, bytebuffers.BLEAdvInd|bytebuffers.BLETxAddRandom, address, adData); err != nil {
+		t.Fatal(err)
+	}
+	expected := append([]byte{0x40, 15, 0x55, 0x44, 0x33, 0x22, 0x11, 0xC0}, adData...)
+	if p := buf.CloneBytes(); !bytes.Equal(p, expected) {
+		t.Fatal("unexpected pdu", p)
+	}
+
+	buf.Discard(8)
+	structures, err := bytebuffers.ReadBLEADData(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(structures) != 2 {
+		t.Fatal("unexpected structures", structures)
+	}
+	if structures[0].Type != bytebuffers.BLEADFlags || !bytes.Equal(structures[0].Data, []byte{0x06}) {
+		t.Fatal("unexpected flags", structures[0])
+	}
+	if structures[1].Type != bytebuffers.BLEADCompleteLocalName || string(structures[1].Data) != "bean" {
+		t.Fatal("unexpected local name", structures[1])
+	}
+	if buf.Len() != 0 {
+		t.Fatal("advertising data must be discarded")
+	}
+
+	_, _ = buf.Write([]byte{0x05, bytebuffers.BLEADCompleteLocalName, 'b'})
+	if _, err = bytebuffers.ReadBLEADData(buf); !errors.Is(err, bytebuffers.ErrBLEInvalid) {
+		t.Fatal("expected invalid, got", err)
+	}
+	if err = bytebuffers.WriteBLEAdvertisingPDU(buf, bytebuffers.BLEAdvInd, address, make([]byte, 32)); !errors.Is(err, bytebuffers.ErrTooLarge) {
+		t.Fatal("expected too large, got", err)
+	}
+}
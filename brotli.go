@@ -0,0 +1,34 @@
+package bytebuffers
+
+import (
+	"bytes"
+	"errors"
+	"io"
+
+	"github.com/andybalholm/brotli"
+)
+
+var (
+	ErrBrotliInvalidQuality = errors.New("bytebuffers.Brotli: invalid quality")
+)
+
+// CompressBrotli
+// 以 Brotli（RFC 7932）压缩可读内容并替换之，quality 为 0 至 11。
+func CompressBrotli(buf Buffer, quality int) (err error) {
+	if quality < brotli.BestSpeed || quality > brotli.BestCompression {
+		err = ErrBrotliInvalidQuality
+		return
+	}
+	tmp := Acquire()
+	defer Release(tmp)
+	err = compress(buf, tmp, brotli.NewWriterLevel(tmp, quality))
+	return
+}
+
+// DecompressBrotli
+// 解压 Brotli 格式的可读内容并替换之，失败时可读内容不变。
+func DecompressBrotli(buf Buffer) (err error) {
+	br := brotli.NewReader(bytes.NewReader(buf.Peek(buf.Len())))
+	err = decompress(buf, io.NopCloser(br))
+	return
+}
@@ -0,0 +1,98 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func brotliPayload(size int) []byte {
+	src := bytes.NewBuffer(nil)
+	for i := 0; src.Len() < size; i++ {
+		_, _ = fmt.Fprintf(src, "<li id=\"item-%d\">value %x</li>\n", i, i*31)
+	}
+	return src.Bytes()[:size]
+}
+
+func TestCompressBrotli(t *testing.T) {
+	src := brotliPayload(100 * 1024)
+	for _, quality := range []int{0, 1, 6, 11} {
+		buf := bytebuffers.NewBuffer()
+		_, _ = buf.Write(src)
+		if err := bytebuffers.CompressBrotli(buf, quality); err != nil {
+			t.Fatal(err)
+		}
+		p, err := io.ReadAll(brotli.NewReader(bytes.NewReader(buf.CloneBytes())))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(p, src) {
+			t.Fatal("reference reader mismatch at quality", quality)
+		}
+	}
+
+	buf := bytebuffers.NewBuffer()
+	if err := bytebuffers.CompressBrotli(buf, 12); !errors.Is(err, bytebuffers.ErrBrotliInvalidQuality) {
+		t.Fatal("expected invalid quality, got", err)
+	}
+}
+
+func TestDecompressBrotli(t *testing.T) {
+	src := brotliPayload(10 * 1024)
+	compressed := bytes.NewBuffer(nil)
+	bw := brotli.NewWriterLevel(compressed, brotli.BestCompression)
+	_, _ = bw.Write(src)
+	_ = bw.Close()
+
+	buf := bytebuffers.NewBuffer()
+	_, _ = buf.Write(compressed.Bytes())
+	if err := bytebuffers.DecompressBrotli(buf); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.CloneBytes(), src) {
+		t.Fatal("reference writer mismatch")
+	}
+
+	_ = buf.Set([]byte("not brotli"))
+	if err := bytebuffers.DecompressBrotli(buf); err == nil {
+		t.Fatal("expected error")
+	}
+	if string(buf.CloneBytes()) != "not brotli" {
+		t.Fatal("buffer changed on failure")
+	}
+}
+
+// BenchmarkCompressBrotli
+// BenchmarkCompressBrotli    	    1252	    889633 ns/op	 1788090 B/op	       7 allocs/op
+func BenchmarkCompressBrotli(b *testing.B) {
+	b.ReportAllocs()
+	src := brotliPayload(100 * 1024)
+	buf := bytebuffers.Acquire()
+	defer bytebuffers.Release(buf)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = buf.Set(src)
+		_ = bytebuffers.CompressBrotli(buf, 1)
+	}
+}
+
+// BenchmarkCompressGzip
+// BenchmarkCompressGzip    	    2394	    459090 ns/op	  813883 B/op	      14 allocs/op
+func BenchmarkCompressGzip(b *testing.B) {
+	b.ReportAllocs()
+	src := brotliPayload(100 * 1024)
+	dst := bytes.NewBuffer(nil)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dst.Reset()
+		gw, _ := gzip.NewWriterLevel(dst, gzip.BestSpeed)
+		_, _ = gw.Write(src)
+		_ = gw.Close()
+	}
+}
@@ -2,10 +2,13 @@ package bytebuffers
 
 import (
 	"bytes"
+	"encoding/base32"
 	"errors"
 	"io"
 	"math"
 	"math/bits"
+	"time"
+	"unicode/utf8"
 	"unsafe"
 )
 
@@ -22,24 +25,57 @@ type Buffer interface {
 	// Peek
 	// 查看 n 个字节，但不会读掉。
 	Peek(n int) (p []byte)
+	// PeekAt
+	// 查看从读位置起偏移 off 处的 n 个字节，但不会读掉。off 超出可读长度时返回 nil。
+	PeekAt(off int, n int) (p []byte)
 	// Next
 	// 取后 n 个
 	Next(n int) (p []byte, err error)
+	// NextSlice
+	// 取后 n 个，不复制。返回的切片与缓冲共享内存，下一次变更后失效。
+	NextSlice(n int) (p []byte, err error)
 	// Discard
 	// 丢弃
 	Discard(n int)
+	// Truncate
+	// 只保留前 n 个可读字节，丢弃其后的内容。n 超出 [0, Len()] 时返回 ErrInvalidOffset。
+	Truncate(n int) (err error)
 	// Read
 	// 读取
 	Read(p []byte) (n int, err error)
 	// ReadByte
 	// 读取一个字节
 	ReadByte() (b byte, err error)
+	// UnreadByte
+	// 撤销最近一次 ReadByte，之后有其它读写时返回 ErrUnreadByte。
+	UnreadByte() (err error)
+	// ReadRune
+	// 读取一个 UTF-8 字符，不完整时不读掉并返回 io.ErrUnexpectedEOF，无效编码时读掉一个字节并返回 utf8.RuneError。
+	ReadRune() (r rune, size int, err error)
+	// UnreadRune
+	// 撤销最近一次 ReadRune，之后有其它读写时返回 ErrUnreadRune。
+	UnreadRune() (err error)
+	// WriteRune
+	// 以 UTF-8 写入一个字符
+	WriteRune(r rune) (n int, err error)
 	// ReadBytes
 	// 以 delim 读
 	ReadBytes(delim byte) (line []byte, err error)
 	// Index
 	// 标号
 	Index(delim byte) (i int)
+	// ReadAt
+	// 从读位置起偏移 off 处读取，不会读掉。不足 len(p) 时返回 io.EOF。
+	ReadAt(p []byte, off int64) (n int, err error)
+	// WriteAt
+	// 从读位置起偏移 off 处覆盖写入，超出可读长度时扩展可读内容，中间空出的部分以 0 填充。
+	WriteAt(p []byte, off int64) (n int, err error)
+	// Seek
+	// 在已缓冲的内容中移动读位置，返回移动后的绝对位置。位置以首次 Seek 时的读位置为 0，
+	// io.SeekEnd 以可读内容的末尾为 0，超出可读内容或已释放的位置时返回 ErrInvalidOffset。
+	//
+	// 首次 Seek 之后读掉的内容在下一次整理内存（扩容、左移或回绕覆盖等）前仍可向后移动到，整理时释放，不会额外占用内存。
+	Seek(offset int64, whence int) (abs int64, err error)
 	// Write
 	// 写入
 	Write(p []byte) (n int, err error)
@@ -64,6 +100,9 @@ type Buffer interface {
 	// ReadFromLimited
 	// 从一流里读取 n 个字节
 	ReadFromLimited(r io.Reader, n int) (nn int, err error)
+	// CopyFromReader
+	// 从一流里读取恰好 n 个字节，只有全部读取成功才写入，否则缓冲不变且 nn 为 0。
+	CopyFromReader(r io.Reader, n int) (nn int, err error)
 	// WriteTo
 	// 全部写入一个流
 	WriteTo(w io.Writer) (n int64, err error)
@@ -73,6 +112,25 @@ type Buffer interface {
 	// CloneBytes
 	// 复制字节，非读操作。
 	CloneBytes() []byte
+	// Bytes
+	// 可读内容，不复制，非读操作。返回的切片与缓冲共享内存，下一次写入、读取等变更后失效。
+	Bytes() []byte
+	// UnsafeString
+	// 以字符串查看可读内容，不复制，非读操作。字符串与缓冲共享内存，下一次变更后不可再使用。
+	UnsafeString() string
+	// Grow
+	// 预留至少 n 字节的可写空间，之后写入 n 字节不会再扩容。固定容量时空间不足返回 ErrBufferFull。
+	Grow(n int) (err error)
+	// Available
+	// 无需扩容即可连续写入的字节数，Borrowing 时为 0。
+	Available() (n int)
+	// AvailableBuffer
+	// 返回长度为 0、容量为 Available() 的切片，追加内容后以 Commit 提交，或作为 Write 的参数。
+	// 切片在下一次写入前有效。
+	AvailableBuffer() (p []byte)
+	// Commit
+	// 把 AvailableBuffer 中已追加的前 n 个字节提交为可读内容，n 超出 Available() 时返回 ErrInvalidOffset。
+	Commit(n int) (err error)
 	// Borrow
 	// 借出
 	Borrow(size int) (p []byte, err error)
@@ -85,6 +143,111 @@ type Buffer interface {
 	// Reset
 	// 重置，当 Borrowing 时，无法重置。
 	Reset() bool
+	// WriteUvarint
+	// 写入 uvarint
+	WriteUvarint(v uint64) (err error)
+	// ReadUvarint
+	// 读取 uvarint，不完整时不读掉。
+	ReadUvarint() (v uint64, err error)
+	// WriteZigzagVarint
+	// 以 zigzag 编码写入有符号 varint（protobuf sint32/sint64）
+	WriteZigzagVarint(v int64) (err error)
+	// ReadZigzagVarint
+	// 读取 zigzag 编码的有符号 varint，不完整时不读掉。
+	ReadZigzagVarint() (v int64, err error)
+	// WriteVarBytes
+	// 写入以 uvarint 长度为前缀的字节
+	WriteVarBytes(p []byte) (err error)
+	// ReadVarBytes
+	// 读取以 uvarint 长度为前缀的字节，不完整时不读掉。
+	ReadVarBytes() (p []byte, err error)
+	// WriteVarString
+	// 写入以 uvarint 长度为前缀的字符串
+	WriteVarString(s string) (err error)
+	// ReadVarString
+	// 读取以 uvarint 长度为前缀的字符串，不完整时不读掉。返回的字符串不与缓冲共享内存。
+	ReadVarString() (s string, err error)
+	// WriteMap
+	// 写入字符串映射，键按升序写入以保证编码确定。
+	WriteMap(m map[string]string) (err error)
+	// ReadMap
+	// 读取字符串映射，不完整时不读掉。
+	ReadMap() (m map[string]string, err error)
+	// WriteSlice
+	// 写入字节切片数组，每个元素以 uvarint 长度为前缀。
+	WriteSlice(elems [][]byte) (err error)
+	// ReadSlice
+	// 读取字节切片数组，不完整时不读掉。
+	ReadSlice() (elems [][]byte, err error)
+	// WriteTimestamp
+	// 以大端 8 字节 Unix 纳秒写入时间，零值时间写入 math.MinInt64。
+	WriteTimestamp(t time.Time) (err error)
+	// ReadTimestamp
+	// 读取大端 8 字节 Unix 纳秒时间，返回 UTC 时间，不完整时不读掉。
+	ReadTimestamp() (t time.Time, err error)
+	// WriteUint16BE
+	// 以大端写入 2 字节无符号整数
+	WriteUint16BE(v uint16) (err error)
+	// ReadUint16BE
+	// 读取大端 2 字节无符号整数，不完整时不读掉。
+	ReadUint16BE() (v uint16, err error)
+	// WriteUint16LE
+	// 以小端写入 2 字节无符号整数
+	WriteUint16LE(v uint16) (err error)
+	// ReadUint16LE
+	// 读取小端 2 字节无符号整数，不完整时不读掉。
+	ReadUint16LE() (v uint16, err error)
+	// WriteUint32BE
+	// 以大端写入 4 字节无符号整数
+	WriteUint32BE(v uint32) (err error)
+	// ReadUint32BE
+	// 读取大端 4 字节无符号整数，不完整时不读掉。
+	ReadUint32BE() (v uint32, err error)
+	// WriteUint32LE
+	// 以小端写入 4 字节无符号整数
+	WriteUint32LE(v uint32) (err error)
+	// ReadUint32LE
+	// 读取小端 4 字节无符号整数，不完整时不读掉。
+	ReadUint32LE() (v uint32, err error)
+	// WriteUint64BE
+	// 以大端写入 8 字节无符号整数
+	WriteUint64BE(v uint64) (err error)
+	// ReadUint64BE
+	// 读取大端 8 字节无符号整数，不完整时不读掉。
+	ReadUint64BE() (v uint64, err error)
+	// WriteUint64LE
+	// 以小端写入 8 字节无符号整数
+	WriteUint64LE(v uint64) (err error)
+	// ReadUint64LE
+	// 读取小端 8 字节无符号整数，不完整时不读掉。
+	ReadUint64LE() (v uint64, err error)
+	// EncodeBase32
+	// 以 base32 编码可读内容并替换之，enc 为 nil 时使用 base32.StdEncoding。
+	EncodeBase32(enc *base32.Encoding) (err error)
+	// DecodeBase32
+	// 以 base32 解码可读内容并替换之，enc 为 nil 时使用 base32.StdEncoding。失败时可读内容不变。
+	DecodeBase32(enc *base32.Encoding) (err error)
+	// EncodeURL
+	// 以 URL 安全的 base64 编码可读内容并替换之，padded 决定是否填充 '='。
+	EncodeURL(padded bool) (err error)
+	// DecodeURL
+	// 以 URL 安全的 base64 解码可读内容并替换之，padded 决定是否需要 '=' 填充。失败时可读内容不变。
+	DecodeURL(padded bool) (err error)
+	// WriteBinaryFixed
+	// 以 encoding/binary 大端写入定长数据，如定长字段的结构体。
+	WriteBinaryFixed(v interface{}) (err error)
+	// ReadBinaryFixed
+	// 以 encoding/binary 大端读取定长数据，不完整时不读掉。
+	ReadBinaryFixed(v interface{}) (err error)
+	// WriteGob
+	// 以 gob 编码写入 v，每次写入均包含类型信息。
+	WriteGob(v interface{}) (err error)
+	// ReadGob
+	// 以 gob 解码一个由 WriteGob 写入的值，只读掉解码所用的字节，失败时不读掉。
+	ReadGob(v interface{}) (err error)
+	// ReadOnly
+	// 只读视图，与原 Buffer 共享内容与读位置，任何写入均返回 ErrReadOnly，Reset 返回 false。
+	ReadOnly() Buffer
 }
 
 const maxInt = int(^uint(0) >> 1)
@@ -93,12 +256,34 @@ var (
 	ErrTooLarge           = errors.New("bytebuffers.Buffer: too large")
 	ErrWriteWhenBorrowing = errors.New("bytebuffers.Buffer: cannot write when borrowing, cause prev borrowed was not return, please call Return() after the area was used")
 	ErrBorrowZero         = errors.New("bytebuffers.Buffer: cannot borrow zero")
+	ErrVarintOverflow     = errors.New("bytebuffers.Buffer: varint overflows a 64-bit integer")
+	ErrBufferFull         = errors.New("bytebuffers.Buffer: buffer is full")
+	ErrUnreadByte         = errors.New("bytebuffers.Buffer: UnreadByte: previous operation was not a successful ReadByte")
+	ErrUnreadRune         = errors.New("bytebuffers.Buffer: UnreadRune: previous operation was not a successful ReadRune")
+	ErrInvalidOffset      = errors.New("bytebuffers.Buffer: invalid offset")
+	ErrInvalidWhence      = errors.New("bytebuffers.Buffer: invalid whence")
 )
 
 func adjustBufferSize(size int, base int) int {
 	return int(math.Ceil(float64(size)/float64(base)) * float64(base))
 }
 
+// peekFull
+// 查看恰好 n 个字节，无可读时返回 io.EOF，不足时返回 io.ErrUnexpectedEOF。
+func peekFull(buf Buffer, n int) (p []byte, err error) {
+	bLen := buf.Len()
+	if bLen == 0 {
+		err = io.EOF
+		return
+	}
+	if bLen < n {
+		err = io.ErrUnexpectedEOF
+		return
+	}
+	p = buf.Peek(n)
+	return
+}
+
 func NewBuffer() Buffer {
 	return NewBufferWithCapacityHint(minHint)
 }
@@ -120,12 +305,43 @@ func NewBufferWithCapacityHint(hint int) Buffer {
 	return b
 }
 
+// NewFixedBuffer
+// 创建一个容量固定为 n 的 Buffer，永不扩容。
+//
+// 空间不足时 Write、WriteString、ReadFrom 等写入能写下的部分，返回已写入的数量与 ErrBufferFull。
+// 固定容量的 Buffer 不会被 Release 回收。
+func NewFixedBuffer(n int) Buffer {
+	if n <= 0 {
+		n = minHint
+	}
+	b := &buffer{
+		bufferFields: bufferFields{
+			h:     n,
+			c:     n,
+			fixed: true,
+		},
+		b: make([]byte, n),
+	}
+	return b
+}
+
 type bufferFields struct {
-	h int
-	c int
-	r int
-	w int
-	a int
+	h     int
+	c     int
+	r     int
+	w     int
+	a     int
+	fixed bool
+	// state of the last ReadByte or ReadRune, ur is the read index after it plus 1
+	// and is cleared by any other read or write, us is the size of the rune and 0 after ReadByte
+	ur int
+	uw int
+	us int
+	ub [utf8.UTFMax]byte
+	// seek origin plus 1, content read after it is kept until the next compaction,
+	// sb is the position of the origin, the bytes released before it since the first Seek
+	s  int
+	sb int
 }
 
 type buffer struct {
@@ -154,6 +370,18 @@ func (buf *buffer) Peek(n int) (p []byte) {
 	return
 }
 
+func (buf *buffer) PeekAt(off int, n int) (p []byte) {
+	bLen := buf.Len()
+	if off < 0 || n < 1 || off >= bLen {
+		return
+	}
+	if n > bLen-off {
+		n = bLen - off
+	}
+	p = buf.b[buf.r+off : buf.r+off+n]
+	return
+}
+
 func (buf *buffer) CloneBytes() []byte {
 	p := buf.Peek(buf.Len())
 	if len(p) == 0 {
@@ -164,7 +392,19 @@ func (buf *buffer) CloneBytes() []byte {
 	return c
 }
 
+func (buf *buffer) Bytes() []byte {
+	return buf.b[buf.r:buf.w]
+}
+
+func (buf *buffer) UnsafeString() string {
+	if buf.r == buf.w {
+		return ""
+	}
+	return unsafe.String(&buf.b[buf.r], buf.w-buf.r)
+}
+
 func (buf *buffer) Next(n int) (p []byte, err error) {
+	buf.ur = 0
 	if n < 1 {
 		return
 	}
@@ -184,7 +424,29 @@ func (buf *buffer) Next(n int) (p []byte, err error) {
 	return
 }
 
+func (buf *buffer) NextSlice(n int) (p []byte, err error) {
+	buf.ur = 0
+	if n < 1 {
+		return
+	}
+	bLen := buf.Len()
+	if bLen == 0 {
+		err = io.EOF
+		return
+	}
+	if n > bLen {
+		n = bLen
+	}
+	end := buf.r + n
+	p = buf.b[buf.r:end:end] // appending to p must not overwrite the buffer
+	buf.r = end
+
+	buf.shrink()
+	return
+}
+
 func (buf *buffer) Read(p []byte) (n int, err error) {
+	buf.ur = 0
 	if len(p) == 0 {
 		return
 	}
@@ -203,6 +465,7 @@ func (buf *buffer) Read(p []byte) (n int, err error) {
 }
 
 func (buf *buffer) ReadByte() (b byte, err error) {
+	buf.ur = 0
 	bLen := buf.Len()
 	if bLen == 0 {
 		err = io.EOF
@@ -211,10 +474,34 @@ func (buf *buffer) ReadByte() (b byte, err error) {
 	b = buf.b[buf.r]
 	buf.r++
 	buf.shrink()
+	buf.ur, buf.uw, buf.us, buf.ub[0] = buf.r+1, buf.w, 0, b
 	return
 }
 
+func (buf *buffer) UnreadByte() (err error) {
+	if buf.ur != buf.r+1 || buf.uw != buf.w || buf.us != 0 || buf.Borrowing() {
+		err = ErrUnreadByte
+		return
+	}
+	buf.unread(buf.ub[:1])
+	return
+}
+
+// unread
+// 把最近一次读取的 p 放回可读内容之前。
+func (buf *buffer) unread(p []byte) {
+	buf.ur = 0
+	if buf.r == 0 { // shrunk after the last read
+		buf.w = copy(buf.b, p)
+		buf.a = buf.w
+		return
+	}
+	buf.r -= len(p)
+	copy(buf.b[buf.r:], p)
+}
+
 func (buf *buffer) ReadBytes(delim byte) (line []byte, err error) {
+	buf.ur = 0
 	bLen := buf.Len()
 	if bLen == 0 {
 		err = io.EOF
@@ -247,6 +534,7 @@ func (buf *buffer) Index(delim byte) (i int) {
 }
 
 func (buf *buffer) Discard(n int) {
+	buf.ur = 0
 	if n < 1 {
 		return
 	}
@@ -262,7 +550,24 @@ func (buf *buffer) Discard(n int) {
 	return
 }
 
+func (buf *buffer) Truncate(n int) (err error) {
+	buf.ur = 0
+	if buf.Borrowing() {
+		err = ErrWriteWhenBorrowing
+		return
+	}
+	if n < 0 || n > buf.Len() {
+		err = ErrInvalidOffset
+		return
+	}
+	buf.w = buf.r + n
+	buf.a = buf.w
+	buf.shrink()
+	return
+}
+
 func (buf *buffer) Write(p []byte) (n int, err error) {
+	buf.ur = 0
 	if buf.Borrowing() {
 		err = ErrWriteWhenBorrowing
 		return
@@ -273,7 +578,7 @@ func (buf *buffer) Write(p []byte) (n int, err error) {
 	}
 
 	if buf.c-buf.w < pLen {
-		if err = buf.grow(pLen); err != nil {
+		if err = buf.grow(pLen); err != nil && !errors.Is(err, ErrBufferFull) {
 			return
 		}
 	}
@@ -285,6 +590,7 @@ func (buf *buffer) Write(p []byte) (n int, err error) {
 }
 
 func (buf *buffer) WriteString(s string) (n int, err error) {
+	buf.ur = 0
 	if s == "" {
 		return
 	}
@@ -293,6 +599,7 @@ func (buf *buffer) WriteString(s string) (n int, err error) {
 }
 
 func (buf *buffer) WriteByte(c byte) (err error) {
+	buf.ur = 0
 	if buf.Borrowing() {
 		err = ErrWriteWhenBorrowing
 		return
@@ -309,6 +616,7 @@ func (buf *buffer) WriteByte(c byte) (err error) {
 }
 
 func (buf *buffer) Set(p []byte) (err error) {
+	buf.ur = 0
 	if buf.Borrowing() {
 		err = ErrWriteWhenBorrowing
 		return
@@ -335,6 +643,7 @@ func (buf *buffer) Set(p []byte) (err error) {
 }
 
 func (buf *buffer) SetString(s string) (err error) {
+	buf.ur = 0
 	if buf.Borrowing() {
 		err = ErrWriteWhenBorrowing
 		return
@@ -357,6 +666,7 @@ func (buf *buffer) ReadFrom(r io.Reader) (n int64, err error) {
 }
 
 func (buf *buffer) ReadFromWithHint(r io.Reader, hint int) (n int64, err error) {
+	buf.ur = 0
 	if buf.Borrowing() {
 		err = ErrWriteWhenBorrowing
 		return
@@ -368,6 +678,9 @@ func (buf *buffer) ReadFromWithHint(r io.Reader, hint int) (n int64, err error)
 		shift := bits.Len(uint(hint) - 1)
 		hint = 1 << shift
 	}
+	if buf.fixed { // only left shift
+		hint = 1
+	}
 	for {
 		if buf.w == buf.c {
 			if err = buf.grow(hint); err != nil {
@@ -390,6 +703,7 @@ func (buf *buffer) ReadFromWithHint(r io.Reader, hint int) (n int64, err error)
 }
 
 func (buf *buffer) ReadFromLimited(r io.Reader, n int) (nn int, err error) {
+	buf.ur = 0
 	if buf.Borrowing() {
 		err = ErrWriteWhenBorrowing
 		return
@@ -397,8 +711,14 @@ func (buf *buffer) ReadFromLimited(r io.Reader, n int) (nn int, err error) {
 	if n < 1 {
 		return
 	}
+	var full error
 	if err = buf.grow(n); err != nil {
-		return
+		if !errors.Is(err, ErrBufferFull) {
+			return
+		}
+		// read what fits
+		full, err = err, nil
+		n = buf.c - buf.w
 	}
 	for n > 0 {
 		rn, rErr := r.Read(buf.b[buf.w : buf.w+n])
@@ -408,16 +728,37 @@ func (buf *buffer) ReadFromLimited(r io.Reader, n int) (nn int, err error) {
 		n -= rn
 		if rErr != nil {
 			if errors.Is(rErr, io.EOF) {
-				break
+				return
 			}
 			err = rErr
 			return
 		}
 	}
+	err = full
+	return
+}
+
+func (buf *buffer) CopyFromReader(r io.Reader, n int) (nn int, err error) {
+	buf.ur = 0
+	if n < 1 {
+		return
+	}
+	p, borrowErr := buf.Borrow(n)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	if _, err = io.ReadFull(r, p); err != nil {
+		buf.Return(0)
+		return
+	}
+	nn = n
+	buf.Return(n)
 	return
 }
 
 func (buf *buffer) WriteTo(w io.Writer) (n int64, err error) {
+	buf.ur = 0
 	for buf.r < buf.w {
 		wn, wErr := w.Write(buf.b[buf.r:buf.w])
 		buf.r += wn
@@ -431,6 +772,7 @@ func (buf *buffer) WriteTo(w io.Writer) (n int64, err error) {
 }
 
 func (buf *buffer) WriteToLimited(w io.Writer, n int) (nn int, err error) {
+	buf.ur = 0
 	if bLen := buf.Len(); bLen < n {
 		n = bLen
 	}
@@ -451,7 +793,49 @@ func (buf *buffer) Borrowing() bool {
 	return buf.a != buf.w
 }
 
+func (buf *buffer) Grow(n int) (err error) {
+	buf.ur = 0
+	if buf.Borrowing() {
+		err = ErrWriteWhenBorrowing
+		return
+	}
+	if buf.c-buf.w < n {
+		err = buf.grow(n)
+	}
+	return
+}
+
+func (buf *buffer) Available() int {
+	if buf.Borrowing() {
+		return 0
+	}
+	return buf.c - buf.w
+}
+
+func (buf *buffer) AvailableBuffer() []byte {
+	if buf.Borrowing() {
+		return nil
+	}
+	return buf.b[buf.w:buf.w]
+}
+
+func (buf *buffer) Commit(n int) (err error) {
+	buf.ur = 0
+	if buf.Borrowing() {
+		err = ErrWriteWhenBorrowing
+		return
+	}
+	if n < 0 || n > buf.c-buf.w {
+		err = ErrInvalidOffset
+		return
+	}
+	buf.w += n
+	buf.a = buf.w
+	return
+}
+
 func (buf *buffer) Borrow(size int) (p []byte, err error) {
+	buf.ur = 0
 	if buf.Borrowing() {
 		err = ErrWriteWhenBorrowing
 		return
@@ -473,6 +857,7 @@ func (buf *buffer) Borrow(size int) (p []byte, err error) {
 }
 
 func (buf *buffer) Return(used int) {
+	buf.ur = 0
 	if buf.a == buf.w {
 		return
 	}
@@ -494,16 +879,22 @@ func (buf *buffer) Reset() bool {
 		buf.r = 0
 		buf.w = 0
 		buf.a = 0
+		buf.ur = 0
+		buf.s = 0
+		buf.sb = 0
 	}
 	return ok
 }
 
 func (buf *buffer) shrink() bool {
-	ok := buf.r == buf.w && buf.a == buf.w
+	ok := buf.r == buf.w && buf.a == buf.w && (buf.s == 0 || buf.s == buf.r+1)
 	if ok {
 		buf.r = 0
 		buf.w = 0
 		buf.a = 0
+		if buf.s > 0 {
+			buf.s = 1
+		}
 	}
 	return ok
 }
@@ -528,18 +919,24 @@ func (buf *buffer) grow(n int) (err error) {
 	}
 
 	buf.shrink()
-	bLen := buf.Len()
+	start := buf.r // the content read after the seek origin is released
+	bLen := buf.w - start
 	bCap := buf.Capacity()
 
 	if remains := bCap - bLen; n <= remains { // n <= remains then try to left shift
-		if buf.r == 0 { // when read index is 0 then do not left shift
+		if start == 0 { // when read index is 0 then do not left shift
 			return
 		}
 		// has data then left shift
-		copy(buf.b, buf.b[buf.r:buf.w])
-		buf.r = 0
-		buf.w = bLen
-		buf.a = buf.w
+		copy(buf.b, buf.b[start:buf.w])
+		buf.moved(start)
+		return
+	} else if buf.fixed { // never reallocate, but make all remains writable
+		if start > 0 {
+			copy(buf.b, buf.b[start:buf.w])
+			buf.moved(start)
+		}
+		err = ErrBufferFull
 		return
 	} else { // sub n
 		n = n - remains
@@ -554,12 +951,22 @@ func (buf *buffer) grow(n int) (err error) {
 	adjustedSize := adjustBufferSize(n, buf.h)
 	nb := make([]byte, adjustedSize+bCap)
 	if bLen > 0 { // has data then copy
-		copy(nb, buf.b[buf.r:buf.w])
+		copy(nb, buf.b[start:buf.w])
 	}
-	buf.r = 0
-	buf.w = bLen
-	buf.a = buf.w
+	buf.moved(start)
 	buf.c += adjustedSize
 	buf.b = nb
 	return
 }
+
+// moved
+// 可读内容从 start 移动到 0 之后更新各位置，Seek 的起点随之移到读位置。
+func (buf *buffer) moved(start int) {
+	if buf.s > 0 {
+		buf.sb += start - (buf.s - 1)
+		buf.s = 1
+	}
+	buf.r -= start
+	buf.w -= start
+	buf.a = buf.w
+}
@@ -3,6 +3,10 @@ package bytebuffers_test
 import (
 	"bytes"
 	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -118,6 +122,68 @@ func TestBuffer_ReadFromLimited(t *testing.T) {
 	t.Log(rn, buf.Len(), rn == 10, string(buf.Peek(10)))
 }
 
+type failingReader struct {
+	r     io.Reader
+	limit int
+}
+
+func (f *failingReader) Read(p []byte) (n int, err error) {
+	if f.limit <= 0 {
+		return 0, errors.New("failing reader")
+	}
+	if len(p) > f.limit {
+		p = p[:f.limit]
+	}
+	n, err = f.r.Read(p)
+	f.limit -= n
+	return
+}
+
+func TestBuffer_CopyFromReader(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	_, _ = buf.Write([]byte("head"))
+
+	src := &failingReader{r: bytes.NewReader([]byte("0123456789")), limit: 5}
+	nn, err := buf.CopyFromReader(src, 10)
+	if err == nil || nn != 0 {
+		t.Fatal("expected error", nn, err)
+	}
+	if buf.Len() != 4 || buf.Borrowing() {
+		t.Fatal("buffer changed on failure", nn, buf.Len())
+	}
+
+	nn, err = buf.CopyFromReader(bytes.NewReader([]byte("01234")), 10)
+	if !errors.Is(err, io.ErrUnexpectedEOF) || nn != 0 {
+		t.Fatal("expected unexpected EOF, got", nn, err)
+	}
+	if buf.Len() != 4 {
+		t.Fatal("buffer changed on short read", nn, buf.Len())
+	}
+
+	nn, err = buf.CopyFromReader(bytes.NewReader([]byte("0123456789")), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nn != 10 || string(buf.Peek(buf.Len())) != "head0123456789" {
+		t.Fatal("unexpected content", nn, string(buf.Peek(buf.Len())))
+	}
+
+	buffers := []bytebuffers.Buffer{
+		bytebuffers.NewConcurrentBuffer(),
+		bytebuffers.NewRingBuffer(8),
+		bytebuffers.NewChainBuffer(8),
+	}
+	for _, other := range buffers {
+		_, _ = other.Write([]byte("head"))
+		if nn, err = other.CopyFromReader(bytes.NewReader([]byte("01234")), 10); err == nil || nn != 0 {
+			t.Fatal("expected error with nothing copied", nn, err)
+		}
+		if other.Len() != 4 || other.Borrowing() {
+			t.Fatal("buffer changed on short read", other.Len())
+		}
+	}
+}
+
 func TestBuffer_WriteToLimited(t *testing.T) {
 	buf := bytebuffers.Acquire()
 	defer bytebuffers.Release(buf)
@@ -161,6 +227,341 @@ func TestBuffer_Set(t *testing.T) {
 	t.Log(string(p), string(p) == "abdce")
 }
 
+func TestBuffer_UnreadByte(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	var scanner io.ByteScanner = buf
+	if err := scanner.UnreadByte(); !errors.Is(err, bytebuffers.ErrUnreadByte) {
+		t.Fatal("expected unread byte error, got", err)
+	}
+	_, _ = buf.Write(binary.AppendUvarint(nil, 300))
+	_, _ = buf.WriteString("ab")
+	if v, err := binary.ReadUvarint(scanner); err != nil || v != 300 {
+		t.Fatal("unexpected uvarint", v, err)
+	}
+	b, _ := buf.ReadByte()
+	if err := buf.UnreadByte(); err != nil || buf.Len() != 2 {
+		t.Fatal("unexpected unread", buf.Len(), err)
+	}
+	if err := buf.UnreadByte(); !errors.Is(err, bytebuffers.ErrUnreadByte) {
+		t.Fatal("expected unread byte error, got", err)
+	}
+	// unread after the last byte was read
+	_, _ = buf.Next(1)
+	if b, _ = buf.ReadByte(); b != 'b' || buf.Len() != 0 {
+		t.Fatal("unexpected byte", b)
+	}
+	if err := buf.UnreadByte(); err != nil || string(buf.CloneBytes()) != "b" {
+		t.Fatal("unexpected unread", string(buf.CloneBytes()), err)
+	}
+	// writes invalidate
+	_, _ = buf.ReadByte()
+	_ = buf.WriteByte('c')
+	if err := buf.UnreadByte(); !errors.Is(err, bytebuffers.ErrUnreadByte) {
+		t.Fatal("expected unread byte error, got", err)
+	}
+}
+
+func TestBuffer_UnreadByteAfterOtherOps(t *testing.T) {
+	buffers := []bytebuffers.Buffer{
+		bytebuffers.NewBuffer(),
+		bytebuffers.NewConcurrentBuffer(),
+		bytebuffers.NewRingBuffer(8),
+		bytebuffers.NewChainBuffer(4),
+	}
+	for _, buf := range buffers {
+		_, _ = buf.WriteString("ab")
+		if b, err := buf.ReadByte(); err != nil || b != 'a' {
+			t.Fatal("unexpected byte", b, err)
+		}
+		// the read and write positions end up where they were after ReadByte
+		buf.Discard(1)
+		_, _ = buf.WriteString("zq")
+		buf.Discard(1)
+		if err := buf.UnreadByte(); !errors.Is(err, bytebuffers.ErrUnreadByte) {
+			t.Fatalf("%T: expected unread byte error, got %v", buf, err)
+		}
+		if string(buf.CloneBytes()) != "q" {
+			t.Fatalf("%T: unexpected bytes %q", buf, buf.CloneBytes())
+		}
+	}
+}
+
+func TestBuffer_NextSlice(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	_, _ = buf.WriteString("0123456789")
+	p, err := buf.NextSlice(4)
+	if err != nil || string(p) != "0123" || buf.Len() != 6 {
+		t.Fatal("unexpected next slice", string(p), err)
+	}
+	// appending must not overwrite the readable content
+	_ = append(p, 'x')
+	if p, err = buf.NextSlice(10); err != nil || string(p) != "456789" {
+		t.Fatal("unexpected next slice", string(p), err)
+	}
+	if _, err = buf.NextSlice(1); !errors.Is(err, io.EOF) {
+		t.Fatal("expected EOF, got", err)
+	}
+}
+
+func TestBuffer_PeekAt(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	_, _ = buf.WriteString("0123456789")
+	buf.Discard(2)
+	if p := buf.PeekAt(3, 2); string(p) != "56" {
+		t.Fatal("unexpected peek", string(p))
+	}
+	if p := buf.PeekAt(6, 5); string(p) != "89" {
+		t.Fatal("unexpected peek", string(p))
+	}
+	if p := buf.PeekAt(8, 1); p != nil {
+		t.Fatal("unexpected peek", string(p))
+	}
+	if p := buf.PeekAt(-1, 1); p != nil {
+		t.Fatal("unexpected peek", string(p))
+	}
+	if buf.Len() != 8 {
+		t.Fatal("peek at consumed", buf.Len())
+	}
+}
+
+func TestBuffer_Bytes(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	if p := buf.Bytes(); len(p) != 0 {
+		t.Fatal("unexpected bytes", p)
+	}
+	_, _ = buf.WriteString("0123456789")
+	buf.Discard(4)
+	p := buf.Bytes()
+	if string(p) != "456789" {
+		t.Fatal("unexpected bytes", string(p))
+	}
+	// shares memory with the buffer
+	p[0] = 'x'
+	if b, _ := buf.ReadByte(); b != 'x' {
+		t.Fatal("bytes was copied")
+	}
+}
+
+func TestBuffer_UnsafeString(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	if s := buf.UnsafeString(); s != "" {
+		t.Fatal("unexpected string", s)
+	}
+	_, _ = buf.WriteString("Content-Type: text/plain")
+	buf.Discard(14)
+	headers := map[string]int{"text/plain": 1}
+	if v, ok := headers[buf.UnsafeString()]; !ok || v != 1 {
+		t.Fatal("unexpected string", buf.UnsafeString())
+	}
+}
+
+func TestBuffer_Truncate(t *testing.T) {
+	buffers := []bytebuffers.Buffer{
+		bytebuffers.NewBuffer(),
+		bytebuffers.NewConcurrentBuffer(),
+		bytebuffers.NewRingBuffer(8),
+		bytebuffers.NewChainBuffer(4),
+	}
+	for _, buf := range buffers {
+		_, _ = buf.WriteString("xx0123")
+		buf.Discard(2)
+		// a partially serialized record
+		_, _ = buf.WriteString("456789")
+		if err := buf.Truncate(5); err != nil || string(buf.CloneBytes()) != "01234" {
+			t.Fatal("unexpected truncate", string(buf.CloneBytes()), err)
+		}
+		if err := buf.Truncate(6); !errors.Is(err, bytebuffers.ErrInvalidOffset) {
+			t.Fatal("expected invalid offset, got", err)
+		}
+		_, _ = buf.WriteString("ab")
+		if string(buf.CloneBytes()) != "01234ab" {
+			t.Fatal("unexpected bytes", string(buf.CloneBytes()))
+		}
+		if _, err := buf.Borrow(1); err != nil {
+			t.Fatal(err)
+		}
+		if err := buf.Truncate(1); !errors.Is(err, bytebuffers.ErrWriteWhenBorrowing) {
+			t.Fatal("expected write when borrowing, got", err)
+		}
+		buf.Return(0)
+		if err := buf.Truncate(0); err != nil || buf.Len() != 0 {
+			t.Fatal("unexpected truncate", buf.Len(), err)
+		}
+	}
+}
+
+func TestBuffer_Grow(t *testing.T) {
+	buffers := []bytebuffers.Buffer{
+		bytebuffers.NewBuffer(),
+		bytebuffers.NewConcurrentBuffer(),
+		bytebuffers.NewRingBuffer(8),
+		bytebuffers.NewChainBuffer(8),
+	}
+	for _, buf := range buffers {
+		_, _ = buf.WriteString("head")
+		if err := buf.Grow(1000); err != nil {
+			t.Fatal(err)
+		}
+		capacity := buf.Capacity()
+		if capacity < 1004 {
+			t.Fatal("unexpected capacity", capacity)
+		}
+		_, _ = buf.Write(make([]byte, 1000))
+		if buf.Capacity() != capacity || buf.Len() != 1004 {
+			t.Fatal("write after grow reallocated", capacity, buf.Capacity())
+		}
+		if _, err := buf.Borrow(1); err != nil {
+			t.Fatal(err)
+		}
+		if err := buf.Grow(1); !errors.Is(err, bytebuffers.ErrWriteWhenBorrowing) {
+			t.Fatal("expected write when borrowing, got", err)
+		}
+		buf.Return(0)
+		if err := buf.ReadOnly().Grow(1); !errors.Is(err, bytebuffers.ErrReadOnly) {
+			t.Fatal("expected read only, got", err)
+		}
+	}
+
+	fixed := bytebuffers.NewFixedBuffer(8)
+	_, _ = fixed.WriteString("0123")
+	fixed.Discard(2)
+	if err := fixed.Grow(6); err != nil {
+		t.Fatal(err)
+	}
+	if err := fixed.Grow(7); !errors.Is(err, bytebuffers.ErrBufferFull) {
+		t.Fatal("expected buffer full, got", err)
+	}
+}
+
+func TestBuffer_AvailableBuffer(t *testing.T) {
+	buffers := []bytebuffers.Buffer{
+		bytebuffers.NewBuffer(),
+		bytebuffers.NewConcurrentBuffer(),
+		bytebuffers.NewRingBuffer(64),
+		bytebuffers.NewChainBuffer(64),
+		bytebuffers.NewFixedBuffer(64),
+	}
+	for _, buf := range buffers {
+		_, _ = buf.WriteString("n=")
+		if err := buf.Grow(20); err != nil {
+			t.Fatal(err)
+		}
+		available := buf.Available()
+		p := buf.AvailableBuffer()
+		if len(p) != 0 || cap(p) != available || available < 20 {
+			t.Fatal("unexpected available buffer", len(p), cap(p), available)
+		}
+		p = strconv.AppendInt(p, -1234567890, 10)
+		if err := buf.Commit(len(p)); err != nil {
+			t.Fatal(err)
+		}
+		// appending and writing is also allowed
+		_, _ = buf.Write(strconv.AppendBool(buf.AvailableBuffer(), true))
+		if s := string(buf.CloneBytes()); s != "n=-1234567890true" {
+			t.Fatal("unexpected bytes", s)
+		}
+		if err := buf.Commit(buf.Available() + 1); !errors.Is(err, bytebuffers.ErrInvalidOffset) {
+			t.Fatal("expected invalid offset, got", err)
+		}
+		if _, err := buf.Borrow(1); err != nil {
+			t.Fatal(err)
+		}
+		if buf.Available() != 0 || buf.AvailableBuffer() != nil {
+			t.Fatal("available while borrowing")
+		}
+		if err := buf.Commit(0); !errors.Is(err, bytebuffers.ErrWriteWhenBorrowing) {
+			t.Fatal("expected write when borrowing, got", err)
+		}
+		buf.Return(0)
+		if err := buf.ReadOnly().Commit(0); !errors.Is(err, bytebuffers.ErrReadOnly) {
+			t.Fatal("expected read only, got", err)
+		}
+	}
+}
+
+func TestNewFixedBuffer(t *testing.T) {
+	buf := bytebuffers.NewFixedBuffer(8)
+	_, _ = buf.WriteString("012345")
+	buf.Discard(4)
+	// left shift is allowed, growing is not
+	n, err := buf.WriteString("6789abcd")
+	if n != 6 || !errors.Is(err, bytebuffers.ErrBufferFull) {
+		t.Fatal("expected short write", n, err)
+	}
+	if buf.Capacity() != 8 || string(buf.CloneBytes()) != "456789ab" {
+		t.Fatal("unexpected content", buf.Capacity(), string(buf.CloneBytes()))
+	}
+	if err = buf.WriteByte('x'); !errors.Is(err, bytebuffers.ErrBufferFull) {
+		t.Fatal("expected buffer full, got", err)
+	}
+	if _, err = buf.Borrow(1); !errors.Is(err, bytebuffers.ErrBufferFull) {
+		t.Fatal("expected buffer full, got", err)
+	}
+
+	buf.Discard(6)
+	rn, err := buf.ReadFrom(strings.NewReader("0123456789"))
+	if rn != 6 || !errors.Is(err, bytebuffers.ErrBufferFull) {
+		t.Fatal("expected short read", rn, err)
+	}
+	buf.Discard(4)
+	nn, err := buf.ReadFromLimited(strings.NewReader("0123456789"), 6)
+	if nn != 4 || !errors.Is(err, bytebuffers.ErrBufferFull) {
+		t.Fatal("expected short read", nn, err)
+	}
+	if string(buf.CloneBytes()) != "23450123" {
+		t.Fatal("unexpected content", string(buf.CloneBytes()))
+	}
+	buf.Discard(8)
+	if n, err = buf.WriteString("abc"); n != 3 || err != nil {
+		t.Fatal("unexpected write", n, err)
+	}
+
+	// fixed buffers are never pooled
+	bytebuffers.Release(buf)
+	for i := 0; i < 4; i++ {
+		if acquired := bytebuffers.Acquire(); acquired == buf {
+			t.Fatal("fixed buffer was pooled")
+		}
+	}
+}
+
+func TestNewFixedBufferHelpers(t *testing.T) {
+	cases := []struct {
+		name  string
+		write func(buf bytebuffers.Buffer) error
+	}{
+		{"uvarint", func(buf bytebuffers.Buffer) error { return buf.WriteUvarint(300) }},
+		{"zigzag", func(buf bytebuffers.Buffer) error { return buf.WriteZigzagVarint(-65) }},
+		{"var bytes", func(buf bytebuffers.Buffer) error { return buf.WriteVarBytes([]byte("0123456789")) }},
+		{"var string", func(buf bytebuffers.Buffer) error { return buf.WriteVarString(strings.Repeat("s", 130)) }},
+		{"map", func(buf bytebuffers.Buffer) error { return buf.WriteMap(map[string]string{"k": "v", "key": "value"}) }},
+		{"slice", func(buf bytebuffers.Buffer) error { return buf.WriteSlice([][]byte{[]byte("a"), nil, []byte("bc")}) }},
+	}
+	for _, c := range cases {
+		expected := bytebuffers.NewBuffer()
+		if err := c.write(expected); err != nil {
+			t.Fatal(c.name, err)
+		}
+		size := expected.Len()
+		// fits exactly
+		buf := bytebuffers.NewFixedBuffer(size)
+		if err := c.write(buf); err != nil {
+			t.Fatal(c.name, "unexpected error", err)
+		}
+		if !bytes.Equal(buf.CloneBytes(), expected.CloneBytes()) || buf.Capacity() != size {
+			t.Fatal(c.name, "unexpected content", buf.CloneBytes(), buf.Capacity())
+		}
+		// one byte short
+		buf = bytebuffers.NewFixedBuffer(size - 1)
+		if err := c.write(buf); !errors.Is(err, bytebuffers.ErrBufferFull) {
+			t.Fatal(c.name, "expected buffer full, got", err)
+		}
+		if buf.Len() != 0 {
+			t.Fatal(c.name, "partial write", buf.Len())
+		}
+	}
+}
+
 // BenchmarkBuffer
 // BenchmarkBuffer-20    	13220983	        86.01 ns/op	       0 B/op	       0 allocs/op
 func BenchmarkBuffer(b *testing.B) {
@@ -0,0 +1,86 @@
+package bytebuffers
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+const (
+	CANEFFFlag = 0x80000000
+	CANRTRFlag = 0x40000000
+	CANERRFlag = 0x20000000
+	CANSFFMask = 0x000007FF
+	CANEFFMask = 0x1FFFFFFF
+
+	canFrameLen = 16
+	canMaxDLen  = 8
+)
+
+var (
+	ErrCANInvalidID   = errors.New("bytebuffers.CAN: invalid identifier")
+	ErrCANDataTooLong = errors.New("bytebuffers.CAN: data longer than 8 bytes")
+	ErrCANInvalid     = errors.New("bytebuffers.CAN: invalid frame")
+)
+
+// WriteCANFrame
+// 写入 Linux SocketCAN 的 can_frame（16 字节）：can_id（含标志位）、dlc、3 字节填充与 8 字节数据。
+//
+// can_id 按小端写入，与常见平台上 CAN_RAW 套接字的主机字节序一致。
+// 标准帧 id 不超过 11 位，扩展帧不超过 29 位。
+func WriteCANFrame(buf Buffer, id uint32, extended bool, rtr bool, data []byte) (err error) {
+	if len(data) > canMaxDLen {
+		err = ErrCANDataTooLong
+		return
+	}
+	canID := id
+	if extended {
+		if id > CANEFFMask {
+			err = ErrCANInvalidID
+			return
+		}
+		canID |= CANEFFFlag
+	} else if id > CANSFFMask {
+		err = ErrCANInvalidID
+		return
+	}
+	if rtr {
+		canID |= CANRTRFlag
+	}
+	p, borrowErr := buf.Borrow(canFrameLen)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	binary.LittleEndian.PutUint32(p, canID)
+	p[4] = byte(len(data))
+	clear(p[5:canFrameLen])
+	copy(p[8:], data)
+	buf.Return(canFrameLen)
+	return
+}
+
+// ReadCANFrame
+// 读取 Linux SocketCAN 的 can_frame，错误帧或 dlc 大于 8 时返回 ErrCANInvalid，不完整时不读掉。
+func ReadCANFrame(buf Buffer) (id uint32, extended bool, rtr bool, data []byte, err error) {
+	p, peekErr := peekFull(buf, canFrameLen)
+	if peekErr != nil {
+		err = peekErr
+		return
+	}
+	canID := binary.LittleEndian.Uint32(p)
+	dlc := int(p[4])
+	if canID&CANERRFlag != 0 || dlc > canMaxDLen {
+		err = ErrCANInvalid
+		return
+	}
+	extended, rtr = canID&CANEFFFlag != 0, canID&CANRTRFlag != 0
+	if extended {
+		id = canID & CANEFFMask
+	} else {
+		id = canID & CANSFFMask
+	}
+	data = make([]byte, dlc)
+	copy(data, p[8:])
+	buf.Discard(canFrameLen)
+	return
+}
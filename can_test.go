@@ -0,0 +1,56 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestCANFrame(t *testing.T) {
+	cases := []struct {
+		id       uint32
+		extended bool
+		rtr      bool
+		data     []byte
+		canID    uint32
+	}{
+		{0x123, false, false, []byte{0xDE, 0xAD, 0xBE, 0xEF}, 0x00000123},
+		{0x7FF, false, true, nil, 0x400007FF},
+		{0x18DAF110, true, false, []byte{1, 2, 3, 4, 5, 6, 7, 8}, 0x98DAF110},
+		{0x1FFFFFFF, true, true, []byte{}, 0xDFFFFFFF},
+	}
+	buf := bytebuffers.NewBuffer()
+	for _, c := range cases {
+		if err := bytebuffers.WriteCANFrame(buf, c.id, c.extended, c.rtr, c.data); err != nil {
+			t.Fatal(err)
+		}
+		p := buf.Peek(buf.Len())
+		if len(p) != 16 {
+			t.Fatal("unexpected frame length", len(p))
+		}
+		if canID := binary.LittleEndian.Uint32(p); canID != c.canID {
+			t.Fatalf("expected can_id %#x, got %#x", c.canID, canID)
+		}
+		if int(p[4]) != len(c.data) || !bytes.Equal(p[5:8], []byte{0, 0, 0}) || !bytes.Equal(p[8:8+len(c.data)], c.data) {
+			t.Fatal("unexpected frame", p)
+		}
+
+		id, extended, rtr, data, err := bytebuffers.ReadCANFrame(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id != c.id || extended != c.extended || rtr != c.rtr || !bytes.Equal(data, c.data) {
+			t.Fatal("unexpected frame", id, extended, rtr, data)
+		}
+	}
+
+	if err := bytebuffers.WriteCANFrame(buf, 0x800, false, false, nil); !errors.Is(err, bytebuffers.ErrCANInvalidID) {
+		t.Fatal("expected invalid id, got", err)
+	}
+	if err := bytebuffers.WriteCANFrame(buf, 0x1, false, false, make([]byte, 9)); !errors.Is(err, bytebuffers.ErrCANDataTooLong) {
+		t.Fatal("expected data too long, got", err)
+	}
+}
@@ -0,0 +1,792 @@
+package bytebuffers
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"unicode/utf8"
+	"unsafe"
+)
+
+// NewChainBuffer
+// 创建一个由固定大小内存块链接而成的 Buffer，扩容时只追加新块，不会重新分配或复制已有内容。
+//
+// 适用于较大的请求体。Peek 跨越块时会把所需内容合并到一个新块中；Borrow 超过块大小时会分配一个专用块。
+func NewChainBuffer(chunkSize int) Buffer {
+	if chunkSize <= 0 {
+		chunkSize = minHint
+	}
+	buf := &chainBuffer{
+		size: chunkSize,
+	}
+	buf.codec = codec{buf}
+	return buf
+}
+
+type chainChunk struct {
+	b    []byte
+	r    int
+	w    int
+	k    int // start of the read content kept for Seek
+	next *chainChunk
+}
+
+type chainBuffer struct {
+	codec
+	size  int // chunk size
+	head  *chainChunk
+	tail  *chainChunk
+	spare *chainChunk // one released chunk kept for reuse
+	n     int         // readable length
+	c     int         // capacity of all chunks
+	a     int         // borrowed size
+	// state of the last ReadByte or ReadRune, uh is cleared by any other read or write,
+	// us is the size of the rune and 0 after ReadByte
+	uh *chainChunk
+	ur int
+	un int
+	us int
+	ub [utf8.UTFMax]byte
+	// state of Seek, sp is the position of the read index since the first Seek,
+	// seen holds the chunks read since then with the last read first, until the next compaction
+	seeking bool
+	sp      int
+	seen    *chainChunk
+}
+
+func (buf *chainBuffer) Len() int { return buf.n }
+
+func (buf *chainBuffer) Capacity() int { return buf.c }
+
+func (buf *chainBuffer) CapacityHint() int { return buf.size }
+
+// newChunk
+// 分配一个至少 size 大小的块，标准大小时优先复用 spare。
+func (buf *chainBuffer) newChunk(size int) (c *chainChunk) {
+	if size <= buf.size {
+		if c = buf.spare; c != nil {
+			buf.spare = nil
+		} else {
+			c = &chainChunk{b: make([]byte, buf.size)}
+		}
+	} else {
+		c = &chainChunk{b: make([]byte, size)}
+	}
+	buf.c += len(c.b)
+	return
+}
+
+// release
+// 释放一个已移出链表的块。
+func (buf *chainBuffer) release(c *chainChunk) {
+	buf.c -= len(c.b)
+	if len(c.b) == buf.size && buf.spare == nil {
+		c.r, c.w, c.k, c.next = 0, 0, 0, nil
+		buf.spare = c
+	}
+}
+
+// writable
+// 返回至少有 min 字节空闲空间的尾块，空间不足时追加一个至少 size 大小的新块。
+func (buf *chainBuffer) writable(min int, size int) *chainChunk {
+	if t := buf.tail; t != nil {
+		if t.r == t.w && (!buf.seeking || t.k == t.r) { // empty tail and nothing kept, reuse it from the beginning
+			t.r, t.w, t.k = 0, 0, 0
+		}
+		if len(t.b)-t.w >= min {
+			return t
+		}
+		if t.r == t.w && t == buf.head { // empty and too small, drop it
+			buf.head, buf.tail = nil, nil
+			buf.release(t)
+		}
+	}
+	if size < min {
+		size = min
+	}
+	buf.compact()
+	c := buf.newChunk(size)
+	if buf.tail == nil {
+		buf.head = c
+	} else {
+		buf.tail.next = c
+	}
+	buf.tail = c
+	return c
+}
+
+// trim
+// 移出头部已读完的块，尾块始终保留。Seek 之后仍有保留内容的块移入 seen。
+func (buf *chainBuffer) trim() {
+	for buf.head != buf.tail && buf.head.r == buf.head.w {
+		c := buf.head
+		buf.head = c.next
+		if buf.seeking && c.k < c.w {
+			c.next = buf.seen
+			buf.seen = c
+			continue
+		}
+		buf.release(c)
+	}
+}
+
+// compact
+// 释放 Seek 之后保留的已读内容，在追加新块或 Peek 合并块时调用。
+func (buf *chainBuffer) compact() {
+	for c := buf.seen; c != nil; {
+		next := c.next
+		buf.release(c)
+		c = next
+	}
+	buf.seen = nil
+	if h := buf.head; h != nil {
+		h.k = h.r
+	}
+}
+
+// rewind
+// 把 Seek 之后保留的最后 n 个已读字节放回可读内容之前，n 不超过 kept。
+func (buf *chainBuffer) rewind(n int) {
+	buf.n += n
+	buf.sp -= n
+	for {
+		if h := buf.head; h != nil {
+			k := h.r - h.k
+			if k > n {
+				k = n
+			}
+			h.r -= k
+			if n -= k; n == 0 {
+				return
+			}
+		}
+		c := buf.seen
+		buf.seen = c.next
+		c.r, c.next = c.w, buf.head
+		buf.head = c
+		if buf.tail == nil {
+			buf.tail = c
+		}
+	}
+}
+
+// kept
+// 读位置之前仍可由 Seek 移回的字节数。
+func (buf *chainBuffer) kept() (n int) {
+	if h := buf.head; h != nil {
+		n = h.r - h.k
+	}
+	for c := buf.seen; c != nil; c = c.next {
+		n += c.w - c.k
+	}
+	return
+}
+
+// coalesce
+// 把头部 n 个字节合并到一个新块中，尾块即使读完也保留，以免影响借出的空间。
+func (buf *chainBuffer) coalesce(n int) {
+	size := buf.size
+	if n > size {
+		size = n
+	}
+	nc := buf.newChunk(size)
+	for nc.w < n {
+		c := buf.head
+		k := copy(nc.b[nc.w:n], c.b[c.r:c.w])
+		nc.w += k
+		c.r += k
+		c.k = c.r
+		if c.r == c.w && c != buf.tail {
+			buf.head = c.next
+			buf.release(c)
+		}
+	}
+	nc.next = buf.head
+	buf.head = nc
+	buf.compact()
+}
+
+func (buf *chainBuffer) Peek(n int) (p []byte) {
+	if n < 1 || buf.n == 0 {
+		return
+	}
+	if n > buf.n {
+		n = buf.n
+	}
+	if h := buf.head; h.w-h.r < n {
+		buf.coalesce(n)
+	}
+	h := buf.head
+	p = h.b[h.r : h.r+n]
+	return
+}
+
+func (buf *chainBuffer) CloneBytes() []byte {
+	if buf.n == 0 {
+		return nil
+	}
+	p := make([]byte, buf.n)
+	n := 0
+	for c := buf.head; c != nil; c = c.next {
+		n += copy(p[n:], c.b[c.r:c.w])
+	}
+	return p
+}
+
+func (buf *chainBuffer) Next(n int) (p []byte, err error) {
+	if n < 1 {
+		return
+	}
+	if buf.n == 0 {
+		err = io.EOF
+		return
+	}
+	if n > buf.n {
+		n = buf.n
+	}
+	p = make([]byte, n)
+	_, _ = buf.Read(p)
+	return
+}
+
+func (buf *chainBuffer) Discard(n int) {
+	buf.uh = nil
+	if n < 1 || buf.n == 0 {
+		return
+	}
+	if n > buf.n {
+		n = buf.n
+	}
+	buf.n -= n
+	if buf.seeking {
+		buf.sp += n
+	}
+	for n > 0 {
+		c := buf.head
+		k := c.w - c.r
+		if k > n {
+			k = n
+		}
+		c.r += k
+		n -= k
+		buf.trim()
+	}
+}
+
+func (buf *chainBuffer) Truncate(n int) (err error) {
+	buf.uh = nil
+	if buf.Borrowing() {
+		err = ErrWriteWhenBorrowing
+		return
+	}
+	if n < 0 || n > buf.n {
+		err = ErrInvalidOffset
+		return
+	}
+	if n == buf.n {
+		return
+	}
+	c := buf.head
+	for rest := n; ; c = c.next {
+		if size := c.w - c.r; rest > size {
+			rest -= size
+			continue
+		}
+		c.w = c.r + rest
+		break
+	}
+	for next := c.next; next != nil; {
+		following := next.next
+		buf.release(next)
+		next = following
+	}
+	c.next = nil
+	buf.tail = c
+	buf.n = n
+	return
+}
+
+func (buf *chainBuffer) Read(p []byte) (n int, err error) {
+	if len(p) == 0 {
+		return
+	}
+	if buf.n == 0 {
+		err = io.EOF
+		return
+	}
+	for c := buf.head; c != nil && n < len(p); c = c.next {
+		n += copy(p[n:], c.b[c.r:c.w])
+	}
+	buf.Discard(n)
+	return
+}
+
+func (buf *chainBuffer) ReadByte() (b byte, err error) {
+	buf.uh = nil
+	if buf.n == 0 {
+		err = io.EOF
+		return
+	}
+	h := buf.head
+	b = h.b[h.r]
+	buf.Discard(1)
+	buf.uh, buf.ur, buf.un, buf.us, buf.ub[0] = buf.head, buf.head.r, buf.n, 0, b
+	return
+}
+
+func (buf *chainBuffer) UnreadByte() (err error) {
+	if !buf.unreadable() || buf.us != 0 {
+		err = ErrUnreadByte
+		return
+	}
+	buf.unread(buf.ub[:1])
+	return
+}
+
+func (buf *chainBuffer) ReadRune() (r rune, size int, err error) {
+	buf.uh = nil
+	if buf.n == 0 {
+		err = io.EOF
+		return
+	}
+	h := buf.head
+	if c := h.b[h.r]; c < utf8.RuneSelf {
+		r, size = rune(c), 1
+		buf.ub[0] = c
+	} else {
+		p := buf.Peek(utf8.UTFMax)
+		if !utf8.FullRune(p) {
+			err = io.ErrUnexpectedEOF
+			return
+		}
+		r, size = utf8.DecodeRune(p)
+		copy(buf.ub[:], p[:size])
+	}
+	buf.Discard(size)
+	buf.uh, buf.ur, buf.un, buf.us = buf.head, buf.head.r, buf.n, size
+	return
+}
+
+func (buf *chainBuffer) UnreadRune() (err error) {
+	if !buf.unreadable() || buf.us == 0 {
+		err = ErrUnreadRune
+		return
+	}
+	buf.unread(buf.ub[:buf.us])
+	return
+}
+
+// unreadable
+// 最近一次 ReadByte 或 ReadRune 之后是否没有其它读写。
+func (buf *chainBuffer) unreadable() bool {
+	return buf.uh != nil && buf.uh == buf.head && buf.ur == buf.head.r && buf.un == buf.n
+}
+
+// unread
+// 把最近一次读取的 p 放回可读内容之前，所在的块已释放时在头部插入一个新块。Seek 之后 p 仍保留在块中。
+func (buf *chainBuffer) unread(p []byte) {
+	buf.uh = nil
+	if buf.seeking {
+		buf.rewind(len(p))
+		return
+	}
+	h := buf.head
+	if h == nil || h.r < len(p) {
+		h = buf.newChunk(len(p))
+		h.r, h.w = len(p), len(p)
+		h.next = buf.head
+		buf.head = h
+		if buf.tail == nil {
+			buf.tail = h
+		}
+	}
+	h.r -= len(p)
+	copy(h.b[h.r:], p)
+	buf.n += len(p)
+}
+
+func (buf *chainBuffer) ReadBytes(delim byte) (line []byte, err error) {
+	if buf.n == 0 {
+		err = io.EOF
+		return
+	}
+	n := buf.n
+	if i := buf.Index(delim); i >= 0 {
+		n = i + 1
+	}
+	line, err = buf.Next(n)
+	return
+}
+
+func (buf *chainBuffer) Index(delim byte) (i int) {
+	offset := 0
+	for c := buf.head; c != nil; c = c.next {
+		if i = bytes.IndexByte(c.b[c.r:c.w], delim); i >= 0 {
+			i += offset
+			return
+		}
+		offset += c.w - c.r
+	}
+	i = -1
+	return
+}
+
+func (buf *chainBuffer) ReadAt(p []byte, off int64) (n int, err error) {
+	if off < 0 {
+		err = ErrInvalidOffset
+		return
+	}
+	if off >= int64(buf.n) {
+		err = io.EOF
+		return
+	}
+	o := int(off)
+	for c := buf.head; c != nil && n < len(p); c = c.next {
+		seg := c.b[c.r:c.w]
+		if o >= len(seg) {
+			o -= len(seg)
+			continue
+		}
+		n += copy(p[n:], seg[o:])
+		o = 0
+	}
+	if n < len(p) {
+		err = io.EOF
+	}
+	return
+}
+
+func (buf *chainBuffer) WriteAt(p []byte, off int64) (n int, err error) {
+	buf.uh = nil
+	if off < 0 {
+		err = ErrInvalidOffset
+		return
+	}
+	if off > int64(maxInt-len(p)) {
+		err = ErrTooLarge
+		return
+	}
+	o := int(off)
+	inplace := len(p)
+	if end := o + len(p); end > buf.n {
+		if buf.Borrowing() {
+			err = ErrWriteWhenBorrowing
+			return
+		}
+		for gap := o - buf.n; gap > 0; {
+			t := buf.writable(1, buf.size)
+			k := len(t.b) - t.w
+			if k > gap {
+				k = gap
+			}
+			clear(t.b[t.w : t.w+k])
+			t.w += k
+			buf.n += k
+			gap -= k
+		}
+		inplace = buf.n - o
+	}
+	// overwrite the readable part, then append the rest
+	rest := o
+	for c := buf.head; c != nil && n < inplace; c = c.next {
+		seg := c.b[c.r:c.w]
+		if rest >= len(seg) {
+			rest -= len(seg)
+			continue
+		}
+		n += copy(seg[rest:], p[n:inplace])
+		rest = 0
+	}
+	if n < len(p) {
+		wn, wErr := buf.Write(p[n:])
+		n += wn
+		err = wErr
+	}
+	return
+}
+
+// Seek
+// 已读的内容保留在块中，追加新块或 Peek 合并块时释放，之后不可再向后移动到其中。
+func (buf *chainBuffer) Seek(offset int64, whence int) (abs int64, err error) {
+	buf.uh = nil
+	if !buf.seeking {
+		buf.seeking = true
+		for c := buf.head; c != nil; c = c.next {
+			c.k = c.r
+		}
+	}
+	cur := int64(buf.sp)
+	if abs, err = seekTo(offset, whence, cur-int64(buf.kept()), cur, cur+int64(buf.n)); err != nil {
+		return
+	}
+	if back := int(cur - abs); back > 0 {
+		buf.rewind(back)
+		return
+	}
+	buf.Discard(int(abs - cur))
+	return
+}
+
+func (buf *chainBuffer) Write(p []byte) (n int, err error) {
+	buf.uh = nil
+	if buf.Borrowing() {
+		err = ErrWriteWhenBorrowing
+		return
+	}
+	if len(p) == 0 {
+		return
+	}
+	if len(p) > maxInt-buf.n {
+		err = ErrTooLarge
+		return
+	}
+	for n < len(p) {
+		t := buf.writable(1, buf.size)
+		wn := copy(t.b[t.w:], p[n:])
+		t.w += wn
+		buf.n += wn
+		n += wn
+	}
+	return
+}
+
+func (buf *chainBuffer) WriteByte(c byte) (err error) {
+	_, err = buf.Write([]byte{c})
+	return
+}
+
+func (buf *chainBuffer) WriteString(s string) (n int, err error) {
+	if s == "" {
+		return
+	}
+	return buf.Write(unsafe.Slice(unsafe.StringData(s), len(s)))
+}
+
+func (buf *chainBuffer) Set(p []byte) (err error) {
+	seeking, sp := buf.seeking, buf.sp
+	if !buf.Reset() {
+		err = ErrWriteWhenBorrowing
+		return
+	}
+	buf.seeking, buf.sp = seeking, sp
+	_, err = buf.Write(p)
+	return
+}
+
+func (buf *chainBuffer) SetString(s string) (err error) {
+	err = buf.Set(unsafe.Slice(unsafe.StringData(s), len(s)))
+	return
+}
+
+func (buf *chainBuffer) ReadFrom(r io.Reader) (n int64, err error) {
+	n, err = buf.ReadFromWithHint(r, 1)
+	return
+}
+
+func (buf *chainBuffer) ReadFromWithHint(r io.Reader, hint int) (n int64, err error) {
+	buf.uh = nil
+	if buf.Borrowing() {
+		err = ErrWriteWhenBorrowing
+		return
+	}
+	for {
+		t := buf.writable(1, hint)
+		rn, rErr := r.Read(t.b[t.w:])
+		t.w += rn
+		buf.n += rn
+		n += int64(rn)
+		if rErr != nil {
+			if errors.Is(rErr, io.EOF) {
+				break
+			}
+			err = rErr
+			return
+		}
+	}
+	return
+}
+
+func (buf *chainBuffer) ReadFromLimited(r io.Reader, n int) (nn int, err error) {
+	buf.uh = nil
+	if buf.Borrowing() {
+		err = ErrWriteWhenBorrowing
+		return
+	}
+	if n < 1 {
+		return
+	}
+	if n > maxInt-buf.n {
+		err = ErrTooLarge
+		return
+	}
+	for nn < n {
+		t := buf.writable(1, buf.size)
+		p := t.b[t.w:]
+		if len(p) > n-nn {
+			p = p[:n-nn]
+		}
+		rn, rErr := r.Read(p)
+		t.w += rn
+		buf.n += rn
+		nn += rn
+		if rErr != nil {
+			if errors.Is(rErr, io.EOF) {
+				break
+			}
+			err = rErr
+			return
+		}
+	}
+	return
+}
+
+func (buf *chainBuffer) CopyFromReader(r io.Reader, n int) (nn int, err error) {
+	if n < 1 {
+		return
+	}
+	p, borrowErr := buf.Borrow(n)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	if _, err = io.ReadFull(r, p); err != nil {
+		buf.Return(0)
+		return
+	}
+	nn = n
+	buf.Return(n)
+	return
+}
+
+func (buf *chainBuffer) WriteTo(w io.Writer) (n int64, err error) {
+	for buf.n > 0 {
+		h := buf.head
+		wn, wErr := w.Write(h.b[h.r:h.w])
+		buf.Discard(wn)
+		n += int64(wn)
+		if wErr != nil {
+			err = wErr
+			return
+		}
+	}
+	return
+}
+
+func (buf *chainBuffer) WriteToLimited(w io.Writer, n int) (nn int, err error) {
+	if n > buf.n {
+		n = buf.n
+	}
+	for nn < n {
+		h := buf.head
+		p := h.b[h.r:h.w]
+		if len(p) > n-nn {
+			p = p[:n-nn]
+		}
+		wn, wErr := w.Write(p)
+		buf.Discard(wn)
+		nn += wn
+		if wErr != nil {
+			err = wErr
+			return
+		}
+	}
+	return
+}
+
+func (buf *chainBuffer) Borrowing() bool {
+	return buf.a != 0
+}
+
+// Grow
+// 预留的空间位于尾块中，尾块剩余空间不足时追加一个新块。
+func (buf *chainBuffer) Grow(n int) (err error) {
+	buf.uh = nil
+	if buf.Borrowing() {
+		err = ErrWriteWhenBorrowing
+		return
+	}
+	if n > 0 {
+		buf.writable(n, buf.size)
+	}
+	return
+}
+
+// Available
+// 尾块的剩余空间，没有块时为 0，可先以 Grow 预留。
+func (buf *chainBuffer) Available() int {
+	if buf.Borrowing() || buf.tail == nil {
+		return 0
+	}
+	return len(buf.tail.b) - buf.tail.w
+}
+
+func (buf *chainBuffer) AvailableBuffer() []byte {
+	if buf.Borrowing() || buf.tail == nil {
+		return nil
+	}
+	t := buf.tail
+	return t.b[t.w:t.w]
+}
+
+func (buf *chainBuffer) Commit(n int) (err error) {
+	buf.uh = nil
+	if buf.Borrowing() {
+		err = ErrWriteWhenBorrowing
+		return
+	}
+	if n < 0 || n > buf.Available() {
+		err = ErrInvalidOffset
+		return
+	}
+	if n > 0 {
+		buf.tail.w += n
+		buf.n += n
+	}
+	return
+}
+
+func (buf *chainBuffer) Borrow(size int) (p []byte, err error) {
+	buf.uh = nil
+	if buf.Borrowing() {
+		err = ErrWriteWhenBorrowing
+		return
+	}
+	if size < 1 {
+		err = ErrBorrowZero
+		return
+	}
+	t := buf.writable(size, buf.size)
+	p = t.b[t.w : t.w+size]
+	buf.a = size
+	return
+}
+
+func (buf *chainBuffer) Return(used int) {
+	buf.uh = nil
+	if buf.a == 0 {
+		return
+	}
+	if used < 0 {
+		panic(errors.New("negative used"))
+	}
+	buf.tail.w += used
+	buf.n += used
+	buf.a = 0
+}
+
+func (buf *chainBuffer) Reset() bool {
+	if buf.Borrowing() {
+		return false
+	}
+	for c := buf.head; c != nil; {
+		next := c.next
+		buf.release(c)
+		c = next
+	}
+	buf.head, buf.tail = nil, nil
+	buf.compact()
+	buf.n = 0
+	buf.uh = nil
+	buf.seeking, buf.sp = false, 0
+	return true
+}
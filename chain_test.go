@@ -0,0 +1,186 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestChainBuffer(t *testing.T) {
+	buf := bytebuffers.NewChainBuffer(4)
+	if _, err := buf.WriteString("0123456789"); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 10 || buf.Capacity() != 12 {
+		t.Fatal("unexpected length or capacity", buf.Len(), buf.Capacity())
+	}
+	if i := buf.Index('9'); i != 9 {
+		t.Fatal("unexpected index", i)
+	}
+	if i := buf.Index('x'); i != -1 {
+		t.Fatal("unexpected index", i)
+	}
+	p := make([]byte, 3)
+	if n, _ := buf.Read(p); n != 3 || string(p) != "012" {
+		t.Fatal("unexpected read", string(p[:n]))
+	}
+	// peeking across chunks coalesces them
+	if p = buf.Peek(6); string(p) != "345678" {
+		t.Fatal("unexpected peek", string(p))
+	}
+	if p = buf.PeekAt(4, 4); string(p) != "789" {
+		t.Fatal("unexpected peek at", string(p))
+	}
+	if b, err := buf.ReadByte(); err != nil || b != '3' {
+		t.Fatal("unexpected byte", b, err)
+	}
+	if err := buf.UnreadByte(); err != nil || buf.Len() != 7 {
+		t.Fatal("unexpected unread", buf.Len(), err)
+	}
+	if b, err := buf.ReadByte(); err != nil || b != '3' {
+		t.Fatal("unexpected byte", b, err)
+	}
+	line, err := buf.ReadBytes('7')
+	if err != nil || string(line) != "4567" {
+		t.Fatal("unexpected line", string(line), err)
+	}
+	if buf.UnsafeString() != "89" {
+		t.Fatal("unexpected bytes", string(buf.CloneBytes()))
+	}
+	if p, err = buf.NextSlice(1); err != nil || string(p) != "8" {
+		t.Fatal("unexpected next slice", string(p), err)
+	}
+	if err = buf.SetString("abc"); err != nil || string(buf.CloneBytes()) != "abc" {
+		t.Fatal("unexpected set", string(buf.CloneBytes()), err)
+	}
+	if !buf.Reset() || buf.Len() != 0 || buf.Capacity() != 0 {
+		t.Fatal("reset failed")
+	}
+	if _, err = buf.ReadByte(); !errors.Is(err, io.EOF) {
+		t.Fatal("expected EOF, got", err)
+	}
+	// the chunk of the unread byte was already released
+	_, _ = buf.WriteString("01234")
+	_, _ = buf.Next(3)
+	if b, _ := buf.ReadByte(); b != '3' {
+		t.Fatal("unexpected byte", b)
+	}
+	if err = buf.UnreadByte(); err != nil || string(buf.CloneBytes()) != "34" {
+		t.Fatal("unexpected unread", string(buf.CloneBytes()), err)
+	}
+}
+
+func TestChainBuffer_NoCopy(t *testing.T) {
+	buf := bytebuffers.NewChainBuffer(1024)
+	_, _ = buf.WriteString("head")
+	p := buf.Peek(4)
+	_, _ = buf.Write(make([]byte, 1<<20))
+	// existing content stays where it is after growth
+	if q := buf.Peek(4); &q[0] != &p[0] || string(q) != "head" {
+		t.Fatal("existing content moved")
+	}
+	if buf.Len() != 4+1<<20 {
+		t.Fatal("unexpected length", buf.Len())
+	}
+}
+
+func TestChainBuffer_Borrow(t *testing.T) {
+	buf := bytebuffers.NewChainBuffer(8)
+	_, _ = buf.WriteString("0123")
+	// larger than a chunk gets a dedicated chunk
+	p, err := buf.Borrow(32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p) != 32 {
+		t.Fatal("unexpected borrowed length", len(p))
+	}
+	if _, err = buf.WriteString("x"); !errors.Is(err, bytebuffers.ErrWriteWhenBorrowing) {
+		t.Fatal("expected write when borrowing, got", err)
+	}
+	if buf.Reset() {
+		t.Fatal("reset when borrowing")
+	}
+	copy(p, "abcdefghij")
+	// peeking while borrowing must not disturb the borrowed area
+	if string(buf.Peek(4)) != "0123" {
+		t.Fatal("unexpected peek")
+	}
+	buf.Return(10)
+	if string(buf.CloneBytes()) != "0123abcdefghij" {
+		t.Fatal("unexpected bytes", string(buf.CloneBytes()))
+	}
+	if string(buf.Peek(8)) != "0123abcd" {
+		t.Fatal("unexpected peek")
+	}
+	if _, err = buf.Borrow(0); !errors.Is(err, bytebuffers.ErrBorrowZero) {
+		t.Fatal("expected borrow zero, got", err)
+	}
+}
+
+func TestChainBuffer_Stream(t *testing.T) {
+	buf := bytebuffers.NewChainBuffer(64)
+	data := strings.Repeat("0123456789abcdef", 256)
+	if n, err := buf.ReadFrom(strings.NewReader(data)); err != nil || n != int64(len(data)) {
+		t.Fatal("unexpected read from", n, err)
+	}
+	out := new(bytes.Buffer)
+	if n, err := buf.WriteToLimited(out, 100); err != nil || n != 100 {
+		t.Fatal("unexpected write to limited", n, err)
+	}
+	if _, err := buf.WriteTo(out); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != data || buf.Len() != 0 {
+		t.Fatal("unexpected stream")
+	}
+	if n, err := buf.ReadFromLimited(strings.NewReader(data), 100); err != nil || n != 100 {
+		t.Fatal("unexpected read from limited", n, err)
+	}
+	if n, err := buf.CopyFromReader(strings.NewReader("short"), 8); err == nil || n != 0 {
+		t.Fatal("expected unexpected EOF", n, err)
+	}
+	if buf.Len() != 100 || string(buf.CloneBytes()) != data[:100] {
+		t.Fatal("unexpected bytes", buf.Len())
+	}
+}
+
+func TestChainBuffer_Codec(t *testing.T) {
+	buf := bytebuffers.NewChainBuffer(5)
+	_, _ = buf.WriteString("012")
+	buf.Discard(3)
+	// the following values cross chunk boundaries
+	if err := buf.WriteUvarint(300); err != nil {
+		t.Fatal(err)
+	}
+	if err := buf.WriteVarString("hello"); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	if err := buf.WriteTimestamp(now); err != nil {
+		t.Fatal(err)
+	}
+	if err := buf.WriteSlice([][]byte{[]byte("a"), []byte("bc")}); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := buf.ReadUvarint(); err != nil || v != 300 {
+		t.Fatal("unexpected uvarint", v, err)
+	}
+	if s, err := buf.ReadVarString(); err != nil || s != "hello" {
+		t.Fatal("unexpected string", s, err)
+	}
+	if ts, err := buf.ReadTimestamp(); err != nil || !ts.Equal(now) {
+		t.Fatal("unexpected timestamp", ts, err)
+	}
+	if elems, err := buf.ReadSlice(); err != nil || len(elems) != 2 || string(elems[1]) != "bc" {
+		t.Fatal("unexpected slice", elems, err)
+	}
+	if buf.Len() != 0 {
+		t.Fatal("unexpected remaining", buf.Len())
+	}
+}
@@ -0,0 +1,77 @@
+package bytebuffers
+
+import (
+	"errors"
+	"io"
+)
+
+var (
+	ErrCOBSInvalid = errors.New("bytebuffers.COBS: invalid encoding")
+)
+
+// WriteCOBSEncode
+// 以 COBS 编码写入 p，编码后不含 0x00，并以 0x00 作为结束符。
+func WriteCOBSEncode(buf Buffer, p []byte) (err error) {
+	size := len(p) + len(p)/254 + 2
+	dst, borrowErr := buf.Borrow(size)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	codeIdx, code, o := 0, byte(1), 1
+	for i, b := range p {
+		if b == 0 {
+			dst[codeIdx] = code
+			codeIdx, code = o, 1
+			o++
+			continue
+		}
+		dst[o] = b
+		o++
+		code++
+		if code == 0xFF && i+1 < len(p) { // a full block at the end needs no further code
+			dst[codeIdx] = code
+			codeIdx, code = o, 1
+			o++
+		}
+	}
+	dst[codeIdx] = code
+	dst[o] = 0
+	o++
+	buf.Return(o)
+	return
+}
+
+// ReadCOBSDecoded
+// 读取一个以 0x00 结尾的 COBS 帧并解码。
+//
+// 当结束符未到达时返回 io.ErrUnexpectedEOF 且不读掉任何字节；当帧无效时，该帧会被丢弃并返回 ErrCOBSInvalid。
+func ReadCOBSDecoded(buf Buffer) (p []byte, err error) {
+	if buf.Len() == 0 {
+		err = io.EOF
+		return
+	}
+	end := buf.Index(0)
+	if end < 0 {
+		err = io.ErrUnexpectedEOF
+		return
+	}
+	src := buf.Peek(end)
+	p = make([]byte, 0, end)
+	for i := 0; i < end; {
+		code := int(src[i])
+		i++
+		if code == 0 || i+code-1 > end {
+			p = nil
+			err = ErrCOBSInvalid
+			break
+		}
+		p = append(p, src[i:i+code-1]...)
+		i += code - 1
+		if code < 0xFF && i < end {
+			p = append(p, 0)
+		}
+	}
+	buf.Discard(end + 1)
+	return
+}
@@ -0,0 +1,109 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestWriteCOBSEncode(t *testing.T) {
+	cases := [][]byte{
+		{},
+		{0},
+		{0, 0},
+		{0x11, 0x22, 0x00, 0x33},
+		bytes.Repeat([]byte{1}, 254),
+		bytes.Repeat([]byte{1}, 255),
+	}
+	for i := 0; i < 8; i++ {
+		p := make([]byte, 1024)
+		_, _ = rand.Read(p)
+		p[0], p[512] = 0, 0
+		cases = append(cases, p)
+	}
+
+	for _, p := range cases {
+		buf := bytebuffers.NewBuffer()
+		if err := bytebuffers.WriteCOBSEncode(buf, p); err != nil {
+			t.Fatal(err)
+		}
+		encoded := buf.Peek(buf.Len())
+		if i := bytes.IndexByte(encoded, 0); i != len(encoded)-1 {
+			t.Fatal("null byte found at", i, "of", len(encoded))
+		}
+		decoded, err := bytebuffers.ReadCOBSDecoded(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(decoded, p) {
+			t.Fatal("round trip mismatch", len(p), len(decoded))
+		}
+		if buf.Len() != 0 {
+			t.Fatal("terminator not consumed")
+		}
+	}
+}
+
+func TestWriteCOBSEncodeVectors(t *testing.T) {
+	seq := func(from, to int) []byte {
+		p := make([]byte, 0, to-from+1)
+		for i := from; i <= to; i++ {
+			p = append(p, byte(i))
+		}
+		return p
+	}
+	cat := func(parts ...[]byte) []byte { return bytes.Join(parts, nil) }
+	cases := []struct {
+		p        []byte
+		expected []byte
+	}{
+		{[]byte{}, []byte{0x01, 0x00}},
+		{[]byte{0x00}, []byte{0x01, 0x01, 0x00}},
+		{[]byte{0x11, 0x22, 0x00, 0x33}, []byte{0x03, 0x11, 0x22, 0x02, 0x33, 0x00}},
+		// a full block at the end is not followed by another code
+		{seq(0x01, 0xFE), cat([]byte{0xFF}, seq(0x01, 0xFE), []byte{0x00})},
+		{cat([]byte{0x00}, seq(0x01, 0xFE)), cat([]byte{0x01, 0xFF}, seq(0x01, 0xFE), []byte{0x00})},
+		{seq(0x01, 0xFF), cat([]byte{0xFF}, seq(0x01, 0xFE), []byte{0x02, 0xFF, 0x00})},
+		{cat(seq(0x01, 0xFE), []byte{0x00}), cat([]byte{0xFF}, seq(0x01, 0xFE), []byte{0x01, 0x01, 0x00})},
+	}
+	for _, c := range cases {
+		buf := bytebuffers.NewBuffer()
+		if err := bytebuffers.WriteCOBSEncode(buf, c.p); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(buf.CloneBytes(), c.expected) {
+			t.Fatalf("unexpected encoding of %d bytes: % x", len(c.p), buf.CloneBytes())
+		}
+		if decoded, err := bytebuffers.ReadCOBSDecoded(buf); err != nil || !bytes.Equal(decoded, c.p) {
+			t.Fatal("round trip mismatch", len(c.p), len(decoded), err)
+		}
+	}
+}
+
+func TestReadCOBSDecoded(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	_, _ = buf.Write([]byte{0x03, 0x11, 0x22, 0x02, 0x33})
+	if _, err := bytebuffers.ReadCOBSDecoded(buf); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal("expected unexpected EOF, got", err)
+	}
+	_ = buf.WriteByte(0)
+	p, err := bytebuffers.ReadCOBSDecoded(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(p, []byte{0x11, 0x22, 0x00, 0x33}) {
+		t.Fatal("unexpected decoded", p)
+	}
+
+	_, _ = buf.Write([]byte{0x05, 0x11, 0x00})
+	if _, err = bytebuffers.ReadCOBSDecoded(buf); !errors.Is(err, bytebuffers.ErrCOBSInvalid) {
+		t.Fatal("expected invalid, got", err)
+	}
+	if buf.Len() != 0 {
+		t.Fatal("invalid frame not discarded")
+	}
+}
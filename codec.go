@@ -0,0 +1,489 @@
+package bytebuffers
+
+import (
+	"bytes"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/gob"
+	"io"
+	"math"
+	"sort"
+	"time"
+	"unicode/utf8"
+	"unsafe"
+)
+
+// codec
+// 仅基于 Buffer 的基础方法（Borrow、Write、Peek、Discard 等）实现的编解码方法，供非连续存储的 Buffer 嵌入。
+//
+// 与 buffer 上的同名方法行为一致，但因存储可能不连续，读取时 Peek 可能需要整理内存。
+type codec struct {
+	buf Buffer
+}
+
+func (c codec) WriteUvarint(v uint64) (err error) {
+	p, borrowErr := c.buf.Borrow(uvarintLen(v))
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	c.buf.Return(binary.PutUvarint(p, v))
+	return
+}
+
+func (c codec) ReadUvarint() (v uint64, err error) {
+	var n int
+	if v, n, err = c.peekUvarint(); err != nil {
+		return
+	}
+	c.buf.Discard(n)
+	return
+}
+
+func (c codec) peekUvarint() (v uint64, n int, err error) {
+	if c.buf.Len() == 0 {
+		err = io.EOF
+		return
+	}
+	v, n = binary.Uvarint(c.buf.Peek(binary.MaxVarintLen64))
+	if n == 0 {
+		err = io.ErrUnexpectedEOF
+		return
+	}
+	if n < 0 {
+		n = 0
+		err = ErrVarintOverflow
+		return
+	}
+	return
+}
+
+func (c codec) WriteRune(r rune) (n int, err error) {
+	if uint32(r) < utf8.RuneSelf {
+		if err = c.buf.WriteByte(byte(r)); err == nil {
+			n = 1
+		}
+		return
+	}
+	size := utf8.RuneLen(r)
+	if size < 0 { // invalid runes are written as utf8.RuneError
+		size = utf8.RuneLen(utf8.RuneError)
+	}
+	p, borrowErr := c.buf.Borrow(size)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	n = utf8.EncodeRune(p, r)
+	c.buf.Return(n)
+	return
+}
+
+func (c codec) WriteZigzagVarint(v int64) (err error) {
+	err = c.WriteUvarint(uint64(v<<1) ^ uint64(v>>63))
+	return
+}
+
+func (c codec) ReadZigzagVarint() (v int64, err error) {
+	uv, readErr := c.ReadUvarint()
+	if readErr != nil {
+		err = readErr
+		return
+	}
+	v = int64(uv>>1) ^ -int64(uv&1)
+	return
+}
+
+func (c codec) WriteVarBytes(p []byte) (err error) {
+	if c.buf.Borrowing() {
+		err = ErrWriteWhenBorrowing
+		return
+	}
+	if err = c.WriteUvarint(uint64(len(p))); err != nil {
+		return
+	}
+	_, err = c.buf.Write(p)
+	return
+}
+
+func (c codec) ReadVarBytes() (p []byte, err error) {
+	size, sn, peekErr := c.peekUvarint()
+	if peekErr != nil {
+		err = peekErr
+		return
+	}
+	if size > uint64(c.buf.Len()-sn) {
+		err = io.ErrUnexpectedEOF
+		return
+	}
+	n := sn + int(size)
+	p = make([]byte, size)
+	copy(p, c.buf.Peek(n)[sn:])
+	c.buf.Discard(n)
+	return
+}
+
+func (c codec) WriteVarString(s string) (err error) {
+	err = c.WriteVarBytes(unsafe.Slice(unsafe.StringData(s), len(s)))
+	return
+}
+
+func (c codec) ReadVarString() (s string, err error) {
+	p, readErr := c.ReadVarBytes()
+	if readErr != nil {
+		err = readErr
+		return
+	}
+	// p is a fresh copy owned by nobody else, so it is safe to alias it.
+	s = unsafe.String(unsafe.SliceData(p), len(p))
+	return
+}
+
+func (c codec) WriteMap(m map[string]string) (err error) {
+	if c.buf.Borrowing() {
+		err = ErrWriteWhenBorrowing
+		return
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	if err = c.WriteUvarint(uint64(len(keys))); err != nil {
+		return
+	}
+	for _, k := range keys {
+		if err = c.WriteVarString(k); err != nil {
+			return
+		}
+		if err = c.WriteVarString(m[k]); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (c codec) ReadMap() (m map[string]string, err error) {
+	bLen := c.buf.Len()
+	if bLen == 0 {
+		err = io.EOF
+		return
+	}
+	var n int
+	if m, n, err = readMap(c.buf.Peek(bLen)); err != nil {
+		return
+	}
+	c.buf.Discard(n)
+	return
+}
+
+func (c codec) WriteSlice(elems [][]byte) (err error) {
+	if c.buf.Borrowing() {
+		err = ErrWriteWhenBorrowing
+		return
+	}
+	if err = c.WriteUvarint(uint64(len(elems))); err != nil {
+		return
+	}
+	for _, elem := range elems {
+		if err = c.WriteVarBytes(elem); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (c codec) ReadSlice() (elems [][]byte, err error) {
+	bLen := c.buf.Len()
+	if bLen == 0 {
+		err = io.EOF
+		return
+	}
+	var n int
+	if elems, n, err = readSlice(c.buf.Peek(bLen)); err != nil {
+		return
+	}
+	c.buf.Discard(n)
+	return
+}
+
+func (c codec) WriteTimestamp(t time.Time) (err error) {
+	p, borrowErr := c.buf.Borrow(8)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	nano := int64(math.MinInt64) // zero time is out of the UnixNano range
+	if !t.IsZero() {
+		nano = t.UnixNano()
+	}
+	binary.BigEndian.PutUint64(p, uint64(nano))
+	c.buf.Return(8)
+	return
+}
+
+func (c codec) ReadTimestamp() (t time.Time, err error) {
+	p, peekErr := peekFull(c.buf, 8)
+	if peekErr != nil {
+		err = peekErr
+		return
+	}
+	nano := int64(binary.BigEndian.Uint64(p))
+	c.buf.Discard(8)
+	if nano == math.MinInt64 {
+		return
+	}
+	t = time.Unix(0, nano).UTC()
+	return
+}
+
+func (c codec) WriteUint16BE(v uint16) (err error) {
+	err = writeUint16(c.buf, binary.BigEndian, v)
+	return
+}
+
+func (c codec) ReadUint16BE() (v uint16, err error) {
+	v, err = readUint16(c.buf, binary.BigEndian)
+	return
+}
+
+func (c codec) WriteUint16LE(v uint16) (err error) {
+	err = writeUint16(c.buf, binary.LittleEndian, v)
+	return
+}
+
+func (c codec) ReadUint16LE() (v uint16, err error) {
+	v, err = readUint16(c.buf, binary.LittleEndian)
+	return
+}
+
+func (c codec) WriteUint32BE(v uint32) (err error) {
+	err = writeUint32(c.buf, binary.BigEndian, v)
+	return
+}
+
+func (c codec) ReadUint32BE() (v uint32, err error) {
+	v, err = readUint32(c.buf, binary.BigEndian)
+	return
+}
+
+func (c codec) WriteUint32LE(v uint32) (err error) {
+	err = writeUint32(c.buf, binary.LittleEndian, v)
+	return
+}
+
+func (c codec) ReadUint32LE() (v uint32, err error) {
+	v, err = readUint32(c.buf, binary.LittleEndian)
+	return
+}
+
+func (c codec) WriteUint64BE(v uint64) (err error) {
+	err = writeUint64(c.buf, binary.BigEndian, v)
+	return
+}
+
+func (c codec) ReadUint64BE() (v uint64, err error) {
+	v, err = readUint64(c.buf, binary.BigEndian)
+	return
+}
+
+func (c codec) WriteUint64LE(v uint64) (err error) {
+	err = writeUint64(c.buf, binary.LittleEndian, v)
+	return
+}
+
+func (c codec) ReadUint64LE() (v uint64, err error) {
+	v, err = readUint64(c.buf, binary.LittleEndian)
+	return
+}
+
+func (c codec) EncodeBase32(enc *base32.Encoding) (err error) {
+	if enc == nil {
+		enc = base32.StdEncoding
+	}
+	err = c.encode(enc.AppendEncode)
+	return
+}
+
+func (c codec) DecodeBase32(enc *base32.Encoding) (err error) {
+	if enc == nil {
+		enc = base32.StdEncoding
+	}
+	err = c.decode(enc.DecodedLen(c.buf.Len()), enc.Decode)
+	return
+}
+
+func (c codec) EncodeURL(padded bool) (err error) {
+	enc := base64.RawURLEncoding
+	if padded {
+		enc = base64.URLEncoding
+	}
+	err = c.encode(enc.AppendEncode)
+	return
+}
+
+func (c codec) DecodeURL(padded bool) (err error) {
+	enc := base64.RawURLEncoding
+	if padded {
+		enc = base64.URLEncoding
+	}
+	err = c.decode(enc.DecodedLen(c.buf.Len()), enc.Decode)
+	return
+}
+
+func (c codec) WriteBinaryFixed(v interface{}) (err error) {
+	if c.buf.Borrowing() {
+		err = ErrWriteWhenBorrowing
+		return
+	}
+	size := binary.Size(v)
+	if size < 0 {
+		err = ErrBinaryInvalidType
+		return
+	}
+	if size == 0 {
+		return
+	}
+	p, borrowErr := c.buf.Borrow(size)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	n, encodeErr := binary.Encode(p, binary.BigEndian, v)
+	if encodeErr != nil {
+		c.buf.Return(0)
+		err = encodeErr
+		return
+	}
+	c.buf.Return(n)
+	return
+}
+
+func (c codec) ReadBinaryFixed(v interface{}) (err error) {
+	size := binary.Size(v)
+	if size < 0 {
+		err = ErrBinaryInvalidType
+		return
+	}
+	bLen := c.buf.Len()
+	if bLen == 0 && size > 0 {
+		err = io.EOF
+		return
+	}
+	if bLen < size {
+		err = io.ErrUnexpectedEOF
+		return
+	}
+	n, decodeErr := binary.Decode(c.buf.Peek(size), binary.BigEndian, v)
+	if decodeErr != nil {
+		err = decodeErr
+		return
+	}
+	c.buf.Discard(n)
+	return
+}
+
+func (c codec) WriteGob(v interface{}) (err error) {
+	if c.buf.Borrowing() {
+		err = ErrWriteWhenBorrowing
+		return
+	}
+	err = gob.NewEncoder(c.buf).Encode(v)
+	return
+}
+
+func (c codec) ReadGob(v interface{}) (err error) {
+	bLen := c.buf.Len()
+	if bLen == 0 {
+		err = io.EOF
+		return
+	}
+	// bytes.Reader is an io.ByteReader, so the decoder never reads ahead.
+	r := bytes.NewReader(c.buf.Peek(bLen))
+	if err = gob.NewDecoder(r).Decode(v); err != nil {
+		return
+	}
+	c.buf.Discard(bLen - r.Len())
+	return
+}
+
+// NextSlice
+// 所需内容不连续时会先整理为连续内存，之后的 Peek 也可能整理内存，从而使返回的切片失效。
+func (c codec) NextSlice(n int) (p []byte, err error) {
+	if n < 1 {
+		return
+	}
+	if c.buf.Len() == 0 {
+		err = io.EOF
+		return
+	}
+	p = c.buf.Peek(n)
+	p = p[:len(p):len(p)]
+	c.buf.Discard(len(p))
+	return
+}
+
+// PeekAt
+// 所需内容不连续时会先整理为连续内存。
+func (c codec) PeekAt(off int, n int) (p []byte) {
+	bLen := c.buf.Len()
+	if off < 0 || n < 1 || off >= bLen {
+		return
+	}
+	if n > bLen-off {
+		n = bLen - off
+	}
+	p = c.buf.Peek(off + n)[off:]
+	return
+}
+
+// Bytes
+// 可读内容不连续时会先整理为连续内存。
+func (c codec) Bytes() []byte {
+	return c.buf.Peek(c.buf.Len())
+}
+
+func (c codec) UnsafeString() string {
+	p := c.buf.Bytes()
+	if len(p) == 0 {
+		return ""
+	}
+	return unsafe.String(unsafe.SliceData(p), len(p))
+}
+
+// encode
+// 编码可读内容并以 Set 替换之。
+func (c codec) encode(appendEncode func(dst, src []byte) []byte) (err error) {
+	if c.buf.Borrowing() {
+		err = ErrWriteWhenBorrowing
+		return
+	}
+	bLen := c.buf.Len()
+	if bLen == 0 {
+		return
+	}
+	err = c.buf.Set(appendEncode(nil, c.buf.Peek(bLen)))
+	return
+}
+
+// decode
+// 解码可读内容，成功后以 Set 替换之，失败时可读内容不变。
+func (c codec) decode(size int, decode func(dst, src []byte) (int, error)) (err error) {
+	if c.buf.Borrowing() {
+		err = ErrWriteWhenBorrowing
+		return
+	}
+	bLen := c.buf.Len()
+	if bLen == 0 {
+		return
+	}
+	dst := make([]byte, size)
+	n, decodeErr := decode(dst, c.buf.Peek(bLen))
+	if decodeErr != nil {
+		err = decodeErr
+		return
+	}
+	err = c.buf.Set(dst[:n])
+	return
+}
@@ -0,0 +1,86 @@
+package bytebuffers
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/zlib"
+	"io"
+)
+
+// CompressZlib
+// 以 zlib（RFC 1950）压缩可读内容并替换之。
+//
+// level 为 zlib.DefaultCompression、zlib.BestSpeed 至 zlib.BestCompression 等，超出范围时返回错误。
+func CompressZlib(buf Buffer, level int) (err error) {
+	tmp := Acquire()
+	defer Release(tmp)
+	zw, zErr := zlib.NewWriterLevel(tmp, level)
+	if zErr != nil {
+		err = zErr
+		return
+	}
+	err = compress(buf, tmp, zw)
+	return
+}
+
+// DecompressZlib
+// 解压 zlib 格式的可读内容并替换之，失败时可读内容不变。
+func DecompressZlib(buf Buffer) (err error) {
+	zr, zErr := zlib.NewReader(bytes.NewReader(buf.Peek(buf.Len())))
+	if zErr != nil {
+		err = zErr
+		return
+	}
+	err = decompress(buf, zr)
+	return
+}
+
+// CompressFlate
+// 以原始 DEFLATE（RFC 1951，无 zlib 头与 Adler-32 尾）压缩可读内容并替换之。
+func CompressFlate(buf Buffer) (err error) {
+	tmp := Acquire()
+	defer Release(tmp)
+	fw, fErr := flate.NewWriter(tmp, flate.DefaultCompression)
+	if fErr != nil {
+		err = fErr
+		return
+	}
+	err = compress(buf, tmp, fw)
+	return
+}
+
+// DecompressFlate
+// 解压原始 DEFLATE 格式的可读内容并替换之，失败时可读内容不变。
+func DecompressFlate(buf Buffer) (err error) {
+	err = decompress(buf, flate.NewReader(bytes.NewReader(buf.Peek(buf.Len()))))
+	return
+}
+
+// compress
+// 以 w 压缩 buf 的可读内容至 tmp，再以 tmp 替换 buf 的可读内容。
+func compress(buf Buffer, tmp Buffer, w io.WriteCloser) (err error) {
+	if _, err = w.Write(buf.Peek(buf.Len())); err != nil {
+		return
+	}
+	if err = w.Close(); err != nil {
+		return
+	}
+	err = buf.Set(tmp.Peek(tmp.Len()))
+	return
+}
+
+// decompress
+// 从 r 读取全部解压内容，再替换 buf 的可读内容。
+func decompress(buf Buffer, r io.ReadCloser) (err error) {
+	tmp := Acquire()
+	defer Release(tmp)
+	if _, err = tmp.ReadFrom(r); err != nil {
+		_ = r.Close()
+		return
+	}
+	if err = r.Close(); err != nil {
+		return
+	}
+	err = buf.Set(tmp.Peek(tmp.Len()))
+	return
+}
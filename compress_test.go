@@ -0,0 +1,104 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/zlib"
+	"crypto/rand"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestCompressZlib(t *testing.T) {
+	random := make([]byte, 10*1024)
+	_, _ = rand.Read(random)
+	for _, level := range []int{zlib.DefaultCompression, zlib.BestSpeed, zlib.BestCompression} {
+		buf := bytebuffers.NewBuffer()
+		_, _ = buf.Write(random)
+		if err := bytebuffers.CompressZlib(buf, level); err != nil {
+			t.Fatal(err)
+		}
+		if err := bytebuffers.DecompressZlib(buf); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(buf.CloneBytes(), random) {
+			t.Fatal("round trip mismatch at level", level)
+		}
+	}
+
+	buf := bytebuffers.NewBuffer()
+	if err := bytebuffers.CompressZlib(buf, zlib.DefaultCompression); err != nil {
+		t.Fatal(err)
+	}
+	zr, zErr := zlib.NewReader(bytes.NewReader(buf.CloneBytes()))
+	if zErr != nil {
+		t.Fatal(zErr)
+	}
+	if p, err := io.ReadAll(zr); err != nil || len(p) != 0 {
+		t.Fatal("invalid empty stream", p, err)
+	}
+	t.Log("empty stream", buf.Len())
+
+	if err := bytebuffers.CompressZlib(buf, 10); err == nil {
+		t.Fatal("expected invalid level error")
+	}
+}
+
+func TestDecompressZlib(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	_, _ = buf.Write([]byte("not a zlib stream"))
+	if err := bytebuffers.DecompressZlib(buf); !errors.Is(err, zlib.ErrHeader) {
+		t.Fatal("expected header error, got", err)
+	}
+	if string(buf.CloneBytes()) != "not a zlib stream" {
+		t.Fatal("buffer changed on failure")
+	}
+}
+
+func TestCompressFlate(t *testing.T) {
+	src := bytes.Repeat([]byte("0123456789"), 1024)
+	buf := bytebuffers.NewBuffer()
+	_, _ = buf.Write(src)
+	if err := bytebuffers.CompressFlate(buf); err != nil {
+		t.Fatal(err)
+	}
+	fr := flate.NewReader(bytes.NewReader(buf.CloneBytes()))
+	p, err := io.ReadAll(fr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(p, src) {
+		t.Fatal("standard reader mismatch")
+	}
+	if err = bytebuffers.DecompressFlate(buf); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.CloneBytes(), src) {
+		t.Fatal("round trip mismatch")
+	}
+}
+
+func TestDecompressFlate(t *testing.T) {
+	src := bytes.Repeat([]byte("abcdef"), 512)
+	compressed := bytes.NewBuffer(nil)
+	fw, _ := flate.NewWriter(compressed, flate.BestCompression)
+	_, _ = fw.Write(src)
+	_ = fw.Close()
+
+	buf := bytebuffers.NewBuffer()
+	_, _ = buf.Write(compressed.Bytes())
+	if err := bytebuffers.DecompressFlate(buf); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.CloneBytes(), src) {
+		t.Fatal("standard writer mismatch")
+	}
+
+	_ = buf.Set([]byte{0xFF, 0xFF, 0xFF})
+	if err := bytebuffers.DecompressFlate(buf); err == nil {
+		t.Fatal("expected corrupt input error")
+	}
+}
@@ -0,0 +1,679 @@
+package bytebuffers
+
+import (
+	"encoding/base32"
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+// NewConcurrentBuffer
+// 创建一个并发安全的 Buffer，允许一个 goroutine 写入（Write、ReadFrom 等）的同时另一个 goroutine 读取（Read、WriteTo 等）。
+func NewConcurrentBuffer() Buffer {
+	return NewConcurrentBufferWithCapacityHint(minHint)
+}
+
+// NewConcurrentBufferWithCapacityHint
+// 以 hint 为容量提示创建一个并发安全的 Buffer。
+func NewConcurrentBufferWithCapacityHint(hint int) Buffer {
+	buf := &concurrentBuffer{
+		b: NewBufferWithCapacityHint(hint).(*buffer),
+	}
+	buf.cond.L = &buf.mu
+	return buf
+}
+
+// concurrentBuffer
+// 以互斥锁保护的 Buffer。
+//
+// mu 保护每次调用，不跨调用持有。Borrow 与其它实现一致，未归还前的写入与借出返回 ErrWriteWhenBorrowing。
+// ReadFrom 系列以借出的方式在锁外读取流，读取者不会被慢速的流阻塞，期间其它写入者在 cond 上等待流读取结束；
+// WriteTo 系列在写入流期间持有 mu。
+// Peek 返回的切片与缓冲共享内存，并发写入可能使其失效，需要稳定内容时应使用 CloneBytes、Next 或 Read。
+type concurrentBuffer struct {
+	mu        sync.Mutex
+	cond      sync.Cond
+	streaming bool // ReadFrom series is reading into the borrowed area outside mu
+	b         *buffer
+}
+
+// lockWrite
+// 加锁并等待进行中的流读取结束。
+func (buf *concurrentBuffer) lockWrite() {
+	buf.mu.Lock()
+	for buf.streaming {
+		buf.cond.Wait()
+	}
+}
+
+func (buf *concurrentBuffer) unlockWrite() {
+	buf.mu.Unlock()
+}
+
+// beginStream
+// 开始在锁外读取流，调用前需持有 lockWrite。
+func (buf *concurrentBuffer) beginStream() (err error) {
+	if buf.b.Borrowing() {
+		err = ErrWriteWhenBorrowing
+		return
+	}
+	buf.streaming = true
+	return
+}
+
+// endStream
+// 结束流读取并唤醒等待的写入者，调用前需持有 mu。
+func (buf *concurrentBuffer) endStream() {
+	buf.streaming = false
+	buf.cond.Broadcast()
+}
+
+func (buf *concurrentBuffer) Len() (n int) {
+	buf.mu.Lock()
+	n = buf.b.Len()
+	buf.mu.Unlock()
+	return
+}
+
+func (buf *concurrentBuffer) Capacity() (n int) {
+	buf.mu.Lock()
+	n = buf.b.Capacity()
+	buf.mu.Unlock()
+	return
+}
+
+func (buf *concurrentBuffer) CapacityHint() (hint int) {
+	buf.mu.Lock()
+	hint = buf.b.CapacityHint()
+	buf.mu.Unlock()
+	return
+}
+
+func (buf *concurrentBuffer) Peek(n int) (p []byte) {
+	buf.mu.Lock()
+	p = buf.b.Peek(n)
+	buf.mu.Unlock()
+	return
+}
+
+func (buf *concurrentBuffer) PeekAt(off int, n int) (p []byte) {
+	buf.mu.Lock()
+	p = buf.b.PeekAt(off, n)
+	buf.mu.Unlock()
+	return
+}
+
+func (buf *concurrentBuffer) Next(n int) (p []byte, err error) {
+	buf.mu.Lock()
+	p, err = buf.b.Next(n)
+	buf.mu.Unlock()
+	return
+}
+
+func (buf *concurrentBuffer) NextSlice(n int) (p []byte, err error) {
+	buf.mu.Lock()
+	p, err = buf.b.NextSlice(n)
+	buf.mu.Unlock()
+	return
+}
+
+func (buf *concurrentBuffer) Discard(n int) {
+	buf.mu.Lock()
+	buf.b.Discard(n)
+	buf.mu.Unlock()
+}
+
+func (buf *concurrentBuffer) Truncate(n int) (err error) {
+	buf.mu.Lock()
+	err = buf.b.Truncate(n)
+	buf.mu.Unlock()
+	return
+}
+
+func (buf *concurrentBuffer) Read(p []byte) (n int, err error) {
+	buf.mu.Lock()
+	n, err = buf.b.Read(p)
+	buf.mu.Unlock()
+	return
+}
+
+func (buf *concurrentBuffer) ReadByte() (b byte, err error) {
+	buf.mu.Lock()
+	b, err = buf.b.ReadByte()
+	buf.mu.Unlock()
+	return
+}
+
+func (buf *concurrentBuffer) UnreadByte() (err error) {
+	buf.mu.Lock()
+	err = buf.b.UnreadByte()
+	buf.mu.Unlock()
+	return
+}
+
+func (buf *concurrentBuffer) ReadRune() (r rune, size int, err error) {
+	buf.mu.Lock()
+	r, size, err = buf.b.ReadRune()
+	buf.mu.Unlock()
+	return
+}
+
+func (buf *concurrentBuffer) UnreadRune() (err error) {
+	buf.mu.Lock()
+	err = buf.b.UnreadRune()
+	buf.mu.Unlock()
+	return
+}
+
+func (buf *concurrentBuffer) ReadBytes(delim byte) (line []byte, err error) {
+	buf.mu.Lock()
+	line, err = buf.b.ReadBytes(delim)
+	buf.mu.Unlock()
+	return
+}
+
+func (buf *concurrentBuffer) Index(delim byte) (i int) {
+	buf.mu.Lock()
+	i = buf.b.Index(delim)
+	buf.mu.Unlock()
+	return
+}
+
+func (buf *concurrentBuffer) ReadAt(p []byte, off int64) (n int, err error) {
+	buf.mu.Lock()
+	n, err = buf.b.ReadAt(p, off)
+	buf.mu.Unlock()
+	return
+}
+
+func (buf *concurrentBuffer) WriteAt(p []byte, off int64) (n int, err error) {
+	buf.lockWrite()
+	n, err = buf.b.WriteAt(p, off)
+	buf.unlockWrite()
+	return
+}
+
+func (buf *concurrentBuffer) Seek(offset int64, whence int) (abs int64, err error) {
+	buf.mu.Lock()
+	abs, err = buf.b.Seek(offset, whence)
+	buf.mu.Unlock()
+	return
+}
+
+func (buf *concurrentBuffer) Write(p []byte) (n int, err error) {
+	buf.lockWrite()
+	n, err = buf.b.Write(p)
+	buf.unlockWrite()
+	return
+}
+
+func (buf *concurrentBuffer) WriteByte(c byte) (err error) {
+	buf.lockWrite()
+	err = buf.b.WriteByte(c)
+	buf.unlockWrite()
+	return
+}
+
+func (buf *concurrentBuffer) WriteString(s string) (n int, err error) {
+	buf.lockWrite()
+	n, err = buf.b.WriteString(s)
+	buf.unlockWrite()
+	return
+}
+
+func (buf *concurrentBuffer) WriteRune(r rune) (n int, err error) {
+	buf.lockWrite()
+	n, err = buf.b.WriteRune(r)
+	buf.unlockWrite()
+	return
+}
+
+func (buf *concurrentBuffer) Set(p []byte) (err error) {
+	buf.lockWrite()
+	err = buf.b.Set(p)
+	buf.unlockWrite()
+	return
+}
+
+func (buf *concurrentBuffer) SetString(s string) (err error) {
+	buf.lockWrite()
+	err = buf.b.SetString(s)
+	buf.unlockWrite()
+	return
+}
+
+func (buf *concurrentBuffer) ReadFrom(r io.Reader) (n int64, err error) {
+	n, err = buf.ReadFromWithHint(r, 1)
+	return
+}
+
+func (buf *concurrentBuffer) ReadFromWithHint(r io.Reader, hint int) (n int64, err error) {
+	buf.lockWrite()
+	if err = buf.beginStream(); err != nil {
+		buf.unlockWrite()
+		return
+	}
+	size := buf.b.CapacityHint()
+	if hint > size {
+		size = hint
+	}
+	for {
+		p, borrowErr := buf.b.Borrow(size)
+		if borrowErr != nil {
+			err = borrowErr
+			break
+		}
+		buf.mu.Unlock()
+		rn, rErr := r.Read(p)
+		buf.mu.Lock()
+		buf.b.Return(rn)
+		n += int64(rn)
+		if rErr != nil {
+			if !errors.Is(rErr, io.EOF) {
+				err = rErr
+			}
+			break
+		}
+	}
+	buf.endStream()
+	buf.mu.Unlock()
+	return
+}
+
+func (buf *concurrentBuffer) ReadFromLimited(r io.Reader, n int) (nn int, err error) {
+	if n < 1 {
+		return
+	}
+	buf.lockWrite()
+	if err = buf.beginStream(); err != nil {
+		buf.unlockWrite()
+		return
+	}
+	p, borrowErr := buf.b.Borrow(n)
+	if borrowErr != nil {
+		err = borrowErr
+		buf.endStream()
+		buf.unlockWrite()
+		return
+	}
+	buf.mu.Unlock()
+	for nn < n {
+		rn, rErr := r.Read(p[nn:])
+		nn += rn
+		if rErr != nil {
+			if !errors.Is(rErr, io.EOF) {
+				err = rErr
+			}
+			break
+		}
+	}
+	buf.mu.Lock()
+	buf.b.Return(nn)
+	buf.endStream()
+	buf.mu.Unlock()
+	return
+}
+
+func (buf *concurrentBuffer) CopyFromReader(r io.Reader, n int) (nn int, err error) {
+	if n < 1 {
+		return
+	}
+	buf.lockWrite()
+	if err = buf.beginStream(); err != nil {
+		buf.unlockWrite()
+		return
+	}
+	p, borrowErr := buf.b.Borrow(n)
+	if borrowErr != nil {
+		err = borrowErr
+		buf.endStream()
+		buf.unlockWrite()
+		return
+	}
+	buf.mu.Unlock()
+	if _, err = io.ReadFull(r, p); err == nil {
+		nn = n
+	}
+	buf.mu.Lock()
+	buf.b.Return(nn)
+	buf.endStream()
+	buf.mu.Unlock()
+	return
+}
+
+func (buf *concurrentBuffer) WriteTo(w io.Writer) (n int64, err error) {
+	buf.mu.Lock()
+	n, err = buf.b.WriteTo(w)
+	buf.mu.Unlock()
+	return
+}
+
+func (buf *concurrentBuffer) WriteToLimited(w io.Writer, n int) (nn int, err error) {
+	buf.mu.Lock()
+	nn, err = buf.b.WriteToLimited(w, n)
+	buf.mu.Unlock()
+	return
+}
+
+func (buf *concurrentBuffer) CloneBytes() (p []byte) {
+	buf.mu.Lock()
+	p = buf.b.CloneBytes()
+	buf.mu.Unlock()
+	return
+}
+
+func (buf *concurrentBuffer) Bytes() (p []byte) {
+	buf.mu.Lock()
+	p = buf.b.Bytes()
+	buf.mu.Unlock()
+	return
+}
+
+func (buf *concurrentBuffer) UnsafeString() (s string) {
+	buf.mu.Lock()
+	s = buf.b.UnsafeString()
+	buf.mu.Unlock()
+	return
+}
+
+func (buf *concurrentBuffer) Grow(n int) (err error) {
+	buf.lockWrite()
+	err = buf.b.Grow(n)
+	buf.unlockWrite()
+	return
+}
+
+func (buf *concurrentBuffer) Available() (n int) {
+	buf.mu.Lock()
+	n = buf.b.Available()
+	buf.mu.Unlock()
+	return
+}
+
+// AvailableBuffer
+// 与其它写入者并发时，追加与 Commit 之间的写入会使切片失效，应由同一个写入者完成。
+func (buf *concurrentBuffer) AvailableBuffer() (p []byte) {
+	buf.mu.Lock()
+	p = buf.b.AvailableBuffer()
+	buf.mu.Unlock()
+	return
+}
+
+func (buf *concurrentBuffer) Commit(n int) (err error) {
+	buf.lockWrite()
+	err = buf.b.Commit(n)
+	buf.unlockWrite()
+	return
+}
+
+func (buf *concurrentBuffer) Borrow(size int) (p []byte, err error) {
+	buf.lockWrite()
+	p, err = buf.b.Borrow(size)
+	buf.unlockWrite()
+	return
+}
+
+func (buf *concurrentBuffer) Return(used int) {
+	buf.mu.Lock()
+	if !buf.streaming { // the borrowed area belongs to the stream
+		buf.b.Return(used)
+	}
+	buf.mu.Unlock()
+}
+
+func (buf *concurrentBuffer) Borrowing() (ok bool) {
+	buf.mu.Lock()
+	ok = buf.b.Borrowing()
+	buf.mu.Unlock()
+	return
+}
+
+func (buf *concurrentBuffer) Reset() (ok bool) {
+	buf.mu.Lock()
+	ok = buf.b.Reset()
+	buf.mu.Unlock()
+	return
+}
+
+func (buf *concurrentBuffer) WriteUvarint(v uint64) (err error) {
+	buf.lockWrite()
+	err = buf.b.WriteUvarint(v)
+	buf.unlockWrite()
+	return
+}
+
+func (buf *concurrentBuffer) ReadUvarint() (v uint64, err error) {
+	buf.mu.Lock()
+	v, err = buf.b.ReadUvarint()
+	buf.mu.Unlock()
+	return
+}
+
+func (buf *concurrentBuffer) WriteZigzagVarint(v int64) (err error) {
+	buf.lockWrite()
+	err = buf.b.WriteZigzagVarint(v)
+	buf.unlockWrite()
+	return
+}
+
+func (buf *concurrentBuffer) ReadZigzagVarint() (v int64, err error) {
+	buf.mu.Lock()
+	v, err = buf.b.ReadZigzagVarint()
+	buf.mu.Unlock()
+	return
+}
+
+func (buf *concurrentBuffer) WriteVarBytes(p []byte) (err error) {
+	buf.lockWrite()
+	err = buf.b.WriteVarBytes(p)
+	buf.unlockWrite()
+	return
+}
+
+func (buf *concurrentBuffer) ReadVarBytes() (p []byte, err error) {
+	buf.mu.Lock()
+	p, err = buf.b.ReadVarBytes()
+	buf.mu.Unlock()
+	return
+}
+
+func (buf *concurrentBuffer) WriteVarString(s string) (err error) {
+	buf.lockWrite()
+	err = buf.b.WriteVarString(s)
+	buf.unlockWrite()
+	return
+}
+
+func (buf *concurrentBuffer) ReadVarString() (s string, err error) {
+	buf.mu.Lock()
+	s, err = buf.b.ReadVarString()
+	buf.mu.Unlock()
+	return
+}
+
+func (buf *concurrentBuffer) WriteMap(m map[string]string) (err error) {
+	buf.lockWrite()
+	err = buf.b.WriteMap(m)
+	buf.unlockWrite()
+	return
+}
+
+func (buf *concurrentBuffer) ReadMap() (m map[string]string, err error) {
+	buf.mu.Lock()
+	m, err = buf.b.ReadMap()
+	buf.mu.Unlock()
+	return
+}
+
+func (buf *concurrentBuffer) WriteSlice(elems [][]byte) (err error) {
+	buf.lockWrite()
+	err = buf.b.WriteSlice(elems)
+	buf.unlockWrite()
+	return
+}
+
+func (buf *concurrentBuffer) ReadSlice() (elems [][]byte, err error) {
+	buf.mu.Lock()
+	elems, err = buf.b.ReadSlice()
+	buf.mu.Unlock()
+	return
+}
+
+func (buf *concurrentBuffer) WriteTimestamp(t time.Time) (err error) {
+	buf.lockWrite()
+	err = buf.b.WriteTimestamp(t)
+	buf.unlockWrite()
+	return
+}
+
+func (buf *concurrentBuffer) ReadTimestamp() (t time.Time, err error) {
+	buf.mu.Lock()
+	t, err = buf.b.ReadTimestamp()
+	buf.mu.Unlock()
+	return
+}
+
+func (buf *concurrentBuffer) WriteUint16BE(v uint16) (err error) {
+	buf.lockWrite()
+	err = buf.b.WriteUint16BE(v)
+	buf.unlockWrite()
+	return
+}
+
+func (buf *concurrentBuffer) ReadUint16BE() (v uint16, err error) {
+	buf.mu.Lock()
+	v, err = buf.b.ReadUint16BE()
+	buf.mu.Unlock()
+	return
+}
+
+func (buf *concurrentBuffer) WriteUint16LE(v uint16) (err error) {
+	buf.lockWrite()
+	err = buf.b.WriteUint16LE(v)
+	buf.unlockWrite()
+	return
+}
+
+func (buf *concurrentBuffer) ReadUint16LE() (v uint16, err error) {
+	buf.mu.Lock()
+	v, err = buf.b.ReadUint16LE()
+	buf.mu.Unlock()
+	return
+}
+
+func (buf *concurrentBuffer) WriteUint32BE(v uint32) (err error) {
+	buf.lockWrite()
+	err = buf.b.WriteUint32BE(v)
+	buf.unlockWrite()
+	return
+}
+
+func (buf *concurrentBuffer) ReadUint32BE() (v uint32, err error) {
+	buf.mu.Lock()
+	v, err = buf.b.ReadUint32BE()
+	buf.mu.Unlock()
+	return
+}
+
+func (buf *concurrentBuffer) WriteUint32LE(v uint32) (err error) {
+	buf.lockWrite()
+	err = buf.b.WriteUint32LE(v)
+	buf.unlockWrite()
+	return
+}
+
+func (buf *concurrentBuffer) ReadUint32LE() (v uint32, err error) {
+	buf.mu.Lock()
+	v, err = buf.b.ReadUint32LE()
+	buf.mu.Unlock()
+	return
+}
+
+func (buf *concurrentBuffer) WriteUint64BE(v uint64) (err error) {
+	buf.lockWrite()
+	err = buf.b.WriteUint64BE(v)
+	buf.unlockWrite()
+	return
+}
+
+func (buf *concurrentBuffer) ReadUint64BE() (v uint64, err error) {
+	buf.mu.Lock()
+	v, err = buf.b.ReadUint64BE()
+	buf.mu.Unlock()
+	return
+}
+
+func (buf *concurrentBuffer) WriteUint64LE(v uint64) (err error) {
+	buf.lockWrite()
+	err = buf.b.WriteUint64LE(v)
+	buf.unlockWrite()
+	return
+}
+
+func (buf *concurrentBuffer) ReadUint64LE() (v uint64, err error) {
+	buf.mu.Lock()
+	v, err = buf.b.ReadUint64LE()
+	buf.mu.Unlock()
+	return
+}
+
+func (buf *concurrentBuffer) EncodeBase32(enc *base32.Encoding) (err error) {
+	buf.lockWrite()
+	err = buf.b.EncodeBase32(enc)
+	buf.unlockWrite()
+	return
+}
+
+func (buf *concurrentBuffer) DecodeBase32(enc *base32.Encoding) (err error) {
+	buf.lockWrite()
+	err = buf.b.DecodeBase32(enc)
+	buf.unlockWrite()
+	return
+}
+
+func (buf *concurrentBuffer) EncodeURL(padded bool) (err error) {
+	buf.lockWrite()
+	err = buf.b.EncodeURL(padded)
+	buf.unlockWrite()
+	return
+}
+
+func (buf *concurrentBuffer) DecodeURL(padded bool) (err error) {
+	buf.lockWrite()
+	err = buf.b.DecodeURL(padded)
+	buf.unlockWrite()
+	return
+}
+
+func (buf *concurrentBuffer) WriteBinaryFixed(v interface{}) (err error) {
+	buf.lockWrite()
+	err = buf.b.WriteBinaryFixed(v)
+	buf.unlockWrite()
+	return
+}
+
+func (buf *concurrentBuffer) ReadBinaryFixed(v interface{}) (err error) {
+	buf.mu.Lock()
+	err = buf.b.ReadBinaryFixed(v)
+	buf.mu.Unlock()
+	return
+}
+
+func (buf *concurrentBuffer) WriteGob(v interface{}) (err error) {
+	buf.lockWrite()
+	err = buf.b.WriteGob(v)
+	buf.unlockWrite()
+	return
+}
+
+func (buf *concurrentBuffer) ReadGob(v interface{}) (err error) {
+	buf.mu.Lock()
+	err = buf.b.ReadGob(v)
+	buf.mu.Unlock()
+	return
+}
+
+func (buf *concurrentBuffer) ReadOnly() Buffer {
+	return readOnlyBuffer{buf}
+}
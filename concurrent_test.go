@@ -0,0 +1,165 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestConcurrentBuffer(t *testing.T) {
+	buf := bytebuffers.NewConcurrentBuffer()
+	const total = 1 << 16
+	expected := make([]byte, total)
+	for i := range expected {
+		expected[i] = byte(i)
+	}
+
+	wg := new(sync.WaitGroup)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < total; {
+			n := 1 + i%97
+			if i+n > total {
+				n = total - i
+			}
+			if i%2 == 0 {
+				_, _ = buf.Write(expected[i : i+n])
+			} else {
+				p, err := buf.Borrow(n)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				copy(p, expected[i:i+n])
+				buf.Return(n)
+			}
+			i += n
+		}
+	}()
+
+	received := make([]byte, 0, total)
+	p := make([]byte, 113)
+	for len(received) < total {
+		n, err := buf.Read(p)
+		if err == io.EOF {
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		received = append(received, p[:n]...)
+	}
+	wg.Wait()
+	if !bytes.Equal(received, expected) {
+		t.Fatal("unexpected bytes")
+	}
+}
+
+func TestConcurrentBuffer_Borrow(t *testing.T) {
+	buf := bytebuffers.NewConcurrentBuffer()
+	p, err := buf.Borrow(5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// like other buffers, writing before the borrowed area is returned fails instead of blocking
+	if _, err = buf.WriteString("world"); !errors.Is(err, bytebuffers.ErrWriteWhenBorrowing) {
+		t.Fatal("expected write when borrowing, got", err)
+	}
+	if _, err = buf.Borrow(1); !errors.Is(err, bytebuffers.ErrWriteWhenBorrowing) {
+		t.Fatal("expected write when borrowing, got", err)
+	}
+	if _, err = buf.ReadFrom(bytes.NewReader([]byte("x"))); !errors.Is(err, bytebuffers.ErrWriteWhenBorrowing) {
+		t.Fatal("expected write when borrowing, got", err)
+	}
+	copy(p, "hello")
+	buf.Return(5)
+	if _, err = buf.WriteString("world"); err != nil {
+		t.Fatal(err)
+	}
+	if string(buf.CloneBytes()) != "helloworld" {
+		t.Fatal("unexpected bytes", string(buf.CloneBytes()))
+	}
+
+	buf.Return(0) // not borrowing, nothing to release
+	if _, err = buf.WriteString("!"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConcurrentBuffer_WriteWhileStreaming(t *testing.T) {
+	buf := bytebuffers.NewConcurrentBuffer()
+	pr, pw := io.Pipe()
+	streamed := make(chan error, 1)
+	go func() {
+		_, err := buf.ReadFrom(pr)
+		streamed <- err
+	}()
+	_, _ = pw.Write([]byte("ping"))
+	for buf.Len() < 4 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// a concurrent write waits for the stream to end
+	written := make(chan error, 1)
+	go func() {
+		_, err := buf.WriteString("!")
+		written <- err
+	}()
+	select {
+	case <-written:
+		t.Fatal("write must wait while streaming")
+	case <-time.After(10 * time.Millisecond):
+	}
+	buf.Return(0) // the borrowed area belongs to the stream
+	_ = pw.Close()
+	if err := <-streamed; err != nil {
+		t.Fatal(err)
+	}
+	if err := <-written; err != nil {
+		t.Fatal(err)
+	}
+	if string(buf.CloneBytes()) != "ping!" {
+		t.Fatal("unexpected bytes", string(buf.CloneBytes()))
+	}
+}
+
+func TestConcurrentBuffer_ReadFrom(t *testing.T) {
+	buf := bytebuffers.NewConcurrentBuffer()
+	pr, pw := io.Pipe()
+	wg := new(sync.WaitGroup)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if _, err := buf.ReadFrom(pr); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	_, _ = pw.Write([]byte("ping"))
+	// the reader is not blocked by ReadFrom waiting on the pipe
+	for buf.Len() < 4 {
+		time.Sleep(time.Millisecond)
+	}
+	if p, _ := buf.Next(4); string(p) != "ping" {
+		t.Fatal("unexpected bytes", string(p))
+	}
+	_ = pw.Close()
+	wg.Wait()
+
+	if _, err := buf.CopyFromReader(bytes.NewReader([]byte("pong")), 4); err != nil {
+		t.Fatal(err)
+	}
+	out := new(bytes.Buffer)
+	if _, err := buf.WriteTo(out); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "pong" {
+		t.Fatal("unexpected bytes", out.String())
+	}
+}
@@ -0,0 +1,66 @@
+package bytebuffers
+
+import (
+	"crypto/rand"
+	"errors"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+var (
+	ErrInvalidKey       = errors.New("bytebuffers.Crypto: invalid key")
+	ErrDecryptionFailed = errors.New("bytebuffers.Crypto: decryption failed")
+)
+
+// Encrypt
+// 以 ChaCha20-Poly1305 加密可读内容并替换之。
+//
+// key 必须为 32 字节。结果为 随机 nonce（12 字节）+ 密文 + 认证标签（16 字节）。
+func Encrypt(buf Buffer, key []byte) (err error) {
+	if len(key) != chacha20poly1305.KeySize {
+		err = ErrInvalidKey
+		return
+	}
+	aead, aeadErr := chacha20poly1305.New(key)
+	if aeadErr != nil {
+		err = aeadErr
+		return
+	}
+	plaintext := buf.Peek(buf.Len())
+	dst := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err = rand.Read(dst); err != nil {
+		return
+	}
+	dst = aead.Seal(dst, dst, plaintext, nil)
+	err = buf.Set(dst)
+	return
+}
+
+// Decrypt
+// 以 ChaCha20-Poly1305 解密由 Encrypt 加密的可读内容并替换之。
+//
+// 当认证失败时返回 ErrDecryptionFailed，且可读内容不变。
+func Decrypt(buf Buffer, key []byte) (err error) {
+	if len(key) != chacha20poly1305.KeySize {
+		err = ErrInvalidKey
+		return
+	}
+	aead, aeadErr := chacha20poly1305.New(key)
+	if aeadErr != nil {
+		err = aeadErr
+		return
+	}
+	p := buf.Peek(buf.Len())
+	if len(p) < aead.NonceSize()+aead.Overhead() {
+		err = ErrDecryptionFailed
+		return
+	}
+	nonce, ciphertext := p[:aead.NonceSize()], p[aead.NonceSize():]
+	plaintext, openErr := aead.Open(nil, nonce, ciphertext, nil)
+	if openErr != nil {
+		err = ErrDecryptionFailed
+		return
+	}
+	err = buf.Set(plaintext)
+	return
+}
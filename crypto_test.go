@@ -0,0 +1,56 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestEncrypt(t *testing.T) {
+	key := make([]byte, 32)
+	_, _ = rand.Read(key)
+	plaintext := []byte("0123456789")
+
+	buf := bytebuffers.NewBuffer()
+	_, _ = buf.Write(plaintext)
+	if err := bytebuffers.Encrypt(buf, key); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != len(plaintext)+12+16 {
+		t.Fatal("unexpected ciphertext length", buf.Len())
+	}
+	if err := bytebuffers.Decrypt(buf, key); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.CloneBytes(), plaintext) {
+		t.Fatal("round trip mismatch")
+	}
+}
+
+func TestDecrypt(t *testing.T) {
+	key := make([]byte, 32)
+	_, _ = rand.Read(key)
+
+	buf := bytebuffers.NewBuffer()
+	_, _ = buf.Write([]byte("0123456789"))
+	_ = bytebuffers.Encrypt(buf, key)
+	tampered := buf.CloneBytes()
+	tampered[12] ^= 0x01
+	_ = buf.Set(tampered)
+	if err := bytebuffers.Decrypt(buf, key); !errors.Is(err, bytebuffers.ErrDecryptionFailed) {
+		t.Fatal("expected decryption failed, got", err)
+	}
+	if !bytes.Equal(buf.CloneBytes(), tampered) {
+		t.Fatal("buffer changed on failure")
+	}
+
+	if err := bytebuffers.Encrypt(buf, key[:16]); !errors.Is(err, bytebuffers.ErrInvalidKey) {
+		t.Fatal("expected invalid key, got", err)
+	}
+	if err := bytebuffers.Decrypt(buf, key[:16]); !errors.Is(err, bytebuffers.ErrInvalidKey) {
+		t.Fatal("expected invalid key, got", err)
+	}
+}
@@ -0,0 +1,250 @@
+package bytebuffers
+
+import (
+	"encoding/asn1"
+	"errors"
+	"io"
+)
+
+const (
+	derTagOID      = 0x06
+	derTagSequence = 0x30
+	derMaxLength   = 1<<31 - 1
+)
+
+var (
+	ErrDERInvalid = errors.New("bytebuffers.DER: invalid encoding")
+)
+
+// WriteDERSequence
+// 写入一个 ASN.1 DER SEQUENCE，fn 向传入的 Buffer 写入序列内容，其可嵌套调用。
+//
+// fn 写入的是临时缓冲，完成后再连同标签（0x30）与 DER 长度一并写入 buf。
+func WriteDERSequence(buf Buffer, fn func(Buffer) error) (err error) {
+	err = writeDERConstructed(buf, derTagSequence, fn)
+	return
+}
+
+// WriteOID
+// 写入 ASN.1 DER 编码的 OBJECT IDENTIFIER，前两段合并为 40*X+Y，各段以 base-128 编码。
+func WriteOID(buf Buffer, oid asn1.ObjectIdentifier) (err error) {
+	value, appendErr := appendOID(make([]byte, 0, len(oid)*2), oid)
+	if appendErr != nil {
+		err = appendErr
+		return
+	}
+	err = writeDER(buf, derTagOID, value)
+	return
+}
+
+// ReadOID
+// 读取 ASN.1 DER 编码的 OBJECT IDENTIFIER，不完整时不读掉。
+func ReadOID(buf Buffer) (oid asn1.ObjectIdentifier, err error) {
+	value, n, readErr := peekDER(buf, derTagOID)
+	if readErr != nil {
+		err = readErr
+		return
+	}
+	if oid, err = parseOID(value); err != nil {
+		return
+	}
+	buf.Discard(n)
+	return
+}
+
+// parseOID
+// 解析 OBJECT IDENTIFIER 的值内容。
+func parseOID(value []byte) (oid asn1.ObjectIdentifier, err error) {
+	if len(value) == 0 {
+		err = ErrDERInvalid
+		return
+	}
+	oid = make(asn1.ObjectIdentifier, 1, len(value)+1)
+	for i := 0; i < len(value); {
+		arc := 0
+		for {
+			if arc > maxInt>>7 || (arc == 0 && value[i] == 0x80) {
+				oid, err = nil, ErrDERInvalid
+				return
+			}
+			b := value[i]
+			i++
+			arc = arc<<7 | int(b&0x7F)
+			if b&0x80 == 0 {
+				break
+			}
+			if i == len(value) {
+				oid, err = nil, ErrDERInvalid
+				return
+			}
+		}
+		oid = append(oid, arc)
+	}
+	switch first := oid[1]; {
+	case first < 40:
+		oid[0], oid[1] = 0, first
+	case first < 80:
+		oid[0], oid[1] = 1, first-40
+	default:
+		oid[0], oid[1] = 2, first-80
+	}
+	return
+}
+
+// appendOID
+// 追加 OBJECT IDENTIFIER 的值内容，前两段合并为 40*X+Y。
+func appendOID(p []byte, oid asn1.ObjectIdentifier) (b []byte, err error) {
+	if len(oid) < 2 || oid[0] < 0 || oid[0] > 2 || oid[1] < 0 || (oid[0] < 2 && oid[1] >= 40) {
+		err = ErrDERInvalid
+		return
+	}
+	b = appendBase128(p, oid[0]*40+oid[1])
+	for _, arc := range oid[2:] {
+		if arc < 0 {
+			b, err = nil, ErrDERInvalid
+			return
+		}
+		b = appendBase128(b, arc)
+	}
+	return
+}
+
+func appendBase128(p []byte, n int) []byte {
+	size := 1
+	for i := n >> 7; i > 0; i >>= 7 {
+		size++
+	}
+	for i := size - 1; i >= 0; i-- {
+		b := byte(n>>(7*i)) & 0x7F
+		if i > 0 {
+			b |= 0x80
+		}
+		p = append(p, b)
+	}
+	return p
+}
+
+// peekDER
+// 查看一个标签为 tag 的 DER 值，返回值内容与整个 TLV 的长度，不读掉。
+func peekDER(buf Buffer, tag byte) (value []byte, n int, err error) {
+	value, n, err = parseDER(buf.Peek(buf.Len()), tag, false)
+	return
+}
+
+// peekBER
+// 同 peekDER，但按 BER 接受非最短的长格式长度，如 0x82 0x00 0x10，用于 SNMP 等以 BER 编码的协议。
+func peekBER(buf Buffer, tag byte) (value []byte, n int, err error) {
+	value, n, err = parseDER(buf.Peek(buf.Len()), tag, true)
+	return
+}
+
+// parseDER
+// 从 p 的开头解析一个标签为 tag 的 DER 值，返回值内容与整个 TLV 的长度。lenient 时接受非最短的长度。
+func parseDER(p []byte, tag byte, lenient bool) (value []byte, n int, err error) {
+	if len(p) == 0 {
+		err = io.EOF
+		return
+	}
+	if p[0] != tag {
+		err = ErrDERInvalid
+		return
+	}
+	if len(p) < 2 {
+		err = io.ErrUnexpectedEOF
+		return
+	}
+	length, hn := int(p[1]), 2
+	if length&0x80 != 0 {
+		size := length & 0x7F
+		if size == 0 || size > 4 {
+			err = ErrDERInvalid
+			return
+		}
+		if len(p) < 2+size {
+			err = io.ErrUnexpectedEOF
+			return
+		}
+		var l uint64
+		for _, b := range p[2 : 2+size] {
+			l = l<<8 | uint64(b)
+		}
+		if l > derMaxLength { // would overflow int on 32-bit platforms
+			err = ErrDERInvalid
+			return
+		}
+		hn += size
+		if l > uint64(len(p)-hn) {
+			err = io.ErrUnexpectedEOF
+			return
+		}
+		length = int(l)
+		if !lenient && (length < 0x80 || derLengthSize(length) != 1+size) { // not minimal
+			err = ErrDERInvalid
+			return
+		}
+	}
+	if len(p)-hn < length {
+		err = io.ErrUnexpectedEOF
+		return
+	}
+	n = hn + length
+	value = p[hn:n]
+	return
+}
+
+// writeDERConstructed
+// 写入一个构造类型的 DER 值，fn 写入的是临时缓冲，完成后再连同标签与长度一并写入 buf。
+func writeDERConstructed(buf Buffer, tag byte, fn func(Buffer) error) (err error) {
+	body := Acquire()
+	defer Release(body)
+	if err = fn(body); err != nil {
+		return
+	}
+	err = writeDER(buf, tag, body.Peek(body.Len()))
+	return
+}
+
+// writeDER
+// 写入一个 DER 的 标签-长度-值。
+func writeDER(buf Buffer, tag byte, value []byte) (err error) {
+	size := 1 + derLengthSize(len(value)) + len(value)
+	p, borrowErr := buf.Borrow(size)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	p[0] = tag
+	n := 1 + putDERLength(p[1:], len(value))
+	copy(p[n:], value)
+	buf.Return(size)
+	return
+}
+
+// derLengthSize
+// DER 长度所占字节数。
+func derLengthSize(n int) int {
+	if n < 0x80 {
+		return 1
+	}
+	size := 1
+	for ; n > 0; n >>= 8 {
+		size++
+	}
+	return size
+}
+
+// putDERLength
+// 以 DER 规则写入长度，短格式为一个字节，长格式为 0x80|字节数 后跟大端长度。
+func putDERLength(p []byte, n int) int {
+	size := derLengthSize(n)
+	if size == 1 {
+		p[0] = byte(n)
+		return 1
+	}
+	p[0] = 0x80 | byte(size-1)
+	for i := size - 1; i > 0; i-- {
+		p[i] = byte(n)
+		n >>= 8
+	}
+	return size
+}
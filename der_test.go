@@ -0,0 +1,118 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"encoding/asn1"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestWriteDERSequence(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	err := bytebuffers.WriteDERSequence(buf, func(body bytebuffers.Buffer) error {
+		_, wErr := body.Write([]byte{0x02, 0x01, 0x01, 0x02, 0x02, 0x01, 0x00})
+		return wErr
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, _ := asn1.Marshal(struct{ A, B int }{1, 256})
+	if !bytes.Equal(buf.CloneBytes(), expected) {
+		t.Fatal("unexpected sequence", buf.CloneBytes(), expected)
+	}
+}
+
+func TestWriteDERSequence_nested(t *testing.T) {
+	type inner struct{ Data []byte }
+	type outer struct {
+		Inner inner
+		N     int
+	}
+	data := bytes.Repeat([]byte{0xAB}, 300)
+	expected, _ := asn1.Marshal(outer{Inner: inner{Data: data}, N: 5})
+
+	octets, _ := asn1.Marshal(data)
+	buf := bytebuffers.NewBuffer()
+	err := bytebuffers.WriteDERSequence(buf, func(body bytebuffers.Buffer) error {
+		if wErr := bytebuffers.WriteDERSequence(body, func(body bytebuffers.Buffer) error {
+			_, wErr := body.Write(octets)
+			return wErr
+		}); wErr != nil {
+			return wErr
+		}
+		_, wErr := body.Write([]byte{0x02, 0x01, 0x05})
+		return wErr
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.CloneBytes(), expected) {
+		t.Fatal("unexpected nested sequence", buf.CloneBytes()[:8], expected[:8])
+	}
+}
+
+func TestWriteOID(t *testing.T) {
+	oids := []asn1.ObjectIdentifier{
+		{2, 16, 840, 1, 101, 3, 4, 2, 1}, // sha256
+		{1, 2, 840, 113549, 1, 1, 1},     // rsaEncryption
+		{1, 3, 6, 1, 2, 1, 1, 1, 0},      // sysDescr.0
+		{2, 999, 3},
+		{0, 0},
+	}
+	buf := bytebuffers.NewBuffer()
+	for _, oid := range oids {
+		if err := bytebuffers.WriteOID(buf, oid); err != nil {
+			t.Fatal(err)
+		}
+		expected, _ := asn1.Marshal(oid)
+		if !bytes.Equal(buf.CloneBytes(), expected) {
+			t.Fatal("unexpected encoding", oid, buf.CloneBytes(), expected)
+		}
+		roid, err := bytebuffers.ReadOID(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !roid.Equal(oid) {
+			t.Fatal("round trip mismatch", roid, oid)
+		}
+	}
+
+	if err := bytebuffers.WriteOID(buf, asn1.ObjectIdentifier{1, 40}); !errors.Is(err, bytebuffers.ErrDERInvalid) {
+		t.Fatal("expected invalid, got", err)
+	}
+}
+
+func TestReadOID(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	_, _ = buf.Write([]byte{0x06, 0x03, 0x2A, 0x86})
+	if _, err := bytebuffers.ReadOID(buf); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal("expected unexpected EOF, got", err)
+	}
+	if buf.Len() != 4 {
+		t.Fatal("incomplete oid consumed")
+	}
+	_ = buf.Set([]byte{0x06, 0x02, 0x2A, 0x86})
+	if _, err := bytebuffers.ReadOID(buf); !errors.Is(err, bytebuffers.ErrDERInvalid) {
+		t.Fatal("expected invalid, got", err)
+	}
+	_ = buf.Set([]byte{0x04, 0x01, 0x00})
+	if _, err := bytebuffers.ReadOID(buf); !errors.Is(err, bytebuffers.ErrDERInvalid) {
+		t.Fatal("expected invalid, got", err)
+	}
+	// DER requires minimal lengths
+	_ = buf.Set([]byte{0x06, 0x82, 0x00, 0x01, 0x2A})
+	if _, err := bytebuffers.ReadOID(buf); !errors.Is(err, bytebuffers.ErrDERInvalid) {
+		t.Fatal("expected invalid, got", err)
+	}
+	// lengths beyond int32 are rejected instead of overflowing
+	_ = buf.Set([]byte{0x06, 0x84, 0x80, 0x00, 0x00, 0x00})
+	if _, err := bytebuffers.ReadOID(buf); !errors.Is(err, bytebuffers.ErrDERInvalid) {
+		t.Fatal("expected invalid, got", err)
+	}
+	if buf.Len() != 6 {
+		t.Fatal("invalid oid consumed")
+	}
+}
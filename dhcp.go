@@ -0,0 +1,244 @@
+package bytebuffers
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+)
+
+const (
+	DHCPBootRequest = 1
+	DHCPBootReply   = 2
+
+	DHCPDiscover = 1
+	DHCPOffer    = 2
+	DHCPRequest  = 3
+	DHCPDecline  = 4
+	DHCPAck      = 5
+	DHCPNak      = 6
+	DHCPRelease  = 7
+	DHCPInform   = 8
+
+	DHCPOptionPad                  = 0
+	DHCPOptionSubnetMask           = 1
+	DHCPOptionRouter               = 3
+	DHCPOptionDomainNameServer     = 6
+	DHCPOptionHostName             = 12
+	DHCPOptionRequestedIPAddress   = 50
+	DHCPOptionIPAddressLeaseTime   = 51
+	DHCPOptionMessageType          = 53
+	DHCPOptionServerIdentifier     = 54
+	DHCPOptionParameterRequestList = 55
+	DHCPOptionClientIdentifier     = 61
+	DHCPOptionEnd                  = 255
+
+	DHCPFlagBroadcast = 0x8000
+
+	dhcpHeaderLen   = 236
+	dhcpCookieLen   = 4
+	dhcpCHAddrLen   = 16
+	dhcpSNameLen    = 64
+	dhcpFileLen     = 128
+	dhcpMagicCookie = 0x63825363
+)
+
+var (
+	ErrDHCPInvalid = errors.New("bytebuffers.DHCP: invalid packet")
+)
+
+// DHCPv4Option
+// DHCP 选项（代码、数据）。
+type DHCPv4Option struct {
+	Code byte
+	Data []byte
+}
+
+// DHCPv4Options
+// 写入 DHCP 报文的参数，HType 为 0 时为以太网（1），HLen 取 CHAddr 的长度。
+//
+// Options 不需要包含结束选项，写入时会自动追加。
+type DHCPv4Options struct {
+	Op      byte
+	HType   byte
+	Hops    byte
+	Xid     uint32
+	Secs    uint16
+	Flags   uint16
+	CIAddr  net.IP
+	YIAddr  net.IP
+	SIAddr  net.IP
+	GIAddr  net.IP
+	CHAddr  net.HardwareAddr
+	SName   string
+	File    string
+	Options []DHCPv4Option
+}
+
+// DHCPv4Packet
+// 解析后的 DHCP 报文，Options 不含填充与结束选项。
+type DHCPv4Packet struct {
+	Op      byte
+	HType   byte
+	HLen    byte
+	Hops    byte
+	Xid     uint32
+	Secs    uint16
+	Flags   uint16
+	CIAddr  net.IP
+	YIAddr  net.IP
+	SIAddr  net.IP
+	GIAddr  net.IP
+	CHAddr  net.HardwareAddr
+	SName   string
+	File    string
+	Options []DHCPv4Option
+}
+
+// Option
+// 返回第一个代码为 code 的选项数据，不存在时为 nil。
+func (packet *DHCPv4Packet) Option(code byte) []byte {
+	for _, opt := range packet.Options {
+		if opt.Code == code {
+			return opt.Data
+		}
+	}
+	return nil
+}
+
+// MessageType
+// 返回选项 53 的消息类型，不存在时为 0。
+func (packet *DHCPv4Packet) MessageType() byte {
+	if data := packet.Option(DHCPOptionMessageType); len(data) == 1 {
+		return data[0]
+	}
+	return 0
+}
+
+// WriteDHCPv4Packet
+// 写入 DHCP 报文：236 字节的 BOOTP 首部、魔数 0x63825363、选项与结束选项。
+func WriteDHCPv4Packet(buf Buffer, opts DHCPv4Options) (err error) {
+	if len(opts.CHAddr) > dhcpCHAddrLen || len(opts.SName) >= dhcpSNameLen || len(opts.File) >= dhcpFileLen {
+		err = ErrDHCPInvalid
+		return
+	}
+	addrs := [4]net.IP{opts.CIAddr, opts.YIAddr, opts.SIAddr, opts.GIAddr}
+	for i, addr := range addrs {
+		if addr == nil {
+			continue
+		}
+		if addrs[i] = addr.To4(); addrs[i] == nil {
+			err = ErrDHCPInvalid
+			return
+		}
+	}
+	size := dhcpHeaderLen + dhcpCookieLen + 1
+	for _, opt := range opts.Options {
+		if opt.Code == DHCPOptionPad || opt.Code == DHCPOptionEnd || len(opt.Data) > 255 {
+			err = ErrDHCPInvalid
+			return
+		}
+		size += 2 + len(opt.Data)
+	}
+	p, borrowErr := buf.Borrow(size)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	clear(p[:dhcpHeaderLen])
+	p[0] = opts.Op
+	if p[1] = opts.HType; p[1] == 0 {
+		p[1] = 1
+	}
+	p[2] = byte(len(opts.CHAddr))
+	p[3] = opts.Hops
+	binary.BigEndian.PutUint32(p[4:], opts.Xid)
+	binary.BigEndian.PutUint16(p[8:], opts.Secs)
+	binary.BigEndian.PutUint16(p[10:], opts.Flags)
+	for i, addr := range addrs {
+		copy(p[12+i*4:16+i*4], addr)
+	}
+	copy(p[28:28+dhcpCHAddrLen], opts.CHAddr)
+	copy(p[44:44+dhcpSNameLen], opts.SName)
+	copy(p[108:108+dhcpFileLen], opts.File)
+	binary.BigEndian.PutUint32(p[dhcpHeaderLen:], dhcpMagicCookie)
+	n := dhcpHeaderLen + dhcpCookieLen
+	for _, opt := range opts.Options {
+		p[n] = opt.Code
+		p[n+1] = byte(len(opt.Data))
+		n += 2 + copy(p[n+2:], opt.Data)
+	}
+	p[n] = DHCPOptionEnd
+	buf.Return(size)
+	return
+}
+
+// ReadDHCPv4Packet
+// 读取 DHCP 报文，选项读至结束选项；没有结束选项时读至缓冲末尾。
+//
+// 魔数不符或选项被截断时返回 ErrDHCPInvalid，失败时不读掉。
+func ReadDHCPv4Packet(buf Buffer) (packet DHCPv4Packet, err error) {
+	p := buf.Peek(buf.Len())
+	if len(p) == 0 {
+		err = io.EOF
+		return
+	}
+	if len(p) < dhcpHeaderLen+dhcpCookieLen {
+		err = io.ErrUnexpectedEOF
+		return
+	}
+	if binary.BigEndian.Uint32(p[dhcpHeaderLen:]) != dhcpMagicCookie || p[2] > dhcpCHAddrLen {
+		err = ErrDHCPInvalid
+		return
+	}
+	n := dhcpHeaderLen + dhcpCookieLen
+	var options []DHCPv4Option
+	for n < len(p) {
+		code := p[n]
+		n++
+		if code == DHCPOptionEnd {
+			break
+		}
+		if code == DHCPOptionPad {
+			continue
+		}
+		if n == len(p) || n+1+int(p[n]) > len(p) {
+			err = ErrDHCPInvalid
+			return
+		}
+		size := int(p[n])
+		data := make([]byte, size)
+		copy(data, p[n+1:])
+		options = append(options, DHCPv4Option{Code: code, Data: data})
+		n += 1 + size
+	}
+	packet = DHCPv4Packet{
+		Op:      p[0],
+		HType:   p[1],
+		HLen:    p[2],
+		Hops:    p[3],
+		Xid:     binary.BigEndian.Uint32(p[4:]),
+		Secs:    binary.BigEndian.Uint16(p[8:]),
+		Flags:   binary.BigEndian.Uint16(p[10:]),
+		CIAddr:  net.IPv4(p[12], p[13], p[14], p[15]),
+		YIAddr:  net.IPv4(p[16], p[17], p[18], p[19]),
+		SIAddr:  net.IPv4(p[20], p[21], p[22], p[23]),
+		GIAddr:  net.IPv4(p[24], p[25], p[26], p[27]),
+		CHAddr:  net.HardwareAddr(bytes.Clone(p[28 : 28+int(p[2])])),
+		SName:   dhcpString(p[44 : 44+dhcpSNameLen]),
+		File:    dhcpString(p[108 : 108+dhcpFileLen]),
+		Options: options,
+	}
+	buf.Discard(n)
+	return
+}
+
+// dhcpString
+// 以 NUL 结尾的定长字段。
+func dhcpString(p []byte) string {
+	if i := bytes.IndexByte(p, 0); i >= 0 {
+		p = p[:i]
+	}
+	return string(p)
+}
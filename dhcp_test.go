@@ -0,0 +1,95 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestWriteDHCPv4Packet(t *testing.T) {
+	mac, _ := net.ParseMAC("00:0b:82:01:fc:42")
+	buf := bytebuffers.NewBuffer()
+	err := bytebuffers.WriteDHCPv4Packet(buf, bytebuffers.DHCPv4Options{
+		Op:     bytebuffers.DHCPBootRequest,
+		Xid:    0x00003D1D,
+		Flags:  bytebuffers.DHCPFlagBroadcast,
+		CHAddr: mac,
+		Options: []bytebuffers.DHCPv4Option{
+			{Code: bytebuffers.DHCPOptionMessageType, Data: []byte{bytebuffers.DHCPDiscover}},
+			{Code: bytebuffers.DHCPOptionClientIdentifier, Data: append([]byte{0x01}, mac...)},
+			{Code: bytebuffers.DHCPOptionRequestedIPAddress, Data: []byte{0, 0, 0, 0}},
+			{Code: bytebuffers.DHCPOptionParameterRequestList, Data: []byte{1, 3, 58, 59}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := buf.CloneBytes()
+	if len(p) != 236+4+3+9+6+6+1 {
+		t.Fatal("unexpected packet length", len(p))
+	}
+	if !bytes.Equal(p[:12], []byte{0x01, 0x01, 0x06, 0x00, 0x00, 0x00, 0x3D, 0x1D, 0x00, 0x00, 0x80, 0x00}) {
+		t.Fatal("unexpected header", p[:12])
+	}
+	if !bytes.Equal(p[28:44], append(bytes.Clone(mac), make([]byte, 10)...)) {
+		t.Fatal("unexpected chaddr", p[28:44])
+	}
+	if !bytes.Equal(p[236:240], []byte{0x63, 0x82, 0x53, 0x63}) {
+		t.Fatal("unexpected magic cookie", p[236:240])
+	}
+	if !bytes.Equal(p[240:243], []byte{53, 1, bytebuffers.DHCPDiscover}) || p[len(p)-1] != bytebuffers.DHCPOptionEnd {
+		t.Fatal("unexpected options", p[240:])
+	}
+
+	packet, err := bytebuffers.ReadDHCPv4Packet(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if packet.Op != bytebuffers.DHCPBootRequest || packet.HType != 1 || packet.HLen != 6 || packet.Xid != 0x3D1D {
+		t.Fatal("unexpected packet", packet)
+	}
+	if packet.CHAddr.String() != mac.String() || !packet.YIAddr.Equal(net.IPv4zero) {
+		t.Fatal("unexpected addresses", packet.CHAddr, packet.YIAddr)
+	}
+	if packet.MessageType() != bytebuffers.DHCPDiscover || len(packet.Options) != 4 {
+		t.Fatal("unexpected options", packet.Options)
+	}
+	if !bytes.Equal(packet.Option(bytebuffers.DHCPOptionParameterRequestList), []byte{1, 3, 58, 59}) {
+		t.Fatal("unexpected parameter request list")
+	}
+	if buf.Len() != 0 {
+		t.Fatal("packet must be discarded")
+	}
+}
+
+func TestReadDHCPv4Packet(t *testing.T) {
+	p := make([]byte, 236+4)
+	p[0], p[1], p[2] = bytebuffers.DHCPBootReply, 1, 6
+	copy(p[16:20], net.IPv4(192, 168, 0, 10).To4())
+	copy(p[44:], "server")
+	binary.BigEndian.PutUint32(p[236:], 0x63825363)
+	p = append(p, 53, 1, bytebuffers.DHCPOffer, 0, 0, 54, 4, 192, 168, 0, 1, 255)
+
+	buf := bytebuffers.NewBuffer()
+	_, _ = buf.Write(p)
+	packet, err := bytebuffers.ReadDHCPv4Packet(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if packet.MessageType() != bytebuffers.DHCPOffer || !packet.YIAddr.Equal(net.IPv4(192, 168, 0, 10)) || packet.SName != "server" {
+		t.Fatal("unexpected packet", packet)
+	}
+	if !bytes.Equal(packet.Option(bytebuffers.DHCPOptionServerIdentifier), []byte{192, 168, 0, 1}) {
+		t.Fatal("unexpected server identifier")
+	}
+
+	p[236] = 0
+	_, _ = buf.Write(p)
+	if _, err = bytebuffers.ReadDHCPv4Packet(buf); !errors.Is(err, bytebuffers.ErrDHCPInvalid) {
+		t.Fatal("expected invalid, got", err)
+	}
+}
@@ -0,0 +1,83 @@
+package bytebuffers
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+const (
+	DiameterAVPFlagVendor    = 0x80
+	DiameterAVPFlagMandatory = 0x40
+	DiameterAVPFlagProtected = 0x20
+
+	diameterAVPHeaderLen = 8
+	diameterMaxAVPLen    = 1<<24 - 1
+)
+
+var (
+	ErrDiameterInvalid = errors.New("bytebuffers.Diameter: invalid avp")
+)
+
+// WriteDiameterAVP
+// 写入 Diameter AVP：代码（4）、标志（1）、长度（3）、V 标志时的厂商 ID（4）、数据及填充至 4 字节对齐。
+//
+// 长度不包含填充。
+func WriteDiameterAVP(buf Buffer, code uint32, flags byte, vendorID uint32, data []byte) (err error) {
+	headerLen := diameterAVPHeaderLen
+	if flags&DiameterAVPFlagVendor != 0 {
+		headerLen += 4
+	}
+	if len(data) > diameterMaxAVPLen-headerLen {
+		err = ErrTooLarge
+		return
+	}
+	length := headerLen + len(data)
+	size := (length + 3) &^ 3
+	p, borrowErr := buf.Borrow(size)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	binary.BigEndian.PutUint32(p, code)
+	binary.BigEndian.PutUint32(p[4:], uint32(flags)<<24|uint32(length))
+	if flags&DiameterAVPFlagVendor != 0 {
+		binary.BigEndian.PutUint32(p[8:], vendorID)
+	}
+	copy(p[headerLen:], data)
+	clear(p[length:size])
+	buf.Return(size)
+	return
+}
+
+// ReadDiameterAVP
+// 读取 Diameter AVP 及其填充，只有 V 标志时才读取厂商 ID，不完整时不读掉。
+func ReadDiameterAVP(buf Buffer) (code uint32, flags byte, vendorID uint32, data []byte, err error) {
+	p, peekErr := peekFull(buf, diameterAVPHeaderLen)
+	if peekErr != nil {
+		err = peekErr
+		return
+	}
+	flags = p[4]
+	length := int(binary.BigEndian.Uint32(p[4:]) & 0x00FFFFFF)
+	headerLen := diameterAVPHeaderLen
+	if flags&DiameterAVPFlagVendor != 0 {
+		headerLen += 4
+	}
+	if length < headerLen {
+		flags, err = 0, ErrDiameterInvalid
+		return
+	}
+	size := (length + 3) &^ 3
+	if p, err = peekFull(buf, size); err != nil {
+		flags = 0
+		return
+	}
+	code = binary.BigEndian.Uint32(p)
+	if headerLen > diameterAVPHeaderLen {
+		vendorID = binary.BigEndian.Uint32(p[8:])
+	}
+	data = make([]byte, length-headerLen)
+	copy(data, p[headerLen:length])
+	buf.Discard(size)
+	return
+}
@@ -0,0 +1,59 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestWriteDiameterAVP(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	// Session-Id
+	sessionID := []byte("host.example.com;1;2")
+	if err := bytebuffers.WriteDiameterAVP(buf, 263, bytebuffers.DiameterAVPFlagMandatory, 0, sessionID); err != nil {
+		t.Fatal(err)
+	}
+	expected, _ := hex.DecodeString("00000107" + "4000001c" + hex.EncodeToString(sessionID))
+	if p := buf.CloneBytes(); !bytes.Equal(p, expected) {
+		t.Fatal("unexpected avp", hex.EncodeToString(p))
+	}
+	code, flags, vendorID, data, err := bytebuffers.ReadDiameterAVP(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code != 263 || flags != bytebuffers.DiameterAVPFlagMandatory || vendorID != 0 || !bytes.Equal(data, sessionID) {
+		t.Fatal("unexpected avp", code, flags, vendorID, data)
+	}
+
+	// 3GPP-RAT-Type, UTF8String "\x06" (EUTRAN) padded to 4 bytes
+	if err = bytebuffers.WriteDiameterAVP(buf, 21, bytebuffers.DiameterAVPFlagVendor, 10415, []byte{0x06}); err != nil {
+		t.Fatal(err)
+	}
+	expected, _ = hex.DecodeString("00000015" + "8000000d" + "000028af" + "06000000")
+	if p := buf.CloneBytes(); !bytes.Equal(p, expected) {
+		t.Fatal("unexpected avp", hex.EncodeToString(p))
+	}
+	if code, flags, vendorID, data, err = bytebuffers.ReadDiameterAVP(buf); err != nil {
+		t.Fatal(err)
+	}
+	if code != 21 || flags != bytebuffers.DiameterAVPFlagVendor || vendorID != 10415 || !bytes.Equal(data, []byte{0x06}) {
+		t.Fatal("unexpected avp", code, flags, vendorID, data)
+	}
+	if buf.Len() != 0 {
+		t.Fatal("padding must be discarded")
+	}
+
+	_, _ = buf.Write(expected[:15])
+	if _, _, _, _, err = bytebuffers.ReadDiameterAVP(buf); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal("expected unexpected EOF, got", err)
+	}
+	buf.Reset()
+	_, _ = buf.Write([]byte{0, 0, 0, 21, 0x80, 0, 0, 8})
+	if _, _, _, _, err = bytebuffers.ReadDiameterAVP(buf); !errors.Is(err, bytebuffers.ErrDiameterInvalid) {
+		t.Fatal("expected invalid, got", err)
+	}
+}
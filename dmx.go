@@ -0,0 +1,78 @@
+package bytebuffers
+
+import (
+	"errors"
+	"io"
+)
+
+const (
+	DMXStartCode = 0x00
+	RDMStartCode = 0xCC
+
+	dmxMaxChannels = 512
+	rdmMaxMessage  = 256
+)
+
+var (
+	ErrDMXTooManyChannels = errors.New("bytebuffers.DMX: more than 512 channels")
+	ErrDMXStartCode       = errors.New("bytebuffers.DMX: unexpected start code")
+)
+
+// WriteDMXFrame
+// 写入 DMX512 帧：起始码 0x00 与至多 512 个通道值。
+func WriteDMXFrame(buf Buffer, channels []byte) (err error) {
+	if len(channels) > dmxMaxChannels {
+		err = ErrDMXTooManyChannels
+		return
+	}
+	err = writeDMX(buf, DMXStartCode, channels)
+	return
+}
+
+// WriteRDMFrame
+// 写入 RDM 帧：起始码 0xCC 与 RDM 消息（子起始码之后的内容），消息至多 256 字节。
+func WriteRDMFrame(buf Buffer, message []byte) (err error) {
+	if len(message) > rdmMaxMessage {
+		err = ErrTooLarge
+		return
+	}
+	err = writeDMX(buf, RDMStartCode, message)
+	return
+}
+
+// ReadDMXFrame
+// 读取 DMX512 帧，帧为缓冲中的全部内容，返回起始码之后的通道值。
+//
+// 起始码不为 0x00 时返回 ErrDMXStartCode，通道超过 512 个时返回 ErrDMXTooManyChannels，均不读掉。
+func ReadDMXFrame(buf Buffer) (channels []byte, err error) {
+	p := buf.Peek(buf.Len())
+	if len(p) == 0 {
+		err = io.EOF
+		return
+	}
+	if p[0] != DMXStartCode {
+		err = ErrDMXStartCode
+		return
+	}
+	if len(p)-1 > dmxMaxChannels {
+		err = ErrDMXTooManyChannels
+		return
+	}
+	channels = make([]byte, len(p)-1)
+	copy(channels, p[1:])
+	buf.Discard(len(p))
+	return
+}
+
+func writeDMX(buf Buffer, startCode byte, data []byte) (err error) {
+	size := 1 + len(data)
+	p, borrowErr := buf.Borrow(size)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	p[0] = startCode
+	copy(p[1:], data)
+	buf.Return(size)
+	return
+}
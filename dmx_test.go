@@ -0,0 +1,48 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestDMXFrame(t *testing.T) {
+	channels := make([]byte, 512)
+	for i := range channels {
+		channels[i] = byte(i)
+	}
+	buf := bytebuffers.NewBuffer()
+	if err := bytebuffers.WriteDMXFrame(buf, channels); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 513 || buf.Peek(1)[0] != bytebuffers.DMXStartCode {
+		t.Fatal("unexpected frame", buf.Len())
+	}
+	read, err := bytebuffers.ReadDMXFrame(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(read, channels) || buf.Len() != 0 {
+		t.Fatal("unexpected channels")
+	}
+
+	if err = bytebuffers.WriteDMXFrame(buf, make([]byte, 513)); !errors.Is(err, bytebuffers.ErrDMXTooManyChannels) {
+		t.Fatal("expected too many channels, got", err)
+	}
+}
+
+func TestWriteRDMFrame(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	message := []byte{0x01, 0x18, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}
+	if err := bytebuffers.WriteRDMFrame(buf, message); err != nil {
+		t.Fatal(err)
+	}
+	if p := buf.CloneBytes(); p[0] != bytebuffers.RDMStartCode || !bytes.Equal(p[1:], message) {
+		t.Fatal("unexpected frame", p)
+	}
+	if _, err := bytebuffers.ReadDMXFrame(buf); !errors.Is(err, bytebuffers.ErrDMXStartCode) {
+		t.Fatal("expected unexpected start code, got", err)
+	}
+}
@@ -0,0 +1,274 @@
+package bytebuffers
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"strings"
+)
+
+const (
+	DNSTypeA     = 1
+	DNSTypeNS    = 2
+	DNSTypeCNAME = 5
+	DNSTypeSOA   = 6
+	DNSTypePTR   = 12
+	DNSTypeMX    = 15
+	DNSTypeTXT   = 16
+	DNSTypeAAAA  = 28
+	DNSTypeSRV   = 33
+
+	DNSClassINET = 1
+
+	DNSFlagResponse           = 0x8000
+	DNSFlagAuthoritative      = 0x0400
+	DNSFlagTruncated          = 0x0200
+	DNSFlagRecursionDesired   = 0x0100
+	DNSFlagRecursionAvailable = 0x0080
+
+	dnsHeaderLen     = 12
+	dnsMaxLabelLen   = 63
+	dnsMaxNameLen    = 255
+	dnsMaxPointers   = 16
+	dnsPointerPrefix = 0xC0
+)
+
+var (
+	ErrDNSInvalidName = errors.New("bytebuffers.DNS: invalid name")
+	ErrDNSInvalid     = errors.New("bytebuffers.DNS: invalid message")
+)
+
+// DNSQuestion
+// DNS 问题，Name 为带结尾点的完整域名。
+type DNSQuestion struct {
+	Name  string
+	Type  uint16
+	Class uint16
+}
+
+// DNSResourceRecord
+// DNS 资源记录，Data 为原始 RDATA。
+//
+// NS、CNAME、PTR 与 MX 记录的 RDATA 中的域名可能被压缩，解压后放在 Target 中。
+type DNSResourceRecord struct {
+	Name   string
+	Type   uint16
+	Class  uint16
+	TTL    uint32
+	Data   []byte
+	Target string
+}
+
+// DNSMessage
+// DNS 消息。
+type DNSMessage struct {
+	ID          uint16
+	Flags       uint16
+	Questions   []DNSQuestion
+	Answers     []DNSResourceRecord
+	Authorities []DNSResourceRecord
+	Additionals []DNSResourceRecord
+}
+
+// WriteDNSQuery
+// 写入只含一个问题的 DNS 查询：随机 ID、标志 0x0100（RD）、QDCOUNT 为 1 与以标签编码的问题。
+func WriteDNSQuery(buf Buffer, name string, qtype, qclass uint16) (err error) {
+	qname, nameErr := appendDNSName(nil, name)
+	if nameErr != nil {
+		err = nameErr
+		return
+	}
+	size := dnsHeaderLen + len(qname) + 4
+	p, borrowErr := buf.Borrow(size)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	binary.BigEndian.PutUint16(p, uint16(rand.Uint32()))
+	binary.BigEndian.PutUint16(p[2:], DNSFlagRecursionDesired)
+	binary.BigEndian.PutUint16(p[4:], 1) // qdcount
+	clear(p[6:dnsHeaderLen])             // ancount, nscount and arcount
+	n := dnsHeaderLen + copy(p[dnsHeaderLen:], qname)
+	binary.BigEndian.PutUint16(p[n:], qtype)
+	binary.BigEndian.PutUint16(p[n+2:], qclass)
+	buf.Return(size)
+	return
+}
+
+// ReadDNSMessage
+// 将整个可读内容作为一个 DNS 消息（如一个 UDP 数据报）解析，支持压缩的域名，成功后全部读掉，出错时不读掉。
+func ReadDNSMessage(buf Buffer) (message DNSMessage, err error) {
+	n := buf.Len()
+	if n == 0 {
+		err = io.EOF
+		return
+	}
+	p := buf.Peek(n)
+	if len(p) < dnsHeaderLen {
+		err = ErrDNSInvalid
+		return
+	}
+	msg := DNSMessage{
+		ID:    binary.BigEndian.Uint16(p),
+		Flags: binary.BigEndian.Uint16(p[2:]),
+	}
+	qdCount := int(binary.BigEndian.Uint16(p[4:]))
+	anCount := int(binary.BigEndian.Uint16(p[6:]))
+	nsCount := int(binary.BigEndian.Uint16(p[8:]))
+	arCount := int(binary.BigEndian.Uint16(p[10:]))
+	off := dnsHeaderLen
+	for i := 0; i < qdCount; i++ {
+		var question DNSQuestion
+		if question.Name, off, err = parseDNSName(p, off); err != nil {
+			return
+		}
+		if len(p)-off < 4 {
+			err = ErrDNSInvalid
+			return
+		}
+		question.Type = binary.BigEndian.Uint16(p[off:])
+		question.Class = binary.BigEndian.Uint16(p[off+2:])
+		off += 4
+		msg.Questions = append(msg.Questions, question)
+	}
+	sections := []struct {
+		count   int
+		records *[]DNSResourceRecord
+	}{
+		{anCount, &msg.Answers},
+		{nsCount, &msg.Authorities},
+		{arCount, &msg.Additionals},
+	}
+	for _, section := range sections {
+		for i := 0; i < section.count; i++ {
+			var record DNSResourceRecord
+			if record, off, err = parseDNSResourceRecord(p, off); err != nil {
+				return
+			}
+			*section.records = append(*section.records, record)
+		}
+	}
+	if off != len(p) {
+		err = ErrDNSInvalid
+		return
+	}
+	message = msg
+	buf.Discard(n)
+	return
+}
+
+func parseDNSResourceRecord(p []byte, off int) (record DNSResourceRecord, next int, err error) {
+	if record.Name, off, err = parseDNSName(p, off); err != nil {
+		return
+	}
+	if len(p)-off < 10 {
+		err = ErrDNSInvalid
+		return
+	}
+	record.Type = binary.BigEndian.Uint16(p[off:])
+	record.Class = binary.BigEndian.Uint16(p[off+2:])
+	record.TTL = binary.BigEndian.Uint32(p[off+4:])
+	rdLength := int(binary.BigEndian.Uint16(p[off+8:]))
+	off += 10
+	if len(p)-off < rdLength {
+		err = ErrDNSInvalid
+		return
+	}
+	record.Data = make([]byte, rdLength)
+	copy(record.Data, p[off:off+rdLength])
+	switch record.Type {
+	case DNSTypeNS, DNSTypeCNAME, DNSTypePTR, DNSTypeMX:
+		start := off
+		if record.Type == DNSTypeMX {
+			start += 2 // preference
+		}
+		if start > off+rdLength {
+			err = ErrDNSInvalid
+			return
+		}
+		var end int
+		if record.Target, end, err = parseDNSName(p[:off+rdLength], start); err != nil {
+			return
+		}
+		if end != off+rdLength {
+			err = ErrDNSInvalid
+			return
+		}
+	}
+	next = off + rdLength
+	return
+}
+
+// parseDNSName decodes the possibly compressed name at off, returning the offset just past it.
+func parseDNSName(p []byte, off int) (name string, next int, err error) {
+	var sb strings.Builder
+	pointers := 0
+	next = -1
+	for {
+		if off >= len(p) {
+			err = ErrDNSInvalid
+			return
+		}
+		length := int(p[off])
+		switch {
+		case length == 0:
+			if next < 0 {
+				next = off + 1
+			}
+			if sb.Len() == 0 {
+				sb.WriteByte('.')
+			}
+			name = sb.String()
+			return
+		case length&dnsPointerPrefix == dnsPointerPrefix:
+			if off+1 >= len(p) {
+				err = ErrDNSInvalid
+				return
+			}
+			target := int(binary.BigEndian.Uint16(p[off:]) &^ (dnsPointerPrefix << 8))
+			// pointers must go backwards, which also rules out loops
+			if pointers++; pointers > dnsMaxPointers || target >= off {
+				err = ErrDNSInvalid
+				return
+			}
+			if next < 0 {
+				next = off + 2
+			}
+			off = target
+		case length&dnsPointerPrefix != 0:
+			err = ErrDNSInvalid
+			return
+		default:
+			if off+1+length > len(p) || sb.Len()+length+1 > dnsMaxNameLen {
+				err = ErrDNSInvalid
+				return
+			}
+			sb.Write(p[off+1 : off+1+length])
+			sb.WriteByte('.')
+			off += 1 + length
+		}
+	}
+}
+
+// appendDNSName appends name as uncompressed wire labels, a trailing dot is optional.
+func appendDNSName(p []byte, name string) (b []byte, err error) {
+	b = p
+	name = strings.TrimSuffix(name, ".")
+	if len(name)+2 > dnsMaxNameLen {
+		err = ErrDNSInvalidName
+		return
+	}
+	if name != "" {
+		for _, label := range strings.Split(name, ".") {
+			if len(label) == 0 || len(label) > dnsMaxLabelLen {
+				b, err = p, ErrDNSInvalidName
+				return
+			}
+			b = append(b, byte(len(label)))
+			b = append(b, label...)
+		}
+	}
+	b = append(b, 0)
+	return
+}
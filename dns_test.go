@@ -0,0 +1,83 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestWriteDNSQuery(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	if err := bytebuffers.WriteDNSQuery(buf, "www.example.com.", bytebuffers.DNSTypeA, bytebuffers.DNSClassINET); err != nil {
+		t.Fatal(err)
+	}
+	p := buf.CloneBytes()
+	expected, _ := hex.DecodeString("0100" + "0001" + "0000" + "0000" + "0000" +
+		"03777777" + "076578616d706c65" + "03636f6d" + "00" + "0001" + "0001")
+	if !bytes.Equal(p[2:], expected) {
+		t.Fatal("unexpected query", hex.EncodeToString(p))
+	}
+
+	message, err := bytebuffers.ReadDNSMessage(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(message.Questions) != 1 || message.Questions[0] != (bytebuffers.DNSQuestion{Name: "www.example.com.", Type: bytebuffers.DNSTypeA, Class: bytebuffers.DNSClassINET}) {
+		t.Fatal("unexpected questions", message.Questions)
+	}
+
+	if err = bytebuffers.WriteDNSQuery(buf, "www..com", bytebuffers.DNSTypeA, bytebuffers.DNSClassINET); !errors.Is(err, bytebuffers.ErrDNSInvalidName) {
+		t.Fatal("expected invalid name, got", err)
+	}
+	if err = bytebuffers.WriteDNSQuery(buf, string(bytes.Repeat([]byte{'a'}, 64))+".com", bytebuffers.DNSTypeA, bytebuffers.DNSClassINET); !errors.Is(err, bytebuffers.ErrDNSInvalidName) {
+		t.Fatal("expected invalid name, got", err)
+	}
+}
+
+func TestReadDNSMessage(t *testing.T) {
+	// response for www.github.com A, with the answer names compressed
+	response, _ := hex.DecodeString("b3e1" + "8180" + "0001" + "0002" + "0000" + "0000" +
+		"03777777" + "06676974687562" + "03636f6d" + "00" + "0001" + "0001" +
+		"c00c" + "0005" + "0001" + "00000e10" + "0002" + "c010" +
+		"c010" + "0001" + "0001" + "0000003c" + "0004" + "8c527104")
+	buf := bytebuffers.NewBuffer()
+	_, _ = buf.Write(response)
+	message, err := bytebuffers.ReadDNSMessage(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Fatal("message must be discarded")
+	}
+	if message.ID != 0xb3e1 || message.Flags&bytebuffers.DNSFlagResponse == 0 || message.Flags&bytebuffers.DNSFlagRecursionAvailable == 0 {
+		t.Fatal("unexpected header", message.ID, message.Flags)
+	}
+	if len(message.Questions) != 1 || message.Questions[0].Name != "www.github.com." {
+		t.Fatal("unexpected questions", message.Questions)
+	}
+	if len(message.Answers) != 2 {
+		t.Fatal("unexpected answers", message.Answers)
+	}
+	cname := message.Answers[0]
+	if cname.Name != "www.github.com." || cname.Type != bytebuffers.DNSTypeCNAME || cname.TTL != 3600 || cname.Target != "github.com." {
+		t.Fatal("unexpected cname", cname)
+	}
+	a := message.Answers[1]
+	if a.Name != "github.com." || a.Type != bytebuffers.DNSTypeA || a.TTL != 60 || !net.IP(a.Data).Equal(net.IPv4(140, 82, 113, 4)) {
+		t.Fatal("unexpected a", a)
+	}
+
+	// pointer loop
+	loop := append(append([]byte{}, response[:12]...), 0xc0, 0x0c, 0x00, 0x01, 0x00, 0x01)
+	_, _ = buf.Write(loop)
+	if _, err = bytebuffers.ReadDNSMessage(buf); !errors.Is(err, bytebuffers.ErrDNSInvalid) {
+		t.Fatal("expected invalid, got", err)
+	}
+	if buf.Len() != len(loop) {
+		t.Fatal("invalid message must not be discarded")
+	}
+}
@@ -0,0 +1,177 @@
+package bytebuffers
+
+import (
+	"bytes"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"io"
+)
+
+var (
+	ErrBinaryInvalidType = errors.New("bytebuffers.Buffer: invalid type for fixed-size binary encoding")
+)
+
+func (buf *buffer) EncodeBase32(enc *base32.Encoding) (err error) {
+	if enc == nil {
+		enc = base32.StdEncoding
+	}
+	err = buf.encode(enc.EncodedLen(buf.Len()), enc.AppendEncode)
+	return
+}
+
+func (buf *buffer) DecodeBase32(enc *base32.Encoding) (err error) {
+	if enc == nil {
+		enc = base32.StdEncoding
+	}
+	err = buf.decode(enc.DecodedLen(buf.Len()), enc.Decode)
+	return
+}
+
+func (buf *buffer) EncodeURL(padded bool) (err error) {
+	enc := base64.RawURLEncoding
+	if padded {
+		enc = base64.URLEncoding
+	}
+	err = buf.encode(enc.EncodedLen(buf.Len()), enc.AppendEncode)
+	return
+}
+
+func (buf *buffer) DecodeURL(padded bool) (err error) {
+	enc := base64.RawURLEncoding
+	if padded {
+		enc = base64.URLEncoding
+	}
+	err = buf.decode(enc.DecodedLen(buf.Len()), enc.Decode)
+	return
+}
+
+func (buf *buffer) WriteBinaryFixed(v interface{}) (err error) {
+	buf.ur = 0
+	if buf.Borrowing() {
+		err = ErrWriteWhenBorrowing
+		return
+	}
+	size := binary.Size(v)
+	if size < 0 {
+		err = ErrBinaryInvalidType
+		return
+	}
+	if buf.c-buf.w < size {
+		if err = buf.grow(size); err != nil {
+			return
+		}
+	}
+	n, encodeErr := binary.Encode(buf.b[buf.w:], binary.BigEndian, v)
+	if encodeErr != nil {
+		err = encodeErr
+		return
+	}
+	buf.w += n
+	buf.a = buf.w
+	return
+}
+
+func (buf *buffer) ReadBinaryFixed(v interface{}) (err error) {
+	buf.ur = 0
+	size := binary.Size(v)
+	if size < 0 {
+		err = ErrBinaryInvalidType
+		return
+	}
+	bLen := buf.Len()
+	if bLen == 0 && size > 0 {
+		err = io.EOF
+		return
+	}
+	if bLen < size {
+		err = io.ErrUnexpectedEOF
+		return
+	}
+	n, decodeErr := binary.Decode(buf.b[buf.r:buf.w], binary.BigEndian, v)
+	if decodeErr != nil {
+		err = decodeErr
+		return
+	}
+	buf.r += n
+	buf.shrink()
+	return
+}
+
+func (buf *buffer) WriteGob(v interface{}) (err error) {
+	buf.ur = 0
+	if buf.Borrowing() {
+		err = ErrWriteWhenBorrowing
+		return
+	}
+	err = gob.NewEncoder(buf).Encode(v)
+	return
+}
+
+func (buf *buffer) ReadGob(v interface{}) (err error) {
+	buf.ur = 0
+	bLen := buf.Len()
+	if bLen == 0 {
+		err = io.EOF
+		return
+	}
+	// bytes.Reader is an io.ByteReader, so the decoder never reads ahead.
+	r := bytes.NewReader(buf.b[buf.r:buf.w])
+	if err = gob.NewDecoder(r).Decode(v); err != nil {
+		return
+	}
+	buf.r += bLen - r.Len()
+	buf.shrink()
+	return
+}
+
+// encode
+// 将可读内容编码到可写区域，再移回读位置以替换之。
+func (buf *buffer) encode(size int, appendEncode func(dst, src []byte) []byte) (err error) {
+	buf.ur = 0
+	if buf.Borrowing() {
+		err = ErrWriteWhenBorrowing
+		return
+	}
+	if buf.Len() == 0 {
+		return
+	}
+	if buf.c-buf.w < size {
+		if err = buf.grow(size); err != nil {
+			return
+		}
+	}
+	encoded := appendEncode(buf.b[buf.w:buf.w], buf.b[buf.r:buf.w])
+	buf.w = buf.r + copy(buf.b[buf.r:], encoded)
+	buf.a = buf.w
+	return
+}
+
+// decode
+// 将可读内容解码到可写区域，成功后再移回读位置以替换之，失败时可读内容不变。
+func (buf *buffer) decode(size int, decode func(dst, src []byte) (int, error)) (err error) {
+	buf.ur = 0
+	if buf.Borrowing() {
+		err = ErrWriteWhenBorrowing
+		return
+	}
+	if buf.Len() == 0 {
+		return
+	}
+	if buf.c-buf.w < size {
+		if err = buf.grow(size); err != nil {
+			return
+		}
+	}
+	n, decodeErr := decode(buf.b[buf.w:buf.w+size], buf.b[buf.r:buf.w])
+	if decodeErr != nil {
+		err = decodeErr
+		return
+	}
+	buf.w = buf.r + copy(buf.b[buf.r:], buf.b[buf.w:buf.w+n])
+	buf.a = buf.w
+	buf.shrink()
+	return
+}
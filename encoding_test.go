@@ -0,0 +1,227 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestBuffer_EncodeBase32(t *testing.T) {
+	encodings := []*base32.Encoding{nil, base32.StdEncoding, base32.HexEncoding, base32.StdEncoding.WithPadding(base32.NoPadding)}
+	cases := []string{"", "f", "fo", "foo", "foob", "fooba", "foobar", "0123456789abcdef"}
+	for _, enc := range encodings {
+		ref := enc
+		if ref == nil {
+			ref = base32.StdEncoding
+		}
+		for _, c := range cases {
+			buf := bytebuffers.NewBuffer()
+			_, _ = buf.WriteString("xx")
+			buf.Discard(2)
+			_, _ = buf.WriteString(c)
+			if err := buf.EncodeBase32(enc); err != nil {
+				t.Fatal(err)
+			}
+			if encoded := string(buf.CloneBytes()); encoded != ref.EncodeToString([]byte(c)) {
+				t.Fatal("unexpected encoding", encoded, ref.EncodeToString([]byte(c)))
+			}
+			if err := buf.DecodeBase32(enc); err != nil {
+				t.Fatal(err)
+			}
+			if decoded := string(buf.CloneBytes()); decoded != c {
+				t.Fatal("round trip mismatch", decoded, c)
+			}
+		}
+	}
+}
+
+func TestBuffer_DecodeBase32(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	_, _ = buf.WriteString("MZXW6===")
+	if err := buf.DecodeBase32(nil); err != nil || string(buf.CloneBytes()) != "foo" {
+		t.Fatal(string(buf.CloneBytes()), err)
+	}
+
+	_ = buf.SetString("MZXW6!==")
+	var corrupt base32.CorruptInputError
+	if err := buf.DecodeBase32(nil); !errors.As(err, &corrupt) {
+		t.Fatal("expected corrupt input, got", err)
+	}
+	if !bytes.Equal(buf.CloneBytes(), []byte("MZXW6!==")) {
+		t.Fatal("buffer changed on failure")
+	}
+}
+
+func TestBuffer_EncodeURL(t *testing.T) {
+	// https://jwt.io default HS256 token signed with "your-256-bit-secret".
+	const token = "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9." +
+		"eyJzdWIiOiIxMjM0NTY3ODkwIiwibmFtZSI6IkpvaG4gRG9lIiwiaWF0IjoxNTE2MjM5MDIyfQ." +
+		"SflKxwRJSMeKKF2QT4fwpMeJf36POk6yJV_adQssw5c"
+
+	segment := func(p []byte) string {
+		buf := bytebuffers.NewBuffer()
+		_, _ = buf.Write(p)
+		if err := buf.EncodeURL(false); err != nil {
+			t.Fatal(err)
+		}
+		return string(buf.CloneBytes())
+	}
+	signing := segment([]byte(`{"alg":"HS256","typ":"JWT"}`)) + "." +
+		segment([]byte(`{"sub":"1234567890","name":"John Doe","iat":1516239022}`))
+	mac := hmac.New(sha256.New, []byte("your-256-bit-secret"))
+	mac.Write([]byte(signing))
+	jwt := signing + "." + segment(mac.Sum(nil))
+	if jwt != token {
+		t.Fatal("unexpected token", jwt)
+	}
+
+	for _, padded := range []bool{true, false} {
+		buf := bytebuffers.NewBuffer()
+		_, _ = buf.Write([]byte{0xFB, 0xFF, 0xBF, 0x01})
+		if err := buf.EncodeURL(padded); err != nil {
+			t.Fatal(err)
+		}
+		encoded := string(buf.CloneBytes())
+		if strings.ContainsAny(encoded, "+/") || strings.Contains(encoded, "=") != padded {
+			t.Fatal("unexpected url encoding", encoded)
+		}
+		if err := buf.DecodeURL(padded); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(buf.CloneBytes(), []byte{0xFB, 0xFF, 0xBF, 0x01}) {
+			t.Fatal("round trip mismatch")
+		}
+	}
+}
+
+func TestBuffer_DecodeURL(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	_, _ = buf.WriteString("a+b/")
+	var corrupt base64.CorruptInputError
+	if err := buf.DecodeURL(false); !errors.As(err, &corrupt) {
+		t.Fatal("expected corrupt input, got", err)
+	}
+	if string(buf.CloneBytes()) != "a+b/" {
+		t.Fatal("buffer changed on failure")
+	}
+}
+
+type fixedHeader struct {
+	Magic   uint32
+	Version uint8
+	Flags   uint8
+	Length  uint16
+	Offset  int64
+	Ratio   float32
+	Tags    [2]uint16
+}
+
+func TestBuffer_WriteBinaryFixed(t *testing.T) {
+	h := fixedHeader{
+		Magic:   0xCAFEBABE,
+		Version: 1,
+		Flags:   0x80,
+		Length:  0x0102,
+		Offset:  -1,
+		Ratio:   0.5,
+		Tags:    [2]uint16{0x0A0B, 0x0C0D},
+	}
+	buf := bytebuffers.NewBuffer()
+	if err := buf.WriteBinaryFixed(&h); err != nil {
+		t.Fatal(err)
+	}
+	expected := make([]byte, 0, binary.Size(h))
+	expected, _ = binary.Append(expected, binary.BigEndian, h)
+	if !bytes.Equal(buf.CloneBytes(), expected) {
+		t.Fatal("encoding mismatch")
+	}
+	if !bytes.Equal(buf.Peek(4), []byte{0xCA, 0xFE, 0xBA, 0xBE}) {
+		t.Fatal("not big endian", buf.Peek(4))
+	}
+
+	var rh fixedHeader
+	if err := buf.ReadBinaryFixed(&rh); err != nil {
+		t.Fatal(err)
+	}
+	if rh != h {
+		t.Fatal("round trip mismatch", rh, h)
+	}
+
+	if err := buf.WriteBinaryFixed(map[string]int{}); !errors.Is(err, bytebuffers.ErrBinaryInvalidType) {
+		t.Fatal("expected invalid type, got", err)
+	}
+	_, _ = buf.Write([]byte{1, 2, 3})
+	if err := buf.ReadBinaryFixed(&rh); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal("expected unexpected EOF, got", err)
+	}
+	if buf.Len() != 3 {
+		t.Fatal("truncated data consumed")
+	}
+}
+
+type gobInner struct {
+	Name  string
+	Score float64
+}
+
+type gobOuter struct {
+	ID      int64
+	Inner   gobInner
+	Tags    []string
+	Attrs   map[string]int
+	Members []*gobInner
+}
+
+func TestBuffer_WriteGob(t *testing.T) {
+	v := gobOuter{
+		ID:      42,
+		Inner:   gobInner{Name: "inner", Score: 1.5},
+		Tags:    []string{"a", "b"},
+		Attrs:   map[string]int{"x": 1, "y": 2},
+		Members: []*gobInner{{Name: "m1"}, {Name: "m2", Score: -3}},
+	}
+	buf := bytebuffers.NewBuffer()
+	if err := buf.WriteGob(v); err != nil {
+		t.Fatal(err)
+	}
+	if err := buf.WriteGob(v); err != nil {
+		t.Fatal(err)
+	}
+	single := buf.Len() / 2
+	for i := 0; i < 2; i++ {
+		var rv gobOuter
+		if err := buf.ReadGob(&rv); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(rv, v) {
+			t.Fatal("round trip mismatch", rv, v)
+		}
+		if buf.Len() != single*(1-i) {
+			t.Fatal("unexpected consumed bytes", buf.Len())
+		}
+	}
+}
+
+func TestBuffer_ReadGob(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	_ = buf.WriteGob(gobInner{Name: "inner"})
+	n := buf.Len()
+	var wrong []int
+	err := buf.ReadGob(&wrong)
+	if err == nil || !strings.HasPrefix(err.Error(), "gob:") {
+		t.Fatal("expected gob error, got", err)
+	}
+	if buf.Len() != n {
+		t.Fatal("buffer consumed on failure")
+	}
+}
@@ -0,0 +1,135 @@
+package bytebuffers
+
+import (
+	"encoding/binary"
+)
+
+// writeUint16
+// 通过 Borrow 与 Return 写入，不产生分配。
+func writeUint16(buf Buffer, order binary.ByteOrder, v uint16) (err error) {
+	p, borrowErr := buf.Borrow(2)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	order.PutUint16(p, v)
+	buf.Return(2)
+	return
+}
+
+// readUint16
+// 通过 Peek 与 Discard 读取，不完整时不读掉。
+func readUint16(buf Buffer, order binary.ByteOrder) (v uint16, err error) {
+	p, peekErr := peekFull(buf, 2)
+	if peekErr != nil {
+		err = peekErr
+		return
+	}
+	v = order.Uint16(p)
+	buf.Discard(2)
+	return
+}
+
+func writeUint32(buf Buffer, order binary.ByteOrder, v uint32) (err error) {
+	p, borrowErr := buf.Borrow(4)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	order.PutUint32(p, v)
+	buf.Return(4)
+	return
+}
+
+func readUint32(buf Buffer, order binary.ByteOrder) (v uint32, err error) {
+	p, peekErr := peekFull(buf, 4)
+	if peekErr != nil {
+		err = peekErr
+		return
+	}
+	v = order.Uint32(p)
+	buf.Discard(4)
+	return
+}
+
+func writeUint64(buf Buffer, order binary.ByteOrder, v uint64) (err error) {
+	p, borrowErr := buf.Borrow(8)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	order.PutUint64(p, v)
+	buf.Return(8)
+	return
+}
+
+func readUint64(buf Buffer, order binary.ByteOrder) (v uint64, err error) {
+	p, peekErr := peekFull(buf, 8)
+	if peekErr != nil {
+		err = peekErr
+		return
+	}
+	v = order.Uint64(p)
+	buf.Discard(8)
+	return
+}
+
+func (buf *buffer) WriteUint16BE(v uint16) (err error) {
+	err = writeUint16(buf, binary.BigEndian, v)
+	return
+}
+
+func (buf *buffer) ReadUint16BE() (v uint16, err error) {
+	v, err = readUint16(buf, binary.BigEndian)
+	return
+}
+
+func (buf *buffer) WriteUint16LE(v uint16) (err error) {
+	err = writeUint16(buf, binary.LittleEndian, v)
+	return
+}
+
+func (buf *buffer) ReadUint16LE() (v uint16, err error) {
+	v, err = readUint16(buf, binary.LittleEndian)
+	return
+}
+
+func (buf *buffer) WriteUint32BE(v uint32) (err error) {
+	err = writeUint32(buf, binary.BigEndian, v)
+	return
+}
+
+func (buf *buffer) ReadUint32BE() (v uint32, err error) {
+	v, err = readUint32(buf, binary.BigEndian)
+	return
+}
+
+func (buf *buffer) WriteUint32LE(v uint32) (err error) {
+	err = writeUint32(buf, binary.LittleEndian, v)
+	return
+}
+
+func (buf *buffer) ReadUint32LE() (v uint32, err error) {
+	v, err = readUint32(buf, binary.LittleEndian)
+	return
+}
+
+func (buf *buffer) WriteUint64BE(v uint64) (err error) {
+	err = writeUint64(buf, binary.BigEndian, v)
+	return
+}
+
+func (buf *buffer) ReadUint64BE() (v uint64, err error) {
+	v, err = readUint64(buf, binary.BigEndian)
+	return
+}
+
+func (buf *buffer) WriteUint64LE(v uint64) (err error) {
+	err = writeUint64(buf, binary.LittleEndian, v)
+	return
+}
+
+func (buf *buffer) ReadUint64LE() (v uint64, err error) {
+	v, err = readUint64(buf, binary.LittleEndian)
+	return
+}
@@ -0,0 +1,96 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestBuffer_WriteUint(t *testing.T) {
+	buffers := []bytebuffers.Buffer{
+		bytebuffers.NewBuffer(),
+		bytebuffers.NewConcurrentBuffer(),
+		bytebuffers.NewRingBuffer(16),
+		bytebuffers.NewChainBuffer(16),
+		bytebuffers.NewFixedBuffer(64),
+	}
+	expected := []byte{
+		0x01, 0x02,
+		0x02, 0x01,
+		0x01, 0x02, 0x03, 0x04,
+		0x04, 0x03, 0x02, 0x01,
+		0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08,
+		0x08, 0x07, 0x06, 0x05, 0x04, 0x03, 0x02, 0x01,
+	}
+	for _, buf := range buffers {
+		_ = buf.WriteUint16BE(0x0102)
+		_ = buf.WriteUint16LE(0x0102)
+		_ = buf.WriteUint32BE(0x01020304)
+		_ = buf.WriteUint32LE(0x01020304)
+		_ = buf.WriteUint64BE(0x0102030405060708)
+		_ = buf.WriteUint64LE(0x0102030405060708)
+		if p := buf.CloneBytes(); !bytes.Equal(p, expected) {
+			t.Fatal("encoding mismatch", p)
+		}
+		if v, err := buf.ReadUint16BE(); err != nil || v != 0x0102 {
+			t.Fatal("uint16 be", v, err)
+		}
+		if v, err := buf.ReadUint16LE(); err != nil || v != 0x0102 {
+			t.Fatal("uint16 le", v, err)
+		}
+		if v, err := buf.ReadUint32BE(); err != nil || v != 0x01020304 {
+			t.Fatal("uint32 be", v, err)
+		}
+		if v, err := buf.ReadUint32LE(); err != nil || v != 0x01020304 {
+			t.Fatal("uint32 le", v, err)
+		}
+		if v, err := buf.ReadUint64BE(); err != nil || v != 0x0102030405060708 {
+			t.Fatal("uint64 be", v, err)
+		}
+		if v, err := buf.ReadUint64LE(); err != nil || v != 0x0102030405060708 {
+			t.Fatal("uint64 le", v, err)
+		}
+		if _, err := buf.ReadUint16BE(); !errors.Is(err, io.EOF) {
+			t.Fatal("expected EOF, got", err)
+		}
+		// incomplete values are not consumed
+		_, _ = buf.Write([]byte{1, 2, 3})
+		if _, err := buf.ReadUint32LE(); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Fatal("expected unexpected EOF, got", err)
+		}
+		if buf.Len() != 3 {
+			t.Fatal("incomplete value was consumed", buf.Len())
+		}
+		_, _ = buf.Write([]byte{4})
+		if v, err := buf.ReadUint32LE(); err != nil || v != 0x04030201 {
+			t.Fatal("uint32 le", v, err)
+		}
+		if err := buf.ReadOnly().WriteUint64BE(1); !errors.Is(err, bytebuffers.ErrReadOnly) {
+			t.Fatal("expected read only, got", err)
+		}
+	}
+}
+
+func TestBuffer_WriteUintAllocs(t *testing.T) {
+	buffers := []bytebuffers.Buffer{
+		bytebuffers.NewBuffer(),
+		bytebuffers.NewRingBuffer(64),
+		bytebuffers.NewChainBuffer(64),
+	}
+	for _, buf := range buffers {
+		allocs := testing.AllocsPerRun(100, func() {
+			_ = buf.WriteUint16BE(1)
+			_ = buf.WriteUint32LE(2)
+			_ = buf.WriteUint64BE(3)
+			_, _ = buf.ReadUint16BE()
+			_, _ = buf.ReadUint32LE()
+			_, _ = buf.ReadUint64BE()
+		})
+		if allocs != 0 {
+			t.Fatal("unexpected allocations", allocs)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package bytebuffers
+
+import (
+	"encoding/binary"
+	"errors"
+	"net"
+)
+
+const (
+	EtherTypeIPv4 = 0x0800
+	EtherTypeARP  = 0x0806
+	EtherTypeVLAN = 0x8100
+	EtherTypeIPv6 = 0x86DD
+
+	ARPRequest = 1
+	ARPReply   = 2
+
+	ethernetHeaderLen = 14
+	vlanTagLen        = 4
+	arpPacketLen      = 28
+)
+
+var (
+	ErrEthernetInvalidMAC  = errors.New("bytebuffers.Ethernet: invalid mac address")
+	ErrEthernetInvalidVLAN = errors.New("bytebuffers.Ethernet: invalid vlan tag")
+	ErrARPInvalidAddress   = errors.New("bytebuffers.ARP: invalid address")
+)
+
+// WriteEthernetFrame
+// 写入以太网帧：目的 MAC（6）、源 MAC（6）、EtherType（2）与 payload。
+//
+// 不填充至最小帧长，也不写入 FCS。
+func WriteEthernetFrame(buf Buffer, dst, src net.HardwareAddr, etherType uint16, payload []byte) (err error) {
+	if len(dst) != 6 || len(src) != 6 {
+		err = ErrEthernetInvalidMAC
+		return
+	}
+	if len(payload) > maxInt-ethernetHeaderLen {
+		err = ErrTooLarge
+		return
+	}
+	size := ethernetHeaderLen + len(payload)
+	p, borrowErr := buf.Borrow(size)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	copy(p[0:6], dst)
+	copy(p[6:12], src)
+	binary.BigEndian.PutUint16(p[12:], etherType)
+	copy(p[ethernetHeaderLen:], payload)
+	buf.Return(size)
+	return
+}
+
+// WriteEthernetVLAN
+// 写入带 802.1Q 标签的以太网帧，标签（TPID 0x8100、优先级、VLAN ID）位于源 MAC 与 EtherType 之间。
+//
+// priority 须小于 8，vlanID 须小于 4096。
+func WriteEthernetVLAN(buf Buffer, dst, src net.HardwareAddr, priority byte, vlanID uint16, etherType uint16, payload []byte) (err error) {
+	if len(dst) != 6 || len(src) != 6 {
+		err = ErrEthernetInvalidMAC
+		return
+	}
+	if priority > 7 || vlanID > 0x0FFF {
+		err = ErrEthernetInvalidVLAN
+		return
+	}
+	if len(payload) > maxInt-ethernetHeaderLen-vlanTagLen {
+		err = ErrTooLarge
+		return
+	}
+	size := ethernetHeaderLen + vlanTagLen + len(payload)
+	p, borrowErr := buf.Borrow(size)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	copy(p[0:6], dst)
+	copy(p[6:12], src)
+	binary.BigEndian.PutUint16(p[12:], EtherTypeVLAN)
+	binary.BigEndian.PutUint16(p[14:], uint16(priority)<<13|vlanID)
+	binary.BigEndian.PutUint16(p[16:], etherType)
+	copy(p[ethernetHeaderLen+vlanTagLen:], payload)
+	buf.Return(size)
+	return
+}
+
+// WriteARPPacket
+// 写入以太网/IPv4 的 ARP 报文（28 字节），目标 MAC 为全零，适用于请求。
+//
+// 应答需要填写目标 MAC，请使用 WriteARPReply。
+func WriteARPPacket(buf Buffer, oper uint16, senderMAC net.HardwareAddr, senderIP, targetIP net.IP) (err error) {
+	err = writeARP(buf, oper, senderMAC, senderIP, nil, targetIP)
+	return
+}
+
+// WriteARPReply
+// 写入以太网/IPv4 的 ARP 应答报文，目标 MAC 为 targetMAC。
+func WriteARPReply(buf Buffer, senderMAC net.HardwareAddr, senderIP net.IP, targetMAC net.HardwareAddr, targetIP net.IP) (err error) {
+	if len(targetMAC) != 6 {
+		err = ErrEthernetInvalidMAC
+		return
+	}
+	err = writeARP(buf, ARPReply, senderMAC, senderIP, targetMAC, targetIP)
+	return
+}
+
+func writeARP(buf Buffer, oper uint16, senderMAC net.HardwareAddr, senderIP net.IP, targetMAC net.HardwareAddr, targetIP net.IP) (err error) {
+	if len(senderMAC) != 6 {
+		err = ErrEthernetInvalidMAC
+		return
+	}
+	sender4, target4 := senderIP.To4(), targetIP.To4()
+	if sender4 == nil || target4 == nil {
+		err = ErrARPInvalidAddress
+		return
+	}
+	p, borrowErr := buf.Borrow(arpPacketLen)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	binary.BigEndian.PutUint16(p[0:], 1) // hardware type: ethernet
+	binary.BigEndian.PutUint16(p[2:], EtherTypeIPv4)
+	p[4] = 6 // hardware size
+	p[5] = 4 // protocol size
+	binary.BigEndian.PutUint16(p[6:], oper)
+	copy(p[8:14], senderMAC)
+	copy(p[14:18], sender4)
+	if targetMAC != nil {
+		copy(p[18:24], targetMAC)
+	} else {
+		clear(p[18:24])
+	}
+	copy(p[24:28], target4)
+	buf.Return(arpPacketLen)
+	return
+}
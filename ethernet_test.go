@@ -0,0 +1,103 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+// arpReplyFrame
+// tcpdump -xx 抓取的 ARP 应答：192.168.1.1 is-at 00:11:22:33:44:55，应答给 192.168.1.100（66:77:88:99:aa:bb）。
+const arpReplyFrame = "66778899aabb001122334455" + "0806" +
+	"0001080006040002" + "001122334455" + "c0a80101" + "66778899aabb" + "c0a80164"
+
+func TestWriteEthernetFrame(t *testing.T) {
+	expected, _ := hex.DecodeString(arpReplyFrame)
+	dst, _ := net.ParseMAC("66:77:88:99:aa:bb")
+	src, _ := net.ParseMAC("00:11:22:33:44:55")
+
+	buf := bytebuffers.NewBuffer()
+	if err := bytebuffers.WriteEthernetFrame(buf, dst, src, bytebuffers.EtherTypeARP, expected[14:]); err != nil {
+		t.Fatal(err)
+	}
+	p := buf.CloneBytes()
+	if !bytes.Equal(p, expected) {
+		t.Fatal("unexpected frame", hex.EncodeToString(p))
+	}
+	if p[12] != 0x08 || p[13] != 0x06 {
+		t.Fatal("unexpected ether type")
+	}
+
+	if err := bytebuffers.WriteEthernetFrame(buf, dst[:5], src, bytebuffers.EtherTypeARP, nil); !errors.Is(err, bytebuffers.ErrEthernetInvalidMAC) {
+		t.Fatal("expected invalid mac, got", err)
+	}
+}
+
+func TestWriteEthernetVLAN(t *testing.T) {
+	dst, _ := net.ParseMAC("ff:ff:ff:ff:ff:ff")
+	src, _ := net.ParseMAC("00:11:22:33:44:55")
+	buf := bytebuffers.NewBuffer()
+	if err := bytebuffers.WriteEthernetVLAN(buf, dst, src, 5, 100, bytebuffers.EtherTypeIPv4, []byte{0x45}); err != nil {
+		t.Fatal(err)
+	}
+	expected, _ := hex.DecodeString("ffffffffffff001122334455" + "8100" + "a064" + "0800" + "45")
+	if p := buf.CloneBytes(); !bytes.Equal(p, expected) {
+		t.Fatal("unexpected frame", hex.EncodeToString(p))
+	}
+
+	if err := bytebuffers.WriteEthernetVLAN(buf, dst, src, 8, 100, bytebuffers.EtherTypeIPv4, nil); !errors.Is(err, bytebuffers.ErrEthernetInvalidVLAN) {
+		t.Fatal("expected invalid vlan, got", err)
+	}
+	if err := bytebuffers.WriteEthernetVLAN(buf, dst, src, 0, 4096, bytebuffers.EtherTypeIPv4, nil); !errors.Is(err, bytebuffers.ErrEthernetInvalidVLAN) {
+		t.Fatal("expected invalid vlan, got", err)
+	}
+}
+
+func TestWriteARPPacket(t *testing.T) {
+	// tcpdump -xx: ARP, Request who-has 192.168.1.1 tell 192.168.1.100
+	expected, _ := hex.DecodeString("ffffffffffff66778899aabb" + "0806" +
+		"0001080006040001" + "66778899aabb" + "c0a80164" + "000000000000" + "c0a80101")
+	src, _ := net.ParseMAC("66:77:88:99:aa:bb")
+	broadcast, _ := net.ParseMAC("ff:ff:ff:ff:ff:ff")
+
+	arp := bytebuffers.NewBuffer()
+	// dirty the free space to make sure the target mac is zeroed
+	_, _ = arp.Write(bytes.Repeat([]byte{0xEE}, 64))
+	arp.Reset()
+	if err := bytebuffers.WriteARPPacket(arp, bytebuffers.ARPRequest, src, net.ParseIP("192.168.1.100"), net.ParseIP("192.168.1.1")); err != nil {
+		t.Fatal(err)
+	}
+	buf := bytebuffers.NewBuffer()
+	if err := bytebuffers.WriteEthernetFrame(buf, broadcast, src, bytebuffers.EtherTypeARP, arp.CloneBytes()); err != nil {
+		t.Fatal(err)
+	}
+	if p := buf.CloneBytes(); !bytes.Equal(p, expected) {
+		t.Fatal("unexpected frame", hex.EncodeToString(p))
+	}
+
+	if err := bytebuffers.WriteARPPacket(arp, bytebuffers.ARPRequest, src, net.ParseIP("::1"), net.ParseIP("192.168.1.1")); !errors.Is(err, bytebuffers.ErrARPInvalidAddress) {
+		t.Fatal("expected invalid address, got", err)
+	}
+}
+
+func TestWriteARPReply(t *testing.T) {
+	expected, _ := hex.DecodeString(arpReplyFrame)
+	sender, _ := net.ParseMAC("00:11:22:33:44:55")
+	target, _ := net.ParseMAC("66:77:88:99:aa:bb")
+
+	buf := bytebuffers.NewBuffer()
+	if err := bytebuffers.WriteARPReply(buf, sender, net.ParseIP("192.168.1.1"), target, net.ParseIP("192.168.1.100")); err != nil {
+		t.Fatal(err)
+	}
+	p := buf.CloneBytes()
+	if !bytes.Equal(p, expected[14:]) {
+		t.Fatal("unexpected packet", hex.EncodeToString(p))
+	}
+	if p[7] != bytebuffers.ARPReply || !bytes.Equal(p[18:24], target) {
+		t.Fatal("unexpected operation or target mac")
+	}
+}
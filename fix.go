@@ -0,0 +1,189 @@
+package bytebuffers
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	FIXTagBeginString  = 8
+	FIXTagBodyLength   = 9
+	FIXTagCheckSum     = 10
+	FIXTagMsgSeqNum    = 34
+	FIXTagMsgType      = 35
+	FIXTagSenderCompID = 49
+	FIXTagSendingTime  = 52
+	FIXTagTargetCompID = 56
+
+	fixSOH         = 0x01
+	fixBeginString = "FIX.4.2"
+	fixCheckSumLen = 7 // 10=NNN<SOH>
+)
+
+var (
+	ErrFIXInvalid  = errors.New("bytebuffers.FIX: invalid message")
+	ErrFIXChecksum = errors.New("bytebuffers.FIX: checksum mismatch")
+)
+
+// WriteFIXMessage
+// 写入 FIX 消息：BeginString(8)、BodyLength(9)、MsgType(35)、MsgSeqNum(34)，其余字段按标签升序，最后为 CheckSum(10)。
+//
+// BeginString 取 fields[8]，缺省为 "FIX.4.2"；fields 中的 9、10、34、35 会被忽略。值不能为空或含有 SOH。
+func WriteFIXMessage(buf Buffer, msgType string, fields map[int]string, seqNum int) (err error) {
+	beginString := fixBeginString
+	if v, has := fields[FIXTagBeginString]; has {
+		beginString = v
+	}
+	if !isFIXValue(beginString) || !isFIXValue(msgType) || seqNum < 0 {
+		err = ErrFIXInvalid
+		return
+	}
+	tags := make([]int, 0, len(fields))
+	for tag, value := range fields {
+		switch tag {
+		case FIXTagBeginString, FIXTagBodyLength, FIXTagCheckSum, FIXTagMsgSeqNum, FIXTagMsgType:
+			continue
+		}
+		if tag <= 0 || !isFIXValue(value) {
+			err = ErrFIXInvalid
+			return
+		}
+		tags = append(tags, tag)
+	}
+	slices.Sort(tags)
+
+	body := appendFIXField(nil, FIXTagMsgType, msgType)
+	body = appendFIXField(body, FIXTagMsgSeqNum, strconv.Itoa(seqNum))
+	for _, tag := range tags {
+		body = appendFIXField(body, tag, fields[tag])
+	}
+	header := appendFIXField(nil, FIXTagBeginString, beginString)
+	header = appendFIXField(header, FIXTagBodyLength, strconv.Itoa(len(body)))
+
+	size := len(header) + len(body) + fixCheckSumLen
+	p, borrowErr := buf.Borrow(size)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	n := copy(p, header)
+	n += copy(p[n:], body)
+	sum := fixCheckSum(p[:n])
+	copy(p[n:], "10=")
+	p[n+3] = '0' + sum/100
+	p[n+4] = '0' + sum/10%10
+	p[n+5] = '0' + sum%10
+	p[n+6] = fixSOH
+	buf.Return(size)
+	return
+}
+
+// ReadFIXMessage
+// 读取 FIX 消息，按 BodyLength 定位 CheckSum 并校验，返回全部标签与值（含 8、9、10）。
+//
+// 不完整时返回 io.ErrUnexpectedEOF，失败时不读掉。
+func ReadFIXMessage(buf Buffer) (fields map[int]string, err error) {
+	p := buf.Peek(buf.Len())
+	if len(p) == 0 {
+		err = io.EOF
+		return
+	}
+	// 8=...<SOH>9=...<SOH>
+	first := bytes.IndexByte(p, fixSOH)
+	if first < 0 {
+		err = fixIncomplete(p, "8=")
+		return
+	}
+	if !bytes.HasPrefix(p, []byte("8=")) {
+		err = ErrFIXInvalid
+		return
+	}
+	second := bytes.IndexByte(p[first+1:], fixSOH)
+	if second < 0 {
+		err = fixIncomplete(p[first+1:], "9=")
+		return
+	}
+	lengthField := p[first+1 : first+1+second]
+	if !bytes.HasPrefix(lengthField, []byte("9=")) {
+		err = ErrFIXInvalid
+		return
+	}
+	bodyLength, convErr := strconv.Atoi(string(lengthField[2:]))
+	if convErr != nil || bodyLength < 0 {
+		err = ErrFIXInvalid
+		return
+	}
+	bodyStart := first + 1 + second + 1
+	if bodyLength > len(p)-bodyStart-fixCheckSumLen {
+		err = io.ErrUnexpectedEOF
+		return
+	}
+	bodyEnd := bodyStart + bodyLength
+	size := bodyEnd + fixCheckSumLen
+	trailer := p[bodyEnd:size]
+	if bodyLength == 0 || p[bodyEnd-1] != fixSOH || !bytes.HasPrefix(trailer, []byte("10=")) || trailer[6] != fixSOH {
+		err = ErrFIXInvalid
+		return
+	}
+	sum, convErr := strconv.Atoi(string(trailer[3:6]))
+	if convErr != nil {
+		err = ErrFIXInvalid
+		return
+	}
+	if sum != int(fixCheckSum(p[:bodyEnd])) {
+		err = ErrFIXChecksum
+		return
+	}
+	fields = make(map[int]string)
+	for _, field := range strings.Split(string(p[:bodyEnd-1]), "\x01") {
+		i := strings.IndexByte(field, '=')
+		if i < 1 {
+			fields, err = nil, ErrFIXInvalid
+			return
+		}
+		tag, tagErr := strconv.Atoi(field[:i])
+		if tagErr != nil || tag <= 0 {
+			fields, err = nil, ErrFIXInvalid
+			return
+		}
+		fields[tag] = field[i+1:]
+	}
+	fields[FIXTagCheckSum] = string(trailer[3:6])
+	buf.Discard(size)
+	return
+}
+
+// fixCheckSum
+// 所有字节之和模 256。
+func fixCheckSum(p []byte) byte {
+	var sum byte
+	for _, b := range p {
+		sum += b
+	}
+	return sum
+}
+
+func appendFIXField(p []byte, tag int, value string) []byte {
+	p = strconv.AppendInt(p, int64(tag), 10)
+	p = append(p, '=')
+	p = append(p, value...)
+	return append(p, fixSOH)
+}
+
+func isFIXValue(s string) bool {
+	return len(s) > 0 && strings.IndexByte(s, fixSOH) < 0
+}
+
+// fixIncomplete
+// 没有找到字段结尾时，前缀匹配则为不完整，否则为无效。
+func fixIncomplete(p []byte, prefix string) error {
+	n := min(len(p), len(prefix))
+	if string(p[:n]) != prefix[:n] {
+		return ErrFIXInvalid
+	}
+	return io.ErrUnexpectedEOF
+}
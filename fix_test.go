@@ -0,0 +1,78 @@
+package bytebuffers_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestWriteFIXMessage(t *testing.T) {
+	fields := map[int]string{
+		bytebuffers.FIXTagSenderCompID: "CLIENT",
+		bytebuffers.FIXTagTargetCompID: "BROKER",
+		bytebuffers.FIXTagSendingTime:  "20240315-12:00:00",
+		11:                             "ORD1",
+		21:                             "1",
+		38:                             "100",
+		40:                             "1",
+		54:                             "1",
+		55:                             "IBM",
+	}
+	buf := bytebuffers.NewBuffer()
+	if err := bytebuffers.WriteFIXMessage(buf, "D", fields, 7); err != nil {
+		t.Fatal(err)
+	}
+	body := "35=D\x0134=7\x0111=ORD1\x0121=1\x0138=100\x0140=1\x0149=CLIENT\x0152=20240315-12:00:00\x0154=1\x0155=IBM\x0156=BROKER\x01"
+	head := fmt.Sprintf("8=FIX.4.2\x019=%d\x01", len(body))
+	sum := 0
+	for _, b := range []byte(head + body) {
+		sum += int(b)
+	}
+	expected := head + body + fmt.Sprintf("10=%03d\x01", sum%256)
+	if s := string(buf.Peek(buf.Len())); s != expected {
+		t.Fatalf("unexpected message %q", strings.ReplaceAll(s, "\x01", "|"))
+	}
+
+	read, err := bytebuffers.ReadFIXMessage(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read[bytebuffers.FIXTagMsgType] != "D" || read[bytebuffers.FIXTagMsgSeqNum] != "7" || read[55] != "IBM" {
+		t.Fatal("unexpected fields", read)
+	}
+	if read[bytebuffers.FIXTagBeginString] != "FIX.4.2" || read[bytebuffers.FIXTagCheckSum] != fmt.Sprintf("%03d", sum%256) {
+		t.Fatal("unexpected fields", read)
+	}
+	if buf.Len() != 0 {
+		t.Fatal("message must be discarded")
+	}
+
+	if err = bytebuffers.WriteFIXMessage(buf, "D", map[int]string{55: "I\x01BM"}, 1); !errors.Is(err, bytebuffers.ErrFIXInvalid) {
+		t.Fatal("expected invalid, got", err)
+	}
+}
+
+func TestReadFIXMessage(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	_ = bytebuffers.WriteFIXMessage(buf, "0", nil, 1) // heartbeat
+	p := buf.CloneBytes()
+	buf.Reset()
+
+	for i := 1; i < len(p); i++ {
+		_, _ = buf.Write(p[:i])
+		if _, err := bytebuffers.ReadFIXMessage(buf); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Fatal("expected unexpected EOF at", i, err)
+		}
+		buf.Reset()
+	}
+
+	p[len(p)-2]++
+	_, _ = buf.Write(p)
+	if _, err := bytebuffers.ReadFIXMessage(buf); !errors.Is(err, bytebuffers.ErrFIXChecksum) {
+		t.Fatal("expected checksum mismatch, got", err)
+	}
+}
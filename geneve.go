@@ -0,0 +1,140 @@
+package bytebuffers
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+const (
+	GENEVEFlagOAM        = 0x80
+	GENEVEFlagCritical   = 0x40
+	GENEVEOptionCritical = 0x80
+
+	geneveHeaderLen       = 8
+	geneveOptionHeaderLen = 4
+	geneveMaxOptionsLen   = 0x3F * 4
+	geneveMaxOptionLen    = 0x1F * 4
+)
+
+var (
+	ErrGENEVEInvalidOption = errors.New("bytebuffers.GENEVE: invalid option")
+	ErrGENEVEInvalid       = errors.New("bytebuffers.GENEVE: invalid header")
+)
+
+// GeneveOption
+// GENEVE 的 TLV 选项，Data 长度须为 4 的倍数且不超过 124 字节。
+type GeneveOption struct {
+	Class uint16
+	Type  byte
+	Data  []byte
+}
+
+// Critical
+// 是否为关键选项（类型最高位）。
+func (o GeneveOption) Critical() bool {
+	return o.Type&GENEVEOptionCritical != 0
+}
+
+// GENEVEHeader
+// GENEVE 头。
+type GENEVEHeader struct {
+	Version      byte
+	Flags        byte
+	ProtocolType uint16
+	VNI          uint32
+	Options      []GeneveOption
+}
+
+// WriteGENEVEHeader
+// 写入 8 字节的 GENEVE 固定头与选项：版本（0）与选项长度、O/C 标志、协议类型、24 位 VNI 与保留字节。
+//
+// flags 只取 O 标志，存在关键选项时自动设置 C 标志。
+func WriteGENEVEHeader(buf Buffer, flags byte, protocolType uint16, vni uint32, options []GeneveOption) (err error) {
+	if vni > 0xFFFFFF {
+		err = ErrGENEVEInvalid
+		return
+	}
+	flags &= GENEVEFlagOAM
+	optsLen := 0
+	for _, option := range options {
+		if len(option.Data)%4 != 0 || len(option.Data) > geneveMaxOptionLen {
+			err = ErrGENEVEInvalidOption
+			return
+		}
+		if option.Critical() {
+			flags |= GENEVEFlagCritical
+		}
+		optsLen += geneveOptionHeaderLen + len(option.Data)
+	}
+	if optsLen > geneveMaxOptionsLen {
+		err = ErrGENEVEInvalidOption
+		return
+	}
+	size := geneveHeaderLen + optsLen
+	p, borrowErr := buf.Borrow(size)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	p[0] = byte(optsLen / 4)
+	p[1] = flags
+	binary.BigEndian.PutUint16(p[2:], protocolType)
+	binary.BigEndian.PutUint32(p[4:], vni<<8)
+	n := geneveHeaderLen
+	for _, option := range options {
+		binary.BigEndian.PutUint16(p[n:], option.Class)
+		p[n+2] = option.Type
+		p[n+3] = byte(len(option.Data) / 4)
+		n += geneveOptionHeaderLen
+		n += copy(p[n:], option.Data)
+	}
+	buf.Return(size)
+	return
+}
+
+// ReadGENEVEHeader
+// 读取 GENEVE 固定头与全部选项，只读掉头部，不完整时不读掉。
+func ReadGENEVEHeader(buf Buffer) (header GENEVEHeader, err error) {
+	p, peekErr := peekFull(buf, geneveHeaderLen)
+	if peekErr != nil {
+		err = peekErr
+		return
+	}
+	if p[0]>>6 != 0 {
+		err = ErrGENEVEInvalid
+		return
+	}
+	size := geneveHeaderLen + int(p[0]&0x3F)*4
+	if p, err = peekFull(buf, size); err != nil {
+		return
+	}
+	var options []GeneveOption
+	for n := geneveHeaderLen; n < size; {
+		if size-n < geneveOptionHeaderLen {
+			err = ErrGENEVEInvalidOption
+			return
+		}
+		end := n + geneveOptionHeaderLen + int(p[n+3]&0x1F)*4
+		if end > size {
+			err = ErrGENEVEInvalidOption
+			return
+		}
+		option := GeneveOption{
+			Class: binary.BigEndian.Uint16(p[n:]),
+			Type:  p[n+2],
+			Data:  make([]byte, end-n-geneveOptionHeaderLen),
+		}
+		copy(option.Data, p[n+geneveOptionHeaderLen:end])
+		options = append(options, option)
+		n = end
+	}
+	header = GENEVEHeader{
+		Version:      p[0] >> 6,
+		Flags:        p[1] & (GENEVEFlagOAM | GENEVEFlagCritical),
+		ProtocolType: binary.BigEndian.Uint16(p[2:]),
+		VNI:          binary.BigEndian.Uint32(p[4:]) >> 8,
+		Options:      options,
+	}
+	buf.Discard(size)
+	return
+}
@@ -0,0 +1,67 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestGENEVEHeader(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	if err := bytebuffers.WriteGENEVEHeader(buf, 0, bytebuffers.EtherTypeIPv4, 0x123456, nil); err != nil {
+		t.Fatal(err)
+	}
+	expected, _ := hex.DecodeString("00" + "00" + "0800" + "123456" + "00")
+	if p := buf.CloneBytes(); !bytes.Equal(p, expected) {
+		t.Fatal("unexpected header", hex.EncodeToString(p))
+	}
+	header, err := bytebuffers.ReadGENEVEHeader(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.VNI != 0x123456 || header.ProtocolType != bytebuffers.EtherTypeIPv4 || header.Flags != 0 || len(header.Options) != 0 {
+		t.Fatal("unexpected header", header)
+	}
+
+	option := bytebuffers.GeneveOption{Class: 0x0102, Type: bytebuffers.GENEVEOptionCritical | 0x01, Data: []byte{0xde, 0xad, 0xbe, 0xef}}
+	if err = bytebuffers.WriteGENEVEHeader(buf, bytebuffers.GENEVEFlagOAM, 0x6558, 42, []bytebuffers.GeneveOption{option}); err != nil {
+		t.Fatal(err)
+	}
+	expected, _ = hex.DecodeString("02" + "c0" + "6558" + "00002a" + "00" + "0102" + "81" + "01" + "deadbeef")
+	p := buf.CloneBytes()
+	if !bytes.Equal(p, expected) {
+		t.Fatal("unexpected header", hex.EncodeToString(p))
+	}
+	if optLen := int(p[0]&0x3F) * 4; optLen != 8 {
+		t.Fatal("unexpected opt length", optLen)
+	}
+	if header, err = bytebuffers.ReadGENEVEHeader(buf); err != nil {
+		t.Fatal(err)
+	}
+	if header.VNI != 42 || header.Flags != bytebuffers.GENEVEFlagOAM|bytebuffers.GENEVEFlagCritical || len(header.Options) != 1 {
+		t.Fatal("unexpected header", header)
+	}
+	if o := header.Options[0]; o.Class != option.Class || o.Type != option.Type || !o.Critical() || !bytes.Equal(o.Data, option.Data) {
+		t.Fatal("unexpected option", o)
+	}
+	if buf.Len() != 0 {
+		t.Fatal("options must be discarded")
+	}
+
+	if err = bytebuffers.WriteGENEVEHeader(buf, 0, 0x6558, 1, []bytebuffers.GeneveOption{{Data: []byte{1}}}); !errors.Is(err, bytebuffers.ErrGENEVEInvalidOption) {
+		t.Fatal("expected invalid option, got", err)
+	}
+	_, _ = buf.Write(expected[:15])
+	if _, err = bytebuffers.ReadGENEVEHeader(buf); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal("expected unexpected EOF, got", err)
+	}
+	buf.Reset()
+	_, _ = buf.Write([]byte{0x01, 0, 0x65, 0x58, 0, 0, 1, 0, 0x01, 0x02, 0x01, 0x02})
+	if _, err = bytebuffers.ReadGENEVEHeader(buf); !errors.Is(err, bytebuffers.ErrGENEVEInvalidOption) {
+		t.Fatal("expected invalid option, got", err)
+	}
+}
@@ -2,4 +2,13 @@ module github.com/brickingsoft/bytebuffers
 
 go 1.23.0
 
-require golang.org/x/sys v0.30.0
+require (
+	github.com/andybalholm/brotli v1.1.1
+	github.com/golang/snappy v1.0.0
+	github.com/klauspost/compress v1.17.11
+	github.com/pierrec/lz4/v4 v4.1.21
+	golang.org/x/crypto v0.33.0
+	google.golang.org/protobuf v1.36.5
+)
+
+require golang.org/x/sys v0.30.0 // indirect
@@ -0,0 +1,114 @@
+package bytebuffers
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+const (
+	GREFlagChecksum = 0x8000
+	GREFlagKey      = 0x2000
+	GREFlagSequence = 0x1000
+	GREFlagAck      = 0x0080 // enhanced GRE (version 1) only
+	GREVersionMask  = 0x0007
+
+	greHeaderLen = 4
+)
+
+var (
+	ErrGREInvalid = errors.New("bytebuffers.GRE: invalid header")
+)
+
+// GREHeader
+// GRE 头，Flags 包含标志位与版本，不存在的可选字段为 0。
+type GREHeader struct {
+	Flags    uint16
+	Protocol uint16
+	Checksum uint16
+	Key      uint32
+	Seq      uint32
+	Ack      uint32
+}
+
+// Version
+// 版本，0 为 RFC 2784，1 为 PPTP 使用的增强 GRE。
+func (h GREHeader) Version() uint16 {
+	return h.Flags & GREVersionMask
+}
+
+// WriteGREHeader
+// 写入版本 0 的 GRE 头：标志与版本、协议类型，hasKey 时设置 K 标志并在偏移 4 处写入 key。
+func WriteGREHeader(buf Buffer, protocol uint16, key uint32, hasKey bool) (err error) {
+	size := greHeaderLen
+	var flags uint16
+	if hasKey {
+		flags |= GREFlagKey
+		size += 4
+	}
+	p, borrowErr := buf.Borrow(size)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	binary.BigEndian.PutUint16(p, flags)
+	binary.BigEndian.PutUint16(p[2:], protocol)
+	if hasKey {
+		binary.BigEndian.PutUint32(p[4:], key)
+	}
+	buf.Return(size)
+	return
+}
+
+// ReadGREHeader
+// 读取 GRE 头，只读取标志中存在的校验和、key、序列号与确认号，只读掉头部，不完整时不读掉。
+//
+// 校验和不做校验。
+func ReadGREHeader(buf Buffer) (header GREHeader, err error) {
+	p, peekErr := peekFull(buf, greHeaderLen)
+	if peekErr != nil {
+		err = peekErr
+		return
+	}
+	flags := binary.BigEndian.Uint16(p)
+	version := flags & GREVersionMask
+	if version > 1 || (version == 0 && flags&GREFlagAck != 0) || (version == 1 && flags&(GREFlagChecksum|GREFlagKey) != GREFlagKey) {
+		err = ErrGREInvalid
+		return
+	}
+	size := greHeaderLen
+	if flags&GREFlagChecksum != 0 {
+		size += 4 // checksum and reserved1
+	}
+	if flags&GREFlagKey != 0 {
+		size += 4
+	}
+	if flags&GREFlagSequence != 0 {
+		size += 4
+	}
+	if flags&GREFlagAck != 0 {
+		size += 4
+	}
+	if p, err = peekFull(buf, size); err != nil {
+		return
+	}
+	header.Flags = flags
+	header.Protocol = binary.BigEndian.Uint16(p[2:])
+	n := greHeaderLen
+	if flags&GREFlagChecksum != 0 {
+		header.Checksum = binary.BigEndian.Uint16(p[n:])
+		n += 4
+	}
+	if flags&GREFlagKey != 0 {
+		header.Key = binary.BigEndian.Uint32(p[n:])
+		n += 4
+	}
+	if flags&GREFlagSequence != 0 {
+		header.Seq = binary.BigEndian.Uint32(p[n:])
+		n += 4
+	}
+	if flags&GREFlagAck != 0 {
+		header.Ack = binary.BigEndian.Uint32(p[n:])
+	}
+	buf.Discard(size)
+	return
+}
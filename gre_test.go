@@ -0,0 +1,69 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestGREHeader(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	if err := bytebuffers.WriteGREHeader(buf, bytebuffers.EtherTypeIPv4, 0xDEADBEEF, true); err != nil {
+		t.Fatal(err)
+	}
+	p := buf.CloneBytes()
+	if p[0]&0x20 == 0 {
+		t.Fatal("K flag must be set", p[0])
+	}
+	if !bytes.Equal(p[4:8], []byte{0xDE, 0xAD, 0xBE, 0xEF}) {
+		t.Fatal("unexpected key", p[4:])
+	}
+	if !bytes.Equal(p, []byte{0x20, 0x00, 0x08, 0x00, 0xDE, 0xAD, 0xBE, 0xEF}) {
+		t.Fatal("unexpected header", p)
+	}
+	header, err := bytebuffers.ReadGREHeader(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.Flags != bytebuffers.GREFlagKey || header.Protocol != bytebuffers.EtherTypeIPv4 || header.Key != 0xDEADBEEF || buf.Len() != 0 {
+		t.Fatal("unexpected header", header)
+	}
+
+	if err = bytebuffers.WriteGREHeader(buf, bytebuffers.EtherTypeIPv6, 0xDEADBEEF, false); err != nil {
+		t.Fatal(err)
+	}
+	p = buf.CloneBytes()
+	if p[0]&0x20 != 0 || len(p) != 4 {
+		t.Fatal("K flag must be clear", p)
+	}
+	if header, err = bytebuffers.ReadGREHeader(buf); err != nil {
+		t.Fatal(err)
+	}
+	if header.Key != 0 || header.Protocol != bytebuffers.EtherTypeIPv6 {
+		t.Fatal("unexpected header", header)
+	}
+
+	// PPTP enhanced GRE with sequence and acknowledgment numbers
+	pptp, _ := hex.DecodeString("3081" + "880b" + "0010" + "0001" + "00000005" + "00000004")
+	_, _ = buf.Write(pptp)
+	if header, err = bytebuffers.ReadGREHeader(buf); err != nil {
+		t.Fatal(err)
+	}
+	if header.Version() != 1 || header.Protocol != 0x880B || header.Key != 0x00100001 || header.Seq != 5 || header.Ack != 4 {
+		t.Fatal("unexpected header", header)
+	}
+
+	_, _ = buf.Write(pptp[:15])
+	if _, err = bytebuffers.ReadGREHeader(buf); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal("expected unexpected EOF, got", err)
+	}
+	buf.Reset()
+	_, _ = buf.Write([]byte{0x00, 0x02, 0x08, 0x00})
+	if _, err = bytebuffers.ReadGREHeader(buf); !errors.Is(err, bytebuffers.ErrGREInvalid) {
+		t.Fatal("expected invalid, got", err)
+	}
+}
@@ -0,0 +1,77 @@
+package bytebuffers
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	grpcPrefixLen = 5
+)
+
+var (
+	ErrGRPCCompressed  = errors.New("bytebuffers.GRPC: compressed message is not supported")
+	ErrGRPCSizeChanged = errors.New("bytebuffers.GRPC: message size changed during marshal")
+)
+
+// WriteLengthPrefixedMessage
+// 以 gRPC 线格式写入 msg：1 字节压缩标志（0）、4 字节大端长度与 protobuf 编码的消息。
+func WriteLengthPrefixedMessage(buf Buffer, msg proto.Message) (err error) {
+	size := proto.Size(msg)
+	if uint64(size) > math.MaxUint32 {
+		err = ErrTooLarge
+		return
+	}
+	p, borrowErr := buf.Borrow(grpcPrefixLen + size)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	// capped at size, a message grown since Size is reallocated instead of written past the borrowed space
+	encoded, marshalErr := proto.MarshalOptions{}.MarshalAppend(p[grpcPrefixLen:grpcPrefixLen:grpcPrefixLen+size], msg)
+	if marshalErr != nil {
+		buf.Return(0)
+		err = marshalErr
+		return
+	}
+	if len(encoded) != size {
+		buf.Return(0)
+		err = ErrGRPCSizeChanged
+		return
+	}
+	p[0] = 0
+	binary.BigEndian.PutUint32(p[1:], uint32(size))
+	buf.Return(grpcPrefixLen + size)
+	return
+}
+
+// ReadLengthPrefixedMessage
+// 读取一个 gRPC 线格式的消息并解码至 msg，压缩标志非 0 时返回 ErrGRPCCompressed。失败或不完整时不读掉。
+func ReadLengthPrefixedMessage(buf Buffer, msg proto.Message) (err error) {
+	p, peekErr := peekFull(buf, grpcPrefixLen)
+	if peekErr != nil {
+		err = peekErr
+		return
+	}
+	if p[0] != 0 {
+		err = ErrGRPCCompressed
+		return
+	}
+	length := uint64(binary.BigEndian.Uint32(p[1:]))
+	if length > uint64(maxInt-grpcPrefixLen) {
+		err = ErrTooLarge
+		return
+	}
+	size := grpcPrefixLen + int(length)
+	if p, err = peekFull(buf, size); err != nil {
+		return
+	}
+	if err = proto.Unmarshal(p[grpcPrefixLen:], msg); err != nil {
+		return
+	}
+	buf.Discard(size)
+	return
+}
@@ -0,0 +1,52 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestWriteLengthPrefixedMessage(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	if err := bytebuffers.WriteLengthPrefixedMessage(buf, wrapperspb.String("hi")); err != nil {
+		t.Fatal(err)
+	}
+	// flag 0, length 4, field 1 wire type 2, length 2, "hi"
+	expected := []byte{0x00, 0x00, 0x00, 0x00, 0x04, 0x0A, 0x02, 'h', 'i'}
+	if !bytes.Equal(buf.CloneBytes(), expected) {
+		t.Fatal("unexpected wire format", buf.CloneBytes())
+	}
+	_ = bytebuffers.WriteLengthPrefixedMessage(buf, wrapperspb.String(""))
+
+	for _, s := range []string{"hi", ""} {
+		msg := &wrapperspb.StringValue{}
+		if err := bytebuffers.ReadLengthPrefixedMessage(buf, msg); err != nil {
+			t.Fatal(err)
+		}
+		if msg.GetValue() != s {
+			t.Fatal("round trip mismatch", msg.GetValue())
+		}
+	}
+	if buf.Len() != 0 {
+		t.Fatal("unexpected remains", buf.Len())
+	}
+}
+
+func TestReadLengthPrefixedMessage(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	_, _ = buf.Write([]byte{0x00, 0x00, 0x00, 0x00, 0x04, 0x0A, 0x02, 'h'})
+	if err := bytebuffers.ReadLengthPrefixedMessage(buf, &wrapperspb.StringValue{}); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal("expected unexpected EOF, got", err)
+	}
+	if buf.Len() != 8 {
+		t.Fatal("incomplete message consumed")
+	}
+	_ = buf.Set([]byte{0x01, 0x00, 0x00, 0x00, 0x00})
+	if err := bytebuffers.ReadLengthPrefixedMessage(buf, &wrapperspb.StringValue{}); !errors.Is(err, bytebuffers.ErrGRPCCompressed) {
+		t.Fatal("expected compressed error, got", err)
+	}
+}
@@ -0,0 +1,121 @@
+package bytebuffers
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+const (
+	GTPv1FlagNPDU      = 0x01
+	GTPv1FlagSequence  = 0x02
+	GTPv1FlagExtension = 0x04
+
+	GTPv1MessageEchoRequest     = 1
+	GTPv1MessageEchoResponse    = 2
+	GTPv1MessageErrorIndication = 26
+	GTPv1MessageEndMarker       = 254
+	GTPv1MessageGPDU            = 255
+
+	gtpv1HeaderLen   = 8
+	gtpv1OptionalLen = 4
+	gtpv1VersionPT   = 0x30 // version 1, PT = GTP
+)
+
+var (
+	ErrGTPInvalid = errors.New("bytebuffers.GTP: invalid header")
+)
+
+// GTPv1Header
+// GTPv1-U 头，不存在的可选字段为 0。
+type GTPv1Header struct {
+	Flags         byte
+	MessageType   byte
+	Length        uint16
+	TEID          uint32
+	SeqNum        uint16
+	NPDU          byte
+	NextExtension byte
+}
+
+// WriteGTPv1Header
+// 写入带序列号的 GTPv1 头：标志（0x32）、消息类型、长度、TEID 以及序列号、N-PDU（0）与下一扩展头类型（0）。
+//
+// 长度为 8 字节固定头之后的字节数，即 4 + payloadLength。
+func WriteGTPv1Header(buf Buffer, messageType byte, teid uint32, seqNum uint16, payloadLength int) (err error) {
+	if payloadLength < 0 || payloadLength > math.MaxUint16-gtpv1OptionalLen {
+		err = ErrTooLarge
+		return
+	}
+	const size = gtpv1HeaderLen + gtpv1OptionalLen
+	p, borrowErr := buf.Borrow(size)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	p[0] = gtpv1VersionPT | GTPv1FlagSequence
+	p[1] = messageType
+	binary.BigEndian.PutUint16(p[2:], uint16(gtpv1OptionalLen+payloadLength))
+	binary.BigEndian.PutUint32(p[4:], teid)
+	binary.BigEndian.PutUint16(p[8:], seqNum)
+	p[10] = 0
+	p[11] = 0
+	buf.Return(size)
+	return
+}
+
+// ReadGTPv1Header
+// 读取 GTPv1 头，E、S、PN 任一标志存在时读取可选字段，扩展头被跳过，只读掉头部，不完整时不读掉。
+func ReadGTPv1Header(buf Buffer) (header GTPv1Header, err error) {
+	p, peekErr := peekFull(buf, gtpv1HeaderLen)
+	if peekErr != nil {
+		err = peekErr
+		return
+	}
+	flags := p[0]
+	if flags&0xF0 != gtpv1VersionPT {
+		err = ErrGTPInvalid
+		return
+	}
+	length := int(binary.BigEndian.Uint16(p[2:]))
+	n := gtpv1HeaderLen
+	if flags&(GTPv1FlagExtension|GTPv1FlagSequence|GTPv1FlagNPDU) != 0 {
+		n += gtpv1OptionalLen
+		if length < gtpv1OptionalLen {
+			err = ErrGTPInvalid
+			return
+		}
+		if p, err = peekFull(buf, n); err != nil {
+			return
+		}
+		header.SeqNum = binary.BigEndian.Uint16(p[8:])
+		header.NPDU = p[10]
+		header.NextExtension = p[11]
+		if flags&GTPv1FlagExtension != 0 {
+			// each extension header is a length in 4-byte units, its content and the next type
+			for next := p[11]; next != 0; {
+				if p, err = peekFull(buf, n+1); err != nil {
+					header = GTPv1Header{}
+					return
+				}
+				extLen := int(p[n]) * 4
+				if extLen == 0 || n+extLen > gtpv1HeaderLen+length {
+					header, err = GTPv1Header{}, ErrGTPInvalid
+					return
+				}
+				if p, err = peekFull(buf, n+extLen); err != nil {
+					header = GTPv1Header{}
+					return
+				}
+				n += extLen
+				next = p[n-1]
+			}
+		}
+	}
+	header.Flags = flags
+	header.MessageType = p[1]
+	header.Length = uint16(length)
+	header.TEID = binary.BigEndian.Uint32(p[4:])
+	buf.Discard(n)
+	return
+}
@@ -0,0 +1,68 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestGTPv1Header(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	payload := []byte{0x45, 0x00, 0x00, 0x14}
+	if err := bytebuffers.WriteGTPv1Header(buf, bytebuffers.GTPv1MessageGPDU, 0x10203040, 7, len(payload)); err != nil {
+		t.Fatal(err)
+	}
+	_, _ = buf.Write(payload)
+	expected, _ := hex.DecodeString("32ff0008" + "10203040" + "0007" + "00" + "00" + "45000014")
+	if p := buf.CloneBytes(); !bytes.Equal(p, expected) {
+		t.Fatal("unexpected packet", hex.EncodeToString(p))
+	}
+
+	header, err := bytebuffers.ReadGTPv1Header(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.MessageType != bytebuffers.GTPv1MessageGPDU || header.TEID != 0x10203040 || header.SeqNum != 7 || header.Length != 8 {
+		t.Fatal("unexpected header", header)
+	}
+	if p := buf.CloneBytes(); !bytes.Equal(p, payload) {
+		t.Fatal("payload must remain", p)
+	}
+	buf.Reset()
+
+	// mandatory header only
+	_, _ = buf.Write([]byte{0x30, 0xff, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01})
+	if header, err = bytebuffers.ReadGTPv1Header(buf); err != nil {
+		t.Fatal(err)
+	}
+	if header.TEID != 1 || header.SeqNum != 0 || buf.Len() != 0 {
+		t.Fatal("unexpected header", header)
+	}
+
+	// PDU session container extension header
+	p, _ := hex.DecodeString("34ff000c" + "00000002" + "0000" + "00" + "85" + "01" + "0001" + "00")
+	_, _ = buf.Write(p)
+	if header, err = bytebuffers.ReadGTPv1Header(buf); err != nil {
+		t.Fatal(err)
+	}
+	if header.TEID != 2 || header.NextExtension != 0x85 || buf.Len() != 0 {
+		t.Fatal("unexpected header", header, buf.Len())
+	}
+
+	_, _ = buf.Write(p[:14])
+	if _, err = bytebuffers.ReadGTPv1Header(buf); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal("expected unexpected EOF, got", err)
+	}
+	if buf.Len() != 14 {
+		t.Fatal("incomplete header must not be discarded")
+	}
+	buf.Reset()
+	_, _ = buf.Write([]byte{0x48, 0xff, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01})
+	if _, err = bytebuffers.ReadGTPv1Header(buf); !errors.Is(err, bytebuffers.ErrGTPInvalid) {
+		t.Fatal("expected invalid, got", err)
+	}
+}
@@ -0,0 +1,158 @@
+package bytebuffers
+
+import (
+	"errors"
+	"io"
+)
+
+const (
+	hdlcFlag   = 0x7E
+	hdlcEscape = 0x7D
+	hdlcXor    = 0x20
+)
+
+var (
+	ErrHDLCInvalid  = errors.New("bytebuffers.HDLC: invalid frame")
+	ErrHDLCChecksum = errors.New("bytebuffers.HDLC: fcs mismatch")
+)
+
+// hdlcFCS
+// 计算 FCS-16（CRC-16/CCITT，RFC 1662）。
+func hdlcFCS(fcs uint16, p []byte) uint16 {
+	for _, b := range p {
+		fcs ^= uint16(b)
+		for i := 0; i < 8; i++ {
+			if fcs&1 != 0 {
+				fcs = (fcs >> 1) ^ 0x8408
+			} else {
+				fcs >>= 1
+			}
+		}
+	}
+	return fcs
+}
+
+// hdlcStuffedLen
+// p 经字节填充后的长度。
+func hdlcStuffedLen(p []byte) int {
+	n := len(p)
+	for _, b := range p {
+		if b == hdlcFlag || b == hdlcEscape {
+			n++
+		}
+	}
+	return n
+}
+
+func hdlcStuff(dst []byte, p []byte) int {
+	n := 0
+	for _, b := range p {
+		if b == hdlcFlag || b == hdlcEscape {
+			dst[n] = hdlcEscape
+			dst[n+1] = b ^ hdlcXor
+			n += 2
+			continue
+		}
+		dst[n] = b
+		n++
+	}
+	return n
+}
+
+// WriteHDLCFrame
+// 写入一个 HDLC 帧：标志、地址、控制、信息、FCS、标志。
+//
+// 地址、控制、信息与 FCS 均会进行字节填充。
+func WriteHDLCFrame(buf Buffer, address, control byte, info []byte) (err error) {
+	header := [2]byte{address, control}
+	fcs := hdlcFCS(0xFFFF, header[:])
+	fcs = hdlcFCS(fcs, info) ^ 0xFFFF
+	trailer := [2]byte{byte(fcs), byte(fcs >> 8)}
+
+	if len(info) > maxInt/2-8 {
+		err = ErrTooLarge
+		return
+	}
+	size := 2 + hdlcStuffedLen(header[:]) + hdlcStuffedLen(info) + hdlcStuffedLen(trailer[:])
+	dst, borrowErr := buf.Borrow(size)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	dst[0] = hdlcFlag
+	n := 1
+	n += hdlcStuff(dst[n:], header[:])
+	n += hdlcStuff(dst[n:], info)
+	n += hdlcStuff(dst[n:], trailer[:])
+	dst[n] = hdlcFlag
+	n++
+	buf.Return(n)
+	return
+}
+
+// ReadHDLCFrame
+// 读取一个 HDLC 帧，去除字节填充并校验 FCS。
+//
+// 开始标志前的字节会被丢弃。当帧不完整时返回 io.ErrUnexpectedEOF 且不读掉帧；
+// 当帧无效或 FCS 不匹配时，该帧会被丢弃。结束标志不会读掉，以便作为下一帧的开始标志。
+func ReadHDLCFrame(buf Buffer) (address, control byte, info []byte, err error) {
+	if buf.Len() == 0 {
+		err = io.EOF
+		return
+	}
+	start := buf.Index(hdlcFlag)
+	if start < 0 {
+		buf.Discard(buf.Len())
+		err = io.ErrUnexpectedEOF
+		return
+	}
+	buf.Discard(start)
+	p := buf.Peek(buf.Len())
+	start = 1
+	for start < len(p) && p[start] == hdlcFlag {
+		start++
+	}
+	end := -1
+	for i := start; i < len(p); i++ {
+		if p[i] == hdlcFlag {
+			end = i
+			break
+		}
+	}
+	if end < 0 {
+		buf.Discard(start - 1)
+		err = io.ErrUnexpectedEOF
+		return
+	}
+
+	frame := make([]byte, 0, end-start)
+	for i := start; i < end; i++ {
+		b := p[i]
+		if b == hdlcEscape {
+			i++
+			if i == end {
+				err = ErrHDLCInvalid
+				break
+			}
+			b = p[i] ^ hdlcXor
+		}
+		frame = append(frame, b)
+	}
+	buf.Discard(end) // the closing flag may open the next frame
+	if err != nil {
+		return
+	}
+	if len(frame) < 4 {
+		err = ErrHDLCInvalid
+		return
+	}
+	n := len(frame) - 2
+	fcs := hdlcFCS(0xFFFF, frame[:n]) ^ 0xFFFF
+	if frame[n] != byte(fcs) || frame[n+1] != byte(fcs>>8) {
+		err = ErrHDLCChecksum
+		return
+	}
+	address, control = frame[0], frame[1]
+	info = frame[2:n]
+	return
+}
@@ -0,0 +1,106 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestWriteHDLCFrame(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	// CRC-16/X-25 of "123456789" is 0x906E, sent least significant byte first.
+	if err := bytebuffers.WriteHDLCFrame(buf, '1', '2', []byte("3456789")); err != nil {
+		t.Fatal(err)
+	}
+	known := []byte{0x7E, '1', '2', '3', '4', '5', '6', '7', '8', '9', 0x6E, 0x90, 0x7E}
+	if !bytes.Equal(buf.CloneBytes(), known) {
+		t.Fatal("unexpected frame", buf.CloneBytes())
+	}
+	// only the exact stuffed size is reserved
+	fixed := bytebuffers.NewFixedBuffer(len(known))
+	if err := bytebuffers.WriteHDLCFrame(fixed, '1', '2', []byte("3456789")); err != nil || !bytes.Equal(fixed.CloneBytes(), known) {
+		t.Fatal("unexpected fixed write", fixed.CloneBytes(), err)
+	}
+	fixed = bytebuffers.NewFixedBuffer(len(known) - 1)
+	if err := bytebuffers.WriteHDLCFrame(fixed, '1', '2', []byte("3456789")); !errors.Is(err, bytebuffers.ErrBufferFull) {
+		t.Fatal("expected buffer full, got", err)
+	}
+	buf.Reset()
+
+	info := []byte{0xC0, 0x21, 0x7E, 0x7D, 0x01}
+	if err := bytebuffers.WriteHDLCFrame(buf, 0xFF, 0x03, info); err != nil {
+		t.Fatal(err)
+	}
+	frame := buf.CloneBytes()
+	if frame[0] != 0x7E || frame[len(frame)-1] != 0x7E {
+		t.Fatal("missing flags", frame)
+	}
+	if bytes.IndexByte(frame[1:len(frame)-1], 0x7E) != -1 {
+		t.Fatal("flag not stuffed", frame)
+	}
+	if !bytes.Contains(frame, []byte{0x7D, 0x5E, 0x7D, 0x5D}) {
+		t.Fatal("escape not stuffed", frame)
+	}
+	fixed = bytebuffers.NewFixedBuffer(len(frame))
+	if err := bytebuffers.WriteHDLCFrame(fixed, 0xFF, 0x03, info); err != nil || !bytes.Equal(fixed.CloneBytes(), frame) {
+		t.Fatal("unexpected fixed write", fixed.CloneBytes(), err)
+	}
+
+	address, control, decoded, err := bytebuffers.ReadHDLCFrame(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if address != 0xFF || control != 0x03 || !bytes.Equal(decoded, info) {
+		t.Fatal("round trip mismatch", address, control, decoded)
+	}
+	if !bytes.Equal(buf.CloneBytes(), []byte{0x7E}) {
+		t.Fatal("frame not consumed", buf.CloneBytes())
+	}
+}
+
+func TestReadHDLCFrame(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	_ = bytebuffers.WriteHDLCFrame(buf, 0xFF, 0x03, []byte("hello"))
+	frame := buf.CloneBytes()
+
+	buf.Reset()
+	_, _ = buf.Write(frame[:len(frame)-1])
+	if _, _, _, err := bytebuffers.ReadHDLCFrame(buf); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal("expected unexpected EOF, got", err)
+	}
+	_ = buf.WriteByte(0x7E)
+	if _, _, info, err := bytebuffers.ReadHDLCFrame(buf); err != nil || string(info) != "hello" {
+		t.Fatal(info, err)
+	}
+
+	frame[4] ^= 0x01
+	_, _ = buf.Write(frame)
+	if _, _, _, err := bytebuffers.ReadHDLCFrame(buf); !errors.Is(err, bytebuffers.ErrHDLCChecksum) {
+		t.Fatal("expected fcs mismatch, got", err)
+	}
+	if !bytes.Equal(buf.CloneBytes(), []byte{0x7E}) {
+		t.Fatal("bad frame not discarded", buf.CloneBytes())
+	}
+}
+
+func TestReadHDLCFrameSharedFlag(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	_ = bytebuffers.WriteHDLCFrame(buf, 0xFF, 0x03, []byte("first"))
+	_ = bytebuffers.WriteHDLCFrame(buf, 0xFF, 0x03, []byte("second"))
+	// 7E f1 7E f2 7E
+	frames := buf.CloneBytes()
+	i := bytes.Index(frames, []byte{0x7E, 0x7E})
+	buf.Reset()
+	_, _ = buf.Write(append(frames[:i:i], frames[i+1:]...))
+	for _, expected := range []string{"first", "second"} {
+		if _, _, info, err := bytebuffers.ReadHDLCFrame(buf); err != nil || string(info) != expected {
+			t.Fatal("unexpected frame", string(info), err)
+		}
+	}
+	if _, _, _, err := bytebuffers.ReadHDLCFrame(buf); !errors.Is(err, io.ErrUnexpectedEOF) || buf.Len() != 1 {
+		t.Fatal("expected unexpected EOF, got", err, buf.Len())
+	}
+}
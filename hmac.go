@@ -0,0 +1,43 @@
+package bytebuffers
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"errors"
+	"hash"
+)
+
+var (
+	ErrHMACMismatch = errors.New("bytebuffers.Crypto: hmac mismatch")
+)
+
+// HMACSign
+// 以 HMAC 签名可读内容，并将标签追加至末尾。
+func HMACSign(buf Buffer, key []byte, h func() hash.Hash) (err error) {
+	mac := hmac.New(h, key)
+	mac.Write(buf.Peek(buf.Len()))
+	_, err = buf.Write(mac.Sum(nil))
+	return
+}
+
+// HMACVerify
+// 校验末尾的 HMAC 标签，成功后去除标签。
+//
+// 当标签不匹配时返回 ErrHMACMismatch，且可读内容不变。
+func HMACVerify(buf Buffer, key []byte, h func() hash.Hash) (err error) {
+	mac := hmac.New(h, key)
+	p := buf.Peek(buf.Len())
+	n := len(p) - mac.Size()
+	if n < 0 {
+		err = ErrHMACMismatch
+		return
+	}
+	mac.Write(p[:n])
+	if !hmac.Equal(mac.Sum(nil), p[n:]) {
+		err = ErrHMACMismatch
+		return
+	}
+	// Set may move the readable region, so do not pass it an aliased slice.
+	err = buf.Set(bytes.Clone(p[:n]))
+	return
+}
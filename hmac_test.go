@@ -0,0 +1,53 @@
+package bytebuffers_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestHMACSign(t *testing.T) {
+	key := []byte("secret")
+	message := []byte("0123456789")
+
+	buf := bytebuffers.NewBuffer()
+	_, _ = buf.Write(message)
+	if err := bytebuffers.HMACSign(buf, key, sha256.New); err != nil {
+		t.Fatal(err)
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(message)
+	if !hmac.Equal(buf.Peek(buf.Len())[len(message):], mac.Sum(nil)) {
+		t.Fatal("unexpected tag")
+	}
+	if err := bytebuffers.HMACVerify(buf, key, sha256.New); err != nil {
+		t.Fatal(err)
+	}
+	if string(buf.CloneBytes()) != string(message) {
+		t.Fatal("tag not removed", buf.Len())
+	}
+}
+
+func TestHMACVerify(t *testing.T) {
+	key := []byte("secret")
+	buf := bytebuffers.NewBuffer()
+	_, _ = buf.Write([]byte("0123456789"))
+	_ = bytebuffers.HMACSign(buf, key, sha256.New)
+	signed := buf.CloneBytes()
+	signed[3] ^= 0x01
+	_ = buf.Set(signed)
+	if err := bytebuffers.HMACVerify(buf, key, sha256.New); !errors.Is(err, bytebuffers.ErrHMACMismatch) {
+		t.Fatal("expected mismatch, got", err)
+	}
+	if buf.Len() != len(signed) {
+		t.Fatal("buffer changed on failure")
+	}
+
+	_ = buf.SetString("short")
+	if err := bytebuffers.HMACVerify(buf, key, sha256.New); !errors.Is(err, bytebuffers.ErrHMACMismatch) {
+		t.Fatal("expected mismatch, got", err)
+	}
+}
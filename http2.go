@@ -0,0 +1,167 @@
+package bytebuffers
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+const (
+	HTTP2FrameData         = 0x0
+	HTTP2FrameHeaders      = 0x1
+	HTTP2FramePriority     = 0x2
+	HTTP2FrameRSTStream    = 0x3
+	HTTP2FrameSettings     = 0x4
+	HTTP2FramePushPromise  = 0x5
+	HTTP2FramePing         = 0x6
+	HTTP2FrameGoAway       = 0x7
+	HTTP2FrameWindowUpdate = 0x8
+	HTTP2FrameContinuation = 0x9
+
+	HTTP2FlagEndStream  = 0x1
+	HTTP2FlagAck        = 0x1
+	HTTP2FlagEndHeaders = 0x4
+
+	http2FrameHeaderLen = 9
+	http2MaxLength      = 1<<24 - 1
+	http2ReservedBit    = 1 << 31
+)
+
+var (
+	ErrHTTP2ReservedBit = errors.New("bytebuffers.HTTP2: reserved bit of stream id is set")
+	ErrHTTP2SettingsAck = errors.New("bytebuffers.HTTP2: settings ack must be empty")
+)
+
+// HTTP2FrameHeader
+// HTTP/2 帧头（RFC 7540 §4.1）。
+type HTTP2FrameHeader struct {
+	Length   uint32
+	Type     byte
+	Flags    byte
+	StreamID uint32
+}
+
+// HTTP2Setting
+// SETTINGS 帧的参数。
+type HTTP2Setting struct {
+	ID    uint16
+	Value uint32
+}
+
+// WriteHTTP2FrameHeader
+// 写入 9 字节的 HTTP/2 帧头：3 字节长度、1 字节类型、1 字节标志与 4 字节流标识。
+func WriteHTTP2FrameHeader(buf Buffer, length uint32, frameType, flags byte, streamID uint32) (err error) {
+	if length > http2MaxLength {
+		err = ErrTooLarge
+		return
+	}
+	if streamID&http2ReservedBit != 0 {
+		err = ErrHTTP2ReservedBit
+		return
+	}
+	p, borrowErr := buf.Borrow(http2FrameHeaderLen)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	putHTTP2FrameHeader(p, length, frameType, flags, streamID)
+	buf.Return(http2FrameHeaderLen)
+	return
+}
+
+// ReadHTTP2FrameHeader
+// 读取 HTTP/2 帧头，流标识的保留位被设置时返回 ErrHTTP2ReservedBit。失败或不完整时不读掉。
+func ReadHTTP2FrameHeader(buf Buffer) (header HTTP2FrameHeader, err error) {
+	p, peekErr := peekFull(buf, http2FrameHeaderLen)
+	if peekErr != nil {
+		err = peekErr
+		return
+	}
+	streamID := binary.BigEndian.Uint32(p[5:])
+	if streamID&http2ReservedBit != 0 {
+		err = ErrHTTP2ReservedBit
+		return
+	}
+	header = HTTP2FrameHeader{
+		Length:   uint32(p[0])<<16 | uint32(p[1])<<8 | uint32(p[2]),
+		Type:     p[3],
+		Flags:    p[4],
+		StreamID: streamID,
+	}
+	buf.Discard(http2FrameHeaderLen)
+	return
+}
+
+// WriteHTTP2Data
+// 写入 DATA 帧。
+func WriteHTTP2Data(buf Buffer, streamID uint32, endStream bool, data []byte) (err error) {
+	var flags byte
+	if endStream {
+		flags |= HTTP2FlagEndStream
+	}
+	err = writeHTTP2Frame(buf, HTTP2FrameData, flags, streamID, data)
+	return
+}
+
+// WriteHTTP2Headers
+// 写入 HEADERS 帧，headerBlock 为已经 HPACK 编码的头块片段。
+func WriteHTTP2Headers(buf Buffer, streamID uint32, endStream, endHeaders bool, headerBlock []byte) (err error) {
+	var flags byte
+	if endStream {
+		flags |= HTTP2FlagEndStream
+	}
+	if endHeaders {
+		flags |= HTTP2FlagEndHeaders
+	}
+	err = writeHTTP2Frame(buf, HTTP2FrameHeaders, flags, streamID, headerBlock)
+	return
+}
+
+// WriteHTTP2Settings
+// 写入 SETTINGS 帧，ack 为 true 时为确认帧，且不能带参数。
+func WriteHTTP2Settings(buf Buffer, ack bool, settings []HTTP2Setting) (err error) {
+	var flags byte
+	if ack {
+		if len(settings) > 0 {
+			err = ErrHTTP2SettingsAck
+			return
+		}
+		flags |= HTTP2FlagAck
+	}
+	payload := make([]byte, 0, 6*len(settings))
+	for _, s := range settings {
+		payload = binary.BigEndian.AppendUint16(payload, s.ID)
+		payload = binary.BigEndian.AppendUint32(payload, s.Value)
+	}
+	err = writeHTTP2Frame(buf, HTTP2FrameSettings, flags, 0, payload)
+	return
+}
+
+func writeHTTP2Frame(buf Buffer, frameType, flags byte, streamID uint32, payload []byte) (err error) {
+	if len(payload) > http2MaxLength {
+		err = ErrTooLarge
+		return
+	}
+	if streamID&http2ReservedBit != 0 {
+		err = ErrHTTP2ReservedBit
+		return
+	}
+	size := http2FrameHeaderLen + len(payload)
+	p, borrowErr := buf.Borrow(size)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	putHTTP2FrameHeader(p, uint32(len(payload)), frameType, flags, streamID)
+	copy(p[http2FrameHeaderLen:], payload)
+	buf.Return(size)
+	return
+}
+
+func putHTTP2FrameHeader(p []byte, length uint32, frameType, flags byte, streamID uint32) {
+	p[0] = byte(length >> 16)
+	p[1] = byte(length >> 8)
+	p[2] = byte(length)
+	p[3] = frameType
+	p[4] = flags
+	binary.BigEndian.PutUint32(p[5:], streamID)
+}
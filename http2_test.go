@@ -0,0 +1,63 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestWriteHTTP2FrameHeader(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	if err := bytebuffers.WriteHTTP2FrameHeader(buf, 0x010203, bytebuffers.HTTP2FrameHeaders, bytebuffers.HTTP2FlagEndHeaders, 0x7FFFFFFF); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.CloneBytes(), []byte{0x01, 0x02, 0x03, 0x01, 0x04, 0x7F, 0xFF, 0xFF, 0xFF}) {
+		t.Fatal("unexpected frame header", buf.CloneBytes())
+	}
+	header, err := bytebuffers.ReadHTTP2FrameHeader(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := bytebuffers.HTTP2FrameHeader{Length: 0x010203, Type: bytebuffers.HTTP2FrameHeaders, Flags: bytebuffers.HTTP2FlagEndHeaders, StreamID: 0x7FFFFFFF}
+	if header != expected {
+		t.Fatal("round trip mismatch", header)
+	}
+
+	if err = bytebuffers.WriteHTTP2FrameHeader(buf, 1<<24, 0, 0, 1); !errors.Is(err, bytebuffers.ErrTooLarge) {
+		t.Fatal("expected too large, got", err)
+	}
+	if err = bytebuffers.WriteHTTP2FrameHeader(buf, 0, 0, 0, 1<<31); !errors.Is(err, bytebuffers.ErrHTTP2ReservedBit) {
+		t.Fatal("expected reserved bit error, got", err)
+	}
+}
+
+func TestReadHTTP2FrameHeader(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	_, _ = buf.Write([]byte{0x00, 0x00, 0x00, 0x04, 0x00, 0x80, 0x00, 0x00, 0x01})
+	if _, err := bytebuffers.ReadHTTP2FrameHeader(buf); !errors.Is(err, bytebuffers.ErrHTTP2ReservedBit) {
+		t.Fatal("expected reserved bit error, got", err)
+	}
+}
+
+func TestWriteHTTP2Frames(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	// SETTINGS with SETTINGS_MAX_CONCURRENT_STREAMS=100, then its ACK
+	_ = bytebuffers.WriteHTTP2Settings(buf, false, []bytebuffers.HTTP2Setting{{ID: 0x3, Value: 100}})
+	_ = bytebuffers.WriteHTTP2Settings(buf, true, nil)
+	_ = bytebuffers.WriteHTTP2Headers(buf, 1, false, true, []byte{0x82})
+	_ = bytebuffers.WriteHTTP2Data(buf, 1, true, []byte("ok"))
+	expected := []byte{
+		0x00, 0x00, 0x06, 0x04, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0x00, 0x00, 0x00, 0x64,
+		0x00, 0x00, 0x00, 0x04, 0x01, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x01, 0x01, 0x04, 0x00, 0x00, 0x00, 0x01, 0x82,
+		0x00, 0x00, 0x02, 0x00, 0x01, 0x00, 0x00, 0x00, 0x01, 'o', 'k',
+	}
+	if !bytes.Equal(buf.CloneBytes(), expected) {
+		t.Fatal("unexpected frames", buf.CloneBytes())
+	}
+	if err := bytebuffers.WriteHTTP2Settings(buf, true, []bytebuffers.HTTP2Setting{{ID: 1}}); !errors.Is(err, bytebuffers.ErrHTTP2SettingsAck) {
+		t.Fatal("expected settings ack error, got", err)
+	}
+}
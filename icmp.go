@@ -0,0 +1,98 @@
+package bytebuffers
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+const (
+	ICMPv4TypeEchoReply              = 0
+	ICMPv4TypeDestinationUnreachable = 3
+	ICMPv4TypeRedirect               = 5
+	ICMPv4TypeEchoRequest            = 8
+	ICMPv4TypeTimeExceeded           = 11
+	ICMPv4TypeParameterProblem       = 12
+
+	icmpHeaderLen = 8
+)
+
+var (
+	ErrICMPInvalid  = errors.New("bytebuffers.ICMP: invalid message")
+	ErrICMPChecksum = errors.New("bytebuffers.ICMP: checksum mismatch")
+)
+
+// ICMPv4Message
+// ICMPv4 消息，Rest 为首部后 4 字节，Data 为其后的内容。
+type ICMPv4Message struct {
+	Type     byte
+	Code     byte
+	Checksum uint16
+	Rest     uint32
+	Data     []byte
+}
+
+// ID
+// 回显请求与应答的标识符。
+func (m ICMPv4Message) ID() uint16 {
+	return uint16(m.Rest >> 16)
+}
+
+// Seq
+// 回显请求与应答的序列号。
+func (m ICMPv4Message) Seq() uint16 {
+	return uint16(m.Rest)
+}
+
+// WriteICMPv4Echo
+// 写入 ICMPv4 回显请求：类型 8、代码 0、校验和、标识符、序列号与 data，校验和覆盖整个 ICMP 消息。
+func WriteICMPv4Echo(buf Buffer, id, seq uint16, data []byte) (err error) {
+	if len(data) > maxInt-icmpHeaderLen {
+		err = ErrTooLarge
+		return
+	}
+	size := icmpHeaderLen + len(data)
+	p, borrowErr := buf.Borrow(size)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	p[0] = ICMPv4TypeEchoRequest
+	p[1] = 0
+	binary.BigEndian.PutUint16(p[2:], 0) // checksum placeholder
+	binary.BigEndian.PutUint16(p[4:], id)
+	binary.BigEndian.PutUint16(p[6:], seq)
+	copy(p[icmpHeaderLen:], data)
+	binary.BigEndian.PutUint16(p[2:], internetChecksum(0, p))
+	buf.Return(size)
+	return
+}
+
+// ReadICMPv4
+// 将整个可读内容作为一个 ICMPv4 消息解析并校验校验和，成功后全部读掉，出错时不读掉。
+func ReadICMPv4(buf Buffer) (message ICMPv4Message, err error) {
+	n := buf.Len()
+	if n == 0 {
+		err = io.EOF
+		return
+	}
+	p := buf.Peek(n)
+	if len(p) < icmpHeaderLen {
+		err = ErrICMPInvalid
+		return
+	}
+	if internetChecksum(0, p) != 0 {
+		err = ErrICMPChecksum
+		return
+	}
+	message = ICMPv4Message{
+		Type:     p[0],
+		Code:     p[1],
+		Checksum: binary.BigEndian.Uint16(p[2:]),
+		Rest:     binary.BigEndian.Uint32(p[4:]),
+		Data:     make([]byte, len(p)-icmpHeaderLen),
+	}
+	copy(message.Data, p[icmpHeaderLen:])
+	buf.Discard(n)
+	return
+}
@@ -0,0 +1,58 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestICMPv4Echo(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	data := []byte("abcdefghijklmnopqrstuvwabcdefghi")
+	if err := bytebuffers.WriteICMPv4Echo(buf, 0x0001, 0x0007, data); err != nil {
+		t.Fatal(err)
+	}
+	p := buf.CloneBytes()
+	if p[0] != bytebuffers.ICMPv4TypeEchoRequest || p[1] != 0 {
+		t.Fatal("unexpected type or code", p[:2])
+	}
+	if onesComplementSum(p) != 0xFFFF {
+		t.Fatal("invalid checksum", binary.BigEndian.Uint16(p[2:]))
+	}
+	if !bytes.Equal(p[4:8], []byte{0x00, 0x01, 0x00, 0x07}) || !bytes.Equal(p[8:], data) {
+		t.Fatal("unexpected echo request", p)
+	}
+	buf.Reset()
+
+	// the peer answers with type 0 and an adjusted checksum
+	reply := append([]byte{}, p...)
+	reply[0] = bytebuffers.ICMPv4TypeEchoReply
+	binary.BigEndian.PutUint16(reply[2:], 0)
+	binary.BigEndian.PutUint16(reply[2:], ^onesComplementSum(reply))
+	_, _ = buf.Write(reply)
+
+	message, err := bytebuffers.ReadICMPv4(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if message.Type != bytebuffers.ICMPv4TypeEchoReply || message.Code != 0 || message.ID() != 1 || message.Seq() != 7 || !bytes.Equal(message.Data, data) {
+		t.Fatal("unexpected message", message)
+	}
+	if buf.Len() != 0 {
+		t.Fatal("message must be discarded")
+	}
+
+	reply[8] ^= 0xFF
+	_, _ = buf.Write(reply)
+	if _, err = bytebuffers.ReadICMPv4(buf); !errors.Is(err, bytebuffers.ErrICMPChecksum) {
+		t.Fatal("expected checksum mismatch, got", err)
+	}
+	buf.Reset()
+	_, _ = buf.Write(reply[:7])
+	if _, err = bytebuffers.ReadICMPv4(buf); !errors.Is(err, bytebuffers.ErrICMPInvalid) {
+		t.Fatal("expected invalid, got", err)
+	}
+}
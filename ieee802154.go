@@ -0,0 +1,173 @@
+package bytebuffers
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+const (
+	IEEE802154FrameBeacon     = 0x0
+	IEEE802154FrameData       = 0x1
+	IEEE802154FrameAck        = 0x2
+	IEEE802154FrameMACCommand = 0x3
+
+	IEEE802154SecurityEnabled  = 0x0008
+	IEEE802154FramePending     = 0x0010
+	IEEE802154AckRequest       = 0x0020
+	IEEE802154PANIDCompression = 0x0040
+
+	IEEE802154AddrNone     = 0x0
+	IEEE802154AddrShort    = 0x2
+	IEEE802154AddrExtended = 0x3
+
+	ieee802154FCSLen = 2
+)
+
+var (
+	ErrIEEE802154Invalid  = errors.New("bytebuffers.IEEE802154: invalid frame")
+	ErrIEEE802154Checksum = errors.New("bytebuffers.IEEE802154: fcs mismatch")
+)
+
+// IEEE802154Frame
+// IEEE 802.15.4 MAC 帧，地址按其模式存放于低位，不存在的字段为 0。
+type IEEE802154Frame struct {
+	FrameControl uint16
+	SeqNum       byte
+	DstPANID     uint16
+	DstAddr      uint64
+	SrcPANID     uint16
+	SrcAddr      uint64
+	Payload      []byte
+}
+
+// FrameType
+// 帧类型（FCF 的第 0 至 2 位）。
+func (frame IEEE802154Frame) FrameType() byte {
+	return byte(frame.FrameControl & 0x07)
+}
+
+// DstAddrMode
+// 目的地址模式（FCF 的第 10、11 位）。
+func (frame IEEE802154Frame) DstAddrMode() byte {
+	return byte(frame.FrameControl>>10) & 0x03
+}
+
+// SrcAddrMode
+// 源地址模式（FCF 的第 14、15 位）。
+func (frame IEEE802154Frame) SrcAddrMode() byte {
+	return byte(frame.FrameControl>>14) & 0x03
+}
+
+// WriteIEEE802154Frame
+// 写入使用 2 字节短地址的 IEEE 802.15.4 数据帧：FCF、序号、PAN ID、目的地址、源地址、payload 与 FCS，均为小端。
+//
+// frameControl 中的帧类型与地址模式会被设置为数据帧与短地址；未设置 PAN ID 压缩时源 PAN ID 同为 panID。
+// FCS 为 CRC-16/KERMIT。
+func WriteIEEE802154Frame(buf Buffer, frameControl uint16, seqNum byte, panID, dst, src uint16, payload []byte) (err error) {
+	fcf := frameControl&^(0x0007|0x0C00|0xC000) | IEEE802154FrameData | IEEE802154AddrShort<<10 | IEEE802154AddrShort<<14
+	headerLen := 3 + 4 + 2
+	if fcf&IEEE802154PANIDCompression == 0 {
+		headerLen += 2
+	}
+	if len(payload) > maxInt-headerLen-ieee802154FCSLen {
+		err = ErrTooLarge
+		return
+	}
+	size := headerLen + len(payload) + ieee802154FCSLen
+	p, borrowErr := buf.Borrow(size)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	binary.LittleEndian.PutUint16(p, fcf)
+	p[2] = seqNum
+	binary.LittleEndian.PutUint16(p[3:], panID)
+	binary.LittleEndian.PutUint16(p[5:], dst)
+	n := 7
+	if fcf&IEEE802154PANIDCompression == 0 {
+		binary.LittleEndian.PutUint16(p[n:], panID)
+		n += 2
+	}
+	binary.LittleEndian.PutUint16(p[n:], src)
+	n += 2
+	n += copy(p[n:], payload)
+	binary.LittleEndian.PutUint16(p[n:], hdlcFCS(0, p[:n]))
+	buf.Return(size)
+	return
+}
+
+// ReadIEEE802154Frame
+// 将缓冲中的全部内容解析为一个 IEEE 802.15.4 MAC 帧（含 FCS），按 FCF 的地址模式读取地址并校验 FCS。
+//
+// 辅助安全头不做解析，包含在 Payload 中。失败时不读掉。
+func ReadIEEE802154Frame(buf Buffer) (frame IEEE802154Frame, err error) {
+	p := buf.Peek(buf.Len())
+	if len(p) == 0 {
+		err = io.EOF
+		return
+	}
+	if len(p) < 3+ieee802154FCSLen {
+		err = io.ErrUnexpectedEOF
+		return
+	}
+	end := len(p) - ieee802154FCSLen
+	if hdlcFCS(0, p[:end]) != binary.LittleEndian.Uint16(p[end:]) {
+		err = ErrIEEE802154Checksum
+		return
+	}
+	frame.FrameControl = binary.LittleEndian.Uint16(p)
+	frame.SeqNum = p[2]
+	dstMode, srcMode := frame.DstAddrMode(), frame.SrcAddrMode()
+	if dstMode == 0x1 || srcMode == 0x1 {
+		frame = IEEE802154Frame{}
+		err = ErrIEEE802154Invalid
+		return
+	}
+	n := 3
+	var ok bool
+	if dstMode != IEEE802154AddrNone {
+		if frame.DstPANID, frame.DstAddr, n, ok = readIEEE802154Addr(p[:end], n, dstMode, true); !ok {
+			frame, err = IEEE802154Frame{}, ErrIEEE802154Invalid
+			return
+		}
+	}
+	if srcMode != IEEE802154AddrNone {
+		withPAN := dstMode == IEEE802154AddrNone || frame.FrameControl&IEEE802154PANIDCompression == 0
+		if frame.SrcPANID, frame.SrcAddr, n, ok = readIEEE802154Addr(p[:end], n, srcMode, withPAN); !ok {
+			frame, err = IEEE802154Frame{}, ErrIEEE802154Invalid
+			return
+		}
+		if !withPAN {
+			frame.SrcPANID = frame.DstPANID
+		}
+	}
+	frame.Payload = make([]byte, end-n)
+	copy(frame.Payload, p[n:end])
+	buf.Discard(len(p))
+	return
+}
+
+func readIEEE802154Addr(p []byte, off int, mode byte, withPAN bool) (panID uint16, addr uint64, next int, ok bool) {
+	size := 2
+	if mode == IEEE802154AddrExtended {
+		size = 8
+	}
+	if withPAN {
+		if len(p)-off < 2 {
+			return
+		}
+		panID = binary.LittleEndian.Uint16(p[off:])
+		off += 2
+	}
+	if len(p)-off < size {
+		return
+	}
+	if size == 2 {
+		addr = uint64(binary.LittleEndian.Uint16(p[off:]))
+	} else {
+		addr = binary.LittleEndian.Uint64(p[off:])
+	}
+	next, ok = off+size, true
+	return
+}
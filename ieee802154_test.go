@@ -0,0 +1,62 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestIEEE802154Frame(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	payload := []byte{0x48, 0x65, 0x6C, 0x6C, 0x6F}
+	fcf := uint16(bytebuffers.IEEE802154AckRequest | bytebuffers.IEEE802154PANIDCompression)
+	if err := bytebuffers.WriteIEEE802154Frame(buf, fcf, 0x17, 0x1AAA, 0xFFFF, 0x0001, payload); err != nil {
+		t.Fatal(err)
+	}
+	p := buf.CloneBytes()
+	// FCF 0x8861: data frame, ack request, pan id compression, short destination and source addresses
+	if !bytes.Equal(p[:2], []byte{0x61, 0x88}) {
+		t.Fatal("unexpected frame control", hex.EncodeToString(p[:2]))
+	}
+	expected, _ := hex.DecodeString("6188" + "17" + "aa1a" + "ffff" + "0100" + "48656c6c6f")
+	if !bytes.Equal(p[:len(p)-2], expected) {
+		t.Fatal("unexpected frame", hex.EncodeToString(p))
+	}
+
+	frame, err := bytebuffers.ReadIEEE802154Frame(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if frame.FrameType() != bytebuffers.IEEE802154FrameData || frame.DstAddrMode() != bytebuffers.IEEE802154AddrShort || frame.SrcAddrMode() != bytebuffers.IEEE802154AddrShort {
+		t.Fatal("unexpected frame control", frame.FrameControl)
+	}
+	if frame.SeqNum != 0x17 || frame.DstPANID != 0x1AAA || frame.SrcPANID != 0x1AAA || frame.DstAddr != 0xFFFF || frame.SrcAddr != 0x0001 {
+		t.Fatal("unexpected addressing", frame)
+	}
+	if !bytes.Equal(frame.Payload, payload) {
+		t.Fatal("unexpected payload", frame.Payload)
+	}
+
+	// without pan id compression the source pan id is present
+	if err = bytebuffers.WriteIEEE802154Frame(buf, 0, 1, 0x1234, 2, 3, nil); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 3+4+4+2 {
+		t.Fatal("unexpected frame length", buf.Len())
+	}
+	if frame, err = bytebuffers.ReadIEEE802154Frame(buf); err != nil || frame.SrcPANID != 0x1234 || frame.SrcAddr != 3 {
+		t.Fatal("unexpected frame", frame, err)
+	}
+
+	_ = bytebuffers.WriteIEEE802154Frame(buf, fcf, 0x17, 0x1AAA, 0xFFFF, 0x0001, payload)
+	p = buf.CloneBytes()
+	p[len(p)-1] ^= 0xFF
+	buf.Reset()
+	_, _ = buf.Write(p)
+	if _, err = bytebuffers.ReadIEEE802154Frame(buf); !errors.Is(err, bytebuffers.ErrIEEE802154Checksum) {
+		t.Fatal("expected fcs mismatch, got", err)
+	}
+}
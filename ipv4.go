@@ -0,0 +1,70 @@
+package bytebuffers
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"net"
+)
+
+const (
+	ipv4HeaderLen = 20
+	ipv4TTL       = 64
+)
+
+var (
+	ErrIPv4InvalidAddress = errors.New("bytebuffers.IPv4: invalid address")
+)
+
+// WriteIPv4Header
+// 写入 20 字节的最小 IPv4 首部及 payload。
+//
+// 标识为随机值，TTL 为 64，无分片，校验和自动计算。
+func WriteIPv4Header(buf Buffer, src, dst net.IP, protocol byte, payload []byte) (err error) {
+	src4, dst4 := src.To4(), dst.To4()
+	if src4 == nil || dst4 == nil {
+		err = ErrIPv4InvalidAddress
+		return
+	}
+	total := ipv4HeaderLen + len(payload)
+	if total > math.MaxUint16 {
+		err = ErrTooLarge
+		return
+	}
+	p, borrowErr := buf.Borrow(total)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	p[0] = 4<<4 | ipv4HeaderLen/4 // version and ihl
+	p[1] = 0                      // dscp and ecn
+	binary.BigEndian.PutUint16(p[2:], uint16(total))
+	binary.BigEndian.PutUint16(p[4:], uint16(rand.Uint32()))
+	binary.BigEndian.PutUint16(p[6:], 0) // flags and fragment offset
+	p[8] = ipv4TTL
+	p[9] = protocol
+	binary.BigEndian.PutUint16(p[10:], 0) // checksum placeholder
+	copy(p[12:16], src4)
+	copy(p[16:20], dst4)
+	binary.BigEndian.PutUint16(p[10:], internetChecksum(0, p[:ipv4HeaderLen]))
+	copy(p[ipv4HeaderLen:], payload)
+	buf.Return(total)
+	return
+}
+
+// internetChecksum
+// 计算 RFC 1071 的反码和校验和，sum 为初始的部分和。
+func internetChecksum(sum uint32, p []byte) uint16 {
+	n := len(p)
+	for i := 0; i+1 < n; i += 2 {
+		sum += uint32(p[i])<<8 | uint32(p[i+1])
+	}
+	if n%2 == 1 {
+		sum += uint32(p[n-1]) << 8
+	}
+	for sum > 0xFFFF {
+		sum = sum>>16 + sum&0xFFFF
+	}
+	return ^uint16(sum)
+}
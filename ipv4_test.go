@@ -0,0 +1,60 @@
+package bytebuffers_test
+
+import (
+	"encoding/binary"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+// onesComplementSum folds p into a 16-bit one's complement sum.
+func onesComplementSum(p []byte) uint16 {
+	var sum uint32
+	for i := 0; i+1 < len(p); i += 2 {
+		sum += uint32(binary.BigEndian.Uint16(p[i:]))
+	}
+	if len(p)%2 == 1 {
+		sum += uint32(p[len(p)-1]) << 8
+	}
+	for sum > 0xFFFF {
+		sum = sum>>16 + sum&0xFFFF
+	}
+	return uint16(sum)
+}
+
+func TestWriteIPv4Header(t *testing.T) {
+	// ICMP echo request with id=1, seq=1 and "ping" as data.
+	icmp := []byte{8, 0, 0, 0, 0, 1, 0, 1, 'p', 'i', 'n', 'g'}
+	binary.BigEndian.PutUint16(icmp[2:], ^onesComplementSum(icmp))
+
+	src, dst := net.ParseIP("192.168.1.1"), net.ParseIP("10.0.0.1")
+	buf := bytebuffers.NewBuffer()
+	if err := bytebuffers.WriteIPv4Header(buf, src, dst, 1, icmp); err != nil {
+		t.Fatal(err)
+	}
+	pkt := buf.CloneBytes()
+	if len(pkt) != 20+len(icmp) {
+		t.Fatal("unexpected length", len(pkt))
+	}
+	if pkt[0] != 0x45 || pkt[8] != 64 || pkt[9] != 1 {
+		t.Fatal("unexpected header fields", pkt[:20])
+	}
+	if binary.BigEndian.Uint16(pkt[2:]) != uint16(len(pkt)) {
+		t.Fatal("unexpected total length")
+	}
+	if !net.IP(pkt[12:16]).Equal(src) || !net.IP(pkt[16:20]).Equal(dst) {
+		t.Fatal("unexpected addresses")
+	}
+	if onesComplementSum(pkt[:20]) != 0xFFFF {
+		t.Fatal("invalid header checksum")
+	}
+	if onesComplementSum(pkt[20:]) != 0xFFFF {
+		t.Fatal("invalid icmp checksum")
+	}
+
+	if err := bytebuffers.WriteIPv4Header(buf, net.ParseIP("::1"), dst, 1, nil); !errors.Is(err, bytebuffers.ErrIPv4InvalidAddress) {
+		t.Fatal("expected invalid address, got", err)
+	}
+}
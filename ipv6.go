@@ -0,0 +1,69 @@
+package bytebuffers
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"net"
+)
+
+const (
+	IPv6NextHeaderHopByHop    = 0
+	IPv6NextHeaderTCP         = 6
+	IPv6NextHeaderUDP         = 17
+	IPv6NextHeaderRouting     = 43
+	IPv6NextHeaderFragment    = 44
+	IPv6NextHeaderICMPv6      = 58
+	IPv6NextHeaderNone        = 59
+	IPv6NextHeaderDestOptions = 60
+
+	ipv6HeaderLen = 40
+	ipv6HopLimit  = 64
+)
+
+var (
+	ErrIPv6InvalidAddress = errors.New("bytebuffers.IPv6: invalid address")
+)
+
+// WriteIPv6Header
+// 写入 40 字节的 IPv6 固定首部及 payload。
+//
+// 流量类别与流标签为 0，跳数限制为 64，payload 包含扩展头与上层数据，IPv6 首部没有校验和。
+func WriteIPv6Header(buf Buffer, src, dst net.IP, nextHeader byte, payload []byte) (err error) {
+	src16, dst16 := src.To16(), dst.To16()
+	if src16 == nil || dst16 == nil || src.To4() != nil || dst.To4() != nil {
+		err = ErrIPv6InvalidAddress
+		return
+	}
+	if len(payload) > math.MaxUint16 {
+		err = ErrTooLarge
+		return
+	}
+	total := ipv6HeaderLen + len(payload)
+	p, borrowErr := buf.Borrow(total)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	binary.BigEndian.PutUint32(p[0:], 6<<28) // version, traffic class and flow label
+	binary.BigEndian.PutUint16(p[4:], uint16(len(payload)))
+	p[6] = nextHeader
+	p[7] = ipv6HopLimit
+	copy(p[8:24], src16)
+	copy(p[24:40], dst16)
+	copy(p[ipv6HeaderLen:], payload)
+	buf.Return(total)
+	return
+}
+
+// ipv6PseudoHeaderSum
+// 计算上层校验和所用的 IPv6 伪首部（RFC 8200 8.1）的部分和，作为 internetChecksum 的初始值。
+func ipv6PseudoHeaderSum(src, dst net.IP, length uint32, nextHeader byte) (sum uint32) {
+	for i := 0; i < net.IPv6len; i += 2 {
+		sum += uint32(binary.BigEndian.Uint16(src[i:]))
+		sum += uint32(binary.BigEndian.Uint16(dst[i:]))
+	}
+	sum += length>>16 + length&0xFFFF
+	sum += uint32(nextHeader)
+	return
+}
@@ -0,0 +1,52 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestWriteIPv6Header(t *testing.T) {
+	udp := bytebuffers.NewBuffer()
+	if err := bytebuffers.WriteUDP(udp, 5353, 5353, []byte("hello")); err != nil {
+		t.Fatal(err)
+	}
+	// hop-by-hop options header padded with PadN, followed by the UDP datagram
+	payload := append([]byte{bytebuffers.IPv6NextHeaderUDP, 0, 1, 4, 0, 0, 0, 0}, udp.CloneBytes()...)
+
+	src, dst := net.ParseIP("fe80::1"), net.ParseIP("ff02::fb")
+	buf := bytebuffers.NewBuffer()
+	if err := bytebuffers.WriteIPv6Header(buf, src, dst, bytebuffers.IPv6NextHeaderHopByHop, payload); err != nil {
+		t.Fatal(err)
+	}
+	pkt := buf.CloneBytes()
+	if len(pkt) != 40+8+8+5 {
+		t.Fatal("unexpected length", len(pkt))
+	}
+	if pkt[0]>>4 != 6 {
+		t.Fatal("unexpected version", pkt[0]>>4)
+	}
+	if binary.BigEndian.Uint32(pkt)&0x0FFFFFFF != 0 {
+		t.Fatal("unexpected traffic class or flow label", pkt[:4])
+	}
+	if length := binary.BigEndian.Uint16(pkt[4:]); int(length) != 8+len(udp.CloneBytes()) {
+		t.Fatal("unexpected payload length", length)
+	}
+	if pkt[6] != bytebuffers.IPv6NextHeaderHopByHop || pkt[7] != 64 {
+		t.Fatal("unexpected next header or hop limit", pkt[6], pkt[7])
+	}
+	if !net.IP(pkt[8:24]).Equal(src) || !net.IP(pkt[24:40]).Equal(dst) {
+		t.Fatal("unexpected addresses")
+	}
+	if !bytes.Equal(pkt[40:], payload) {
+		t.Fatal("unexpected payload")
+	}
+
+	if err := bytebuffers.WriteIPv6Header(buf, net.ParseIP("192.168.1.1"), dst, bytebuffers.IPv6NextHeaderUDP, nil); !errors.Is(err, bytebuffers.ErrIPv6InvalidAddress) {
+		t.Fatal("expected invalid address, got", err)
+	}
+}
@@ -0,0 +1,123 @@
+package bytebuffers
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+)
+
+const (
+	ircMaxMessageLen = 512
+)
+
+var (
+	ErrMessageTooLong = errors.New("bytebuffers.IRC: message too long")
+	ErrIRCInvalid     = errors.New("bytebuffers.IRC: invalid message")
+)
+
+// WriteIRCMessage
+// 写入 IRC 消息：[:prefix] command [params...] [:trailing]\r\n。
+//
+// 含 \r\n 超过 512 字节时返回 ErrMessageTooLong。params 不能为空、不能含空格或以冒号开头。
+func WriteIRCMessage(buf Buffer, prefix, command string, params []string, trailing string) (err error) {
+	if command == "" || strings.ContainsAny(command, " :\r\n\x00") || strings.ContainsAny(prefix, " \r\n\x00") || strings.ContainsAny(trailing, "\r\n\x00") {
+		err = ErrIRCInvalid
+		return
+	}
+	size := len(command) + 2
+	if prefix != "" {
+		size += 1 + len(prefix) + 1
+	}
+	for _, param := range params {
+		if param == "" || param[0] == ':' || strings.ContainsAny(param, " \r\n\x00") {
+			err = ErrIRCInvalid
+			return
+		}
+		size += 1 + len(param)
+	}
+	if trailing != "" {
+		size += 2 + len(trailing)
+	}
+	if size > ircMaxMessageLen {
+		err = ErrMessageTooLong
+		return
+	}
+	p, borrowErr := buf.Borrow(size)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	p = p[:0]
+	if prefix != "" {
+		p = append(p, ':')
+		p = append(p, prefix...)
+		p = append(p, ' ')
+	}
+	p = append(p, command...)
+	for _, param := range params {
+		p = append(p, ' ')
+		p = append(p, param...)
+	}
+	if trailing != "" {
+		p = append(p, " :"...)
+		p = append(p, trailing...)
+	}
+	p = append(p, '\r', '\n')
+	buf.Return(size)
+	return
+}
+
+// ReadIRCMessage
+// 读取一条以 \r\n 结尾的 IRC 消息。
+//
+// 超过 512 字节时返回 ErrMessageTooLong，不完整时返回 io.ErrUnexpectedEOF，均不读掉。
+func ReadIRCMessage(buf Buffer) (prefix, command string, params []string, trailing string, err error) {
+	line, n, lineErr := peekCRLFLine(buf)
+	if lineErr != nil {
+		if errors.Is(lineErr, io.ErrUnexpectedEOF) && buf.Len() >= ircMaxMessageLen {
+			lineErr = ErrMessageTooLong
+		}
+		err = lineErr
+		return
+	}
+	if n > ircMaxMessageLen {
+		err = ErrMessageTooLong
+		return
+	}
+	if len(line) > 0 && line[0] == ':' {
+		i := bytes.IndexByte(line, ' ')
+		if i < 0 {
+			err = ErrIRCInvalid
+			return
+		}
+		prefix, line = string(line[1:i]), line[i+1:]
+	}
+	line = bytes.TrimLeft(line, " ")
+	i := bytes.IndexByte(line, ' ')
+	if i < 0 {
+		i = len(line)
+	}
+	if i == 0 {
+		prefix, err = "", ErrIRCInvalid
+		return
+	}
+	command, line = string(line[:i]), line[i:]
+	for {
+		line = bytes.TrimLeft(line, " ")
+		if len(line) == 0 {
+			break
+		}
+		if line[0] == ':' {
+			trailing = string(line[1:])
+			break
+		}
+		if i = bytes.IndexByte(line, ' '); i < 0 {
+			i = len(line)
+		}
+		params = append(params, string(line[:i]))
+		line = line[i:]
+	}
+	buf.Discard(n)
+	return
+}
@@ -0,0 +1,68 @@
+package bytebuffers_test
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestIRCMessage(t *testing.T) {
+	cases := []struct {
+		prefix   string
+		command  string
+		params   []string
+		trailing string
+		encoded  string
+	}{
+		{"nick!user@host", "PRIVMSG", []string{"#go"}, "hello, world", ":nick!user@host PRIVMSG #go :hello, world\r\n"},
+		{"", "JOIN", []string{"#go,#rust", "key"}, "", "JOIN #go,#rust key\r\n"},
+		{"", "NICK", []string{"gopher"}, "", "NICK gopher\r\n"},
+		{"", "PING", nil, "irc.example.net", "PING :irc.example.net\r\n"},
+	}
+	buf := bytebuffers.NewBuffer()
+	for _, c := range cases {
+		if err := bytebuffers.WriteIRCMessage(buf, c.prefix, c.command, c.params, c.trailing); err != nil {
+			t.Fatal(err)
+		}
+		if s := string(buf.Peek(buf.Len())); s != c.encoded {
+			t.Fatalf("expected %q, got %q", c.encoded, s)
+		}
+		prefix, command, params, trailing, err := bytebuffers.ReadIRCMessage(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if prefix != c.prefix || command != c.command || !slices.Equal(params, c.params) || trailing != c.trailing {
+			t.Fatal("unexpected message", prefix, command, params, trailing)
+		}
+	}
+}
+
+func TestIRCMessageTooLong(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	// "PRIVMSG #go :" + 498 bytes + "\r\n" = 513 bytes
+	text := strings.Repeat("a", 498)
+	if err := bytebuffers.WriteIRCMessage(buf, "", "PRIVMSG", []string{"#go"}, text); !errors.Is(err, bytebuffers.ErrMessageTooLong) {
+		t.Fatal("expected message too long, got", err)
+	}
+	if err := bytebuffers.WriteIRCMessage(buf, "", "PRIVMSG", []string{"#go"}, text[1:]); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 512 {
+		t.Fatal("unexpected length", buf.Len())
+	}
+	buf.Reset()
+
+	_, _ = buf.WriteString("PRIVMSG #go :" + text + "\r\n")
+	if _, _, _, _, err := bytebuffers.ReadIRCMessage(buf); !errors.Is(err, bytebuffers.ErrMessageTooLong) {
+		t.Fatal("expected message too long, got", err)
+	}
+	buf.Reset()
+	_, _ = buf.WriteString("PING :irc")
+	if _, _, _, _, err := bytebuffers.ReadIRCMessage(buf); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal("expected unexpected EOF, got", err)
+	}
+}
@@ -0,0 +1,157 @@
+package bytebuffers
+
+import (
+	"errors"
+)
+
+const (
+	ISOTPSingleFrame      = 0x0
+	ISOTPFirstFrame       = 0x1
+	ISOTPConsecutiveFrame = 0x2
+	ISOTPFlowControl      = 0x3
+
+	isotpFrameLen   = 8
+	isotpMaxSingle  = 7
+	isotpFirstData  = 6
+	isotpMaxLength  = 4095
+	isotpPadding    = 0xCC
+	isotpMaxSeqNum  = 0x0F
+	isotpConsecData = 7
+)
+
+var (
+	ErrISOTPInvalid = errors.New("bytebuffers.ISOTP: invalid frame")
+)
+
+// WriteISOTPFrame
+// 将 data 分段为 ISO-TP 帧并以 SocketCAN can_frame 写入，CAN id 为 address（超过 11 位时为扩展帧）。
+//
+// 不超过 7 字节时为单帧，否则为首帧加连续帧，序号从 1 开始循环。不处理流控。
+func WriteISOTPFrame(buf Buffer, address uint32, data []byte) (err error) {
+	if len(data) > isotpMaxLength {
+		err = ErrTooLarge
+		return
+	}
+	extended := address > CANSFFMask
+	frame := Acquire()
+	defer Release(frame)
+	if len(data) <= isotpMaxSingle {
+		if err = WriteISOTPSingleFrame(frame, data); err != nil {
+			return
+		}
+		err = WriteCANFrame(buf, address, extended, false, frame.Peek(isotpFrameLen))
+		return
+	}
+	if err = WriteISOTPFirstFrame(frame, len(data), data[:isotpFirstData]); err != nil {
+		return
+	}
+	if err = WriteCANFrame(buf, address, extended, false, frame.Peek(isotpFrameLen)); err != nil {
+		return
+	}
+	frame.Reset()
+	seqNum := byte(1)
+	for rest := data[isotpFirstData:]; len(rest) > 0; seqNum = (seqNum + 1) & isotpMaxSeqNum {
+		chunk := rest[:min(len(rest), isotpConsecData)]
+		rest = rest[len(chunk):]
+		if err = WriteISOTPConsecutiveFrame(frame, seqNum, chunk); err != nil {
+			return
+		}
+		if err = WriteCANFrame(buf, address, extended, false, frame.Peek(isotpFrameLen)); err != nil {
+			return
+		}
+		frame.Reset()
+	}
+	return
+}
+
+// WriteISOTPSingleFrame
+// 写入 ISO-TP 单帧：PCI（高 4 位 0x0，低 4 位长度）与 1 至 7 字节数据，填充至 8 字节。
+func WriteISOTPSingleFrame(buf Buffer, data []byte) (err error) {
+	if len(data) == 0 || len(data) > isotpMaxSingle {
+		err = ErrISOTPInvalid
+		return
+	}
+	err = writeISOTP(buf, []byte{ISOTPSingleFrame<<4 | byte(len(data))}, data)
+	return
+}
+
+// WriteISOTPFirstFrame
+// 写入 ISO-TP 首帧：PCI（高 4 位 0x1，随后 12 位总长度）与前 6 字节数据。
+//
+// totalLen 须在 8 至 4095 之间，data 须为 6 字节。
+func WriteISOTPFirstFrame(buf Buffer, totalLen int, data []byte) (err error) {
+	if totalLen <= isotpMaxSingle || totalLen > isotpMaxLength || len(data) != isotpFirstData {
+		err = ErrISOTPInvalid
+		return
+	}
+	err = writeISOTP(buf, []byte{ISOTPFirstFrame<<4 | byte(totalLen>>8), byte(totalLen)}, data)
+	return
+}
+
+// WriteISOTPConsecutiveFrame
+// 写入 ISO-TP 连续帧：PCI（高 4 位 0x2，低 4 位序号）与 1 至 7 字节数据，填充至 8 字节。
+func WriteISOTPConsecutiveFrame(buf Buffer, seqNum byte, data []byte) (err error) {
+	if seqNum > isotpMaxSeqNum || len(data) == 0 || len(data) > isotpConsecData {
+		err = ErrISOTPInvalid
+		return
+	}
+	err = writeISOTP(buf, []byte{ISOTPConsecutiveFrame<<4 | seqNum}, data)
+	return
+}
+
+// ReadISOTPFrame
+// 读取一个 8 字节的 ISO-TP 帧，frameType 为 PCI 的类型。
+//
+// 单帧的 value 为数据长度；首帧为总长度；连续帧为序号，data 含末尾的填充；流控帧为流状态，data 为块大小与 STmin。
+func ReadISOTPFrame(buf Buffer) (frameType byte, value int, data []byte, err error) {
+	p, peekErr := peekFull(buf, isotpFrameLen)
+	if peekErr != nil {
+		err = peekErr
+		return
+	}
+	var payload []byte
+	switch frameType = p[0] >> 4; frameType {
+	case ISOTPSingleFrame:
+		if value = int(p[0] & 0x0F); value == 0 || value > isotpMaxSingle {
+			frameType, value, err = 0, 0, ErrISOTPInvalid
+			return
+		}
+		payload = p[1 : 1+value]
+	case ISOTPFirstFrame:
+		if value = int(p[0]&0x0F)<<8 | int(p[1]); value <= isotpMaxSingle {
+			frameType, value, err = 0, 0, ErrISOTPInvalid
+			return
+		}
+		payload = p[2:isotpFrameLen]
+	case ISOTPConsecutiveFrame:
+		value = int(p[0] & 0x0F)
+		payload = p[1:isotpFrameLen]
+	case ISOTPFlowControl:
+		value = int(p[0] & 0x0F)
+		payload = p[1:3]
+	default:
+		frameType, err = 0, ErrISOTPInvalid
+		return
+	}
+	data = make([]byte, len(payload))
+	copy(data, payload)
+	buf.Discard(isotpFrameLen)
+	return
+}
+
+// writeISOTP
+// 写入 PCI 与数据，并填充至 8 字节。
+func writeISOTP(buf Buffer, pci []byte, data []byte) (err error) {
+	p, borrowErr := buf.Borrow(isotpFrameLen)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	n := copy(p, pci)
+	n += copy(p[n:isotpFrameLen], data)
+	for ; n < isotpFrameLen; n++ {
+		p[n] = isotpPadding
+	}
+	buf.Return(isotpFrameLen)
+	return
+}
@@ -0,0 +1,103 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestISOTPSingleFrame(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	// UDS ReadDataByIdentifier VIN
+	if err := bytebuffers.WriteISOTPSingleFrame(buf, []byte{0x22, 0xF1, 0x90}); err != nil {
+		t.Fatal(err)
+	}
+	expected := []byte{0x03, 0x22, 0xF1, 0x90, 0xCC, 0xCC, 0xCC, 0xCC}
+	if p := buf.CloneBytes(); !bytes.Equal(p, expected) {
+		t.Fatal("unexpected frame", p)
+	}
+	frameType, size, data, err := bytebuffers.ReadISOTPFrame(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if frameType != bytebuffers.ISOTPSingleFrame || size != 3 || !bytes.Equal(data, expected[1:4]) {
+		t.Fatal("unexpected frame", frameType, size, data)
+	}
+
+	if err = bytebuffers.WriteISOTPSingleFrame(buf, make([]byte, 8)); !errors.Is(err, bytebuffers.ErrISOTPInvalid) {
+		t.Fatal("expected invalid, got", err)
+	}
+}
+
+func TestISOTPMultiFrame(t *testing.T) {
+	payload := make([]byte, 20)
+	for i := range payload {
+		payload[i] = byte(i + 1)
+	}
+	buf := bytebuffers.NewBuffer()
+	if err := bytebuffers.WriteISOTPFrame(buf, 0x7E8, payload); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 3*16 {
+		t.Fatal("expected first frame and two consecutive frames, got", buf.Len())
+	}
+
+	frames := bytebuffers.NewBuffer()
+	for buf.Len() > 0 {
+		id, extended, _, data, err := bytebuffers.ReadCANFrame(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id != 0x7E8 || extended || len(data) != 8 {
+			t.Fatal("unexpected can frame", id, extended, data)
+		}
+		_, _ = frames.Write(data)
+	}
+
+	frameType, total, data, err := bytebuffers.ReadISOTPFrame(frames)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if frameType != bytebuffers.ISOTPFirstFrame || total != len(payload) {
+		t.Fatal("unexpected first frame", frameType, total)
+	}
+	reassembled := data
+	for want := 1; len(reassembled) < total; want++ {
+		var seqNum int
+		if frameType, seqNum, data, err = bytebuffers.ReadISOTPFrame(frames); err != nil {
+			t.Fatal(err)
+		}
+		if frameType != bytebuffers.ISOTPConsecutiveFrame || seqNum != want {
+			t.Fatal("unexpected consecutive frame", frameType, seqNum)
+		}
+		reassembled = append(reassembled, data...)
+	}
+	if !bytes.Equal(reassembled, payload) {
+		t.Fatal("unexpected payload", reassembled)
+	}
+}
+
+func TestWriteISOTPFirstFrame(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	if err := bytebuffers.WriteISOTPFirstFrame(buf, 0x123, []byte{1, 2, 3, 4, 5, 6}); err != nil {
+		t.Fatal(err)
+	}
+	if p := buf.CloneBytes(); !bytes.Equal(p, []byte{0x11, 0x23, 1, 2, 3, 4, 5, 6}) {
+		t.Fatal("unexpected frame", p)
+	}
+	if err := bytebuffers.WriteISOTPFirstFrame(buf, 7, []byte{1, 2, 3, 4, 5, 6}); !errors.Is(err, bytebuffers.ErrISOTPInvalid) {
+		t.Fatal("expected invalid, got", err)
+	}
+	buf.Reset()
+	if err := bytebuffers.WriteISOTPConsecutiveFrame(buf, 2, []byte{1, 2}); err != nil {
+		t.Fatal(err)
+	}
+	if p := buf.CloneBytes(); !bytes.Equal(p, []byte{0x22, 1, 2, 0xCC, 0xCC, 0xCC, 0xCC, 0xCC}) {
+		t.Fatal("unexpected frame", p)
+	}
+	if err := bytebuffers.WriteISOTPConsecutiveFrame(buf, 16, []byte{1}); !errors.Is(err, bytebuffers.ErrISOTPInvalid) {
+		t.Fatal("expected invalid, got", err)
+	}
+}
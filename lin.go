@@ -0,0 +1,103 @@
+package bytebuffers
+
+import (
+	"errors"
+	"io"
+)
+
+const (
+	linBreak       = 0x00
+	linSync        = 0x55
+	linMaxID       = 0x3F
+	linMaxData     = 8
+	linDiagRequest = 0x3C
+	linDiagReply   = 0x3D
+)
+
+var (
+	ErrLINInvalid  = errors.New("bytebuffers.LIN: invalid frame")
+	ErrLINParity   = errors.New("bytebuffers.LIN: parity mismatch")
+	ErrLINChecksum = errors.New("bytebuffers.LIN: checksum mismatch")
+)
+
+// WriteLINFrame
+// 写入 LIN 帧：间隔场（以 0x00 表示）、同步字节 0x55、受保护 ID、1 至 8 字节数据与校验和。
+//
+// 校验和为 LIN 2.x 的增强型（受保护 ID 与数据的带进位和取反），诊断帧 0x3C、0x3D 为经典型（仅数据）。
+func WriteLINFrame(buf Buffer, id byte, data []byte) (err error) {
+	if id > linMaxID || len(data) == 0 || len(data) > linMaxData {
+		err = ErrLINInvalid
+		return
+	}
+	size := 3 + len(data) + 1
+	p, borrowErr := buf.Borrow(size)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	pid := linProtectedID(id)
+	p[0], p[1], p[2] = linBreak, linSync, pid
+	copy(p[3:], data)
+	p[size-1] = linChecksum(pid, data)
+	buf.Return(size)
+	return
+}
+
+// ReadLINFrame
+// 将缓冲中的全部内容解析为一个 LIN 帧，校验同步字节、受保护 ID 的奇偶位与校验和。
+//
+// 失败时不读掉。
+func ReadLINFrame(buf Buffer) (id byte, data []byte, err error) {
+	p := buf.Peek(buf.Len())
+	if len(p) == 0 {
+		err = io.EOF
+		return
+	}
+	if len(p) < 5 {
+		err = io.ErrUnexpectedEOF
+		return
+	}
+	if p[0] != linBreak || p[1] != linSync || len(p) > 4+linMaxData {
+		err = ErrLINInvalid
+		return
+	}
+	pid := p[2]
+	if linProtectedID(pid&linMaxID) != pid {
+		err = ErrLINParity
+		return
+	}
+	end := len(p) - 1
+	if linChecksum(pid, p[3:end]) != p[end] {
+		err = ErrLINChecksum
+		return
+	}
+	id = pid & linMaxID
+	data = make([]byte, end-3)
+	copy(data, p[3:end])
+	buf.Discard(len(p))
+	return
+}
+
+// linProtectedID
+// 受保护 ID：P0 = ID0^ID1^ID2^ID4，P1 = ¬(ID1^ID3^ID4^ID5)。
+func linProtectedID(id byte) byte {
+	bit := func(n uint) byte { return (id >> n) & 1 }
+	p0 := bit(0) ^ bit(1) ^ bit(2) ^ bit(4)
+	p1 := ^(bit(1) ^ bit(3) ^ bit(4) ^ bit(5)) & 1
+	return id | p0<<6 | p1<<7
+}
+
+// linChecksum
+// 带进位的和（超过 255 时减去 255）取反。
+func linChecksum(pid byte, data []byte) byte {
+	sum := uint16(0)
+	if id := pid & linMaxID; id != linDiagRequest && id != linDiagReply {
+		sum = uint16(pid)
+	}
+	for _, b := range data {
+		if sum += uint16(b); sum > 0xFF {
+			sum -= 0xFF
+		}
+	}
+	return ^byte(sum)
+}
@@ -0,0 +1,61 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestWriteLINFrame(t *testing.T) {
+	cases := []struct {
+		id      byte
+		data    []byte
+		encoded []byte
+	}{
+		// enhanced checksum: 0x50+0x55+0x93+0xE5 with carry is 0x1F, inverted 0xE0
+		{0x10, []byte{0x55, 0x93, 0xE5}, []byte{0x00, 0x55, 0x50, 0x55, 0x93, 0xE5, 0xE0}},
+		{0x01, []byte{0x00}, []byte{0x00, 0x55, 0xC1, 0x00, 0x3E}},
+		{0x23, []byte{0xFF, 0xFF}, []byte{0x00, 0x55, 0xA3, 0xFF, 0xFF, 0x5C}},
+		// diagnostic master request uses the classic checksum (LIN 2.1 2.8.3 example bytes)
+		{0x3C, []byte{0x4A, 0x55, 0x93, 0xE5}, []byte{0x00, 0x55, 0x3C, 0x4A, 0x55, 0x93, 0xE5, 0xE6}},
+	}
+	buf := bytebuffers.NewBuffer()
+	for _, c := range cases {
+		if err := bytebuffers.WriteLINFrame(buf, c.id, c.data); err != nil {
+			t.Fatal(err)
+		}
+		if p := buf.CloneBytes(); !bytes.Equal(p, c.encoded) {
+			t.Fatalf("unexpected frame for id %#x: % x", c.id, p)
+		}
+		id, data, err := bytebuffers.ReadLINFrame(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id != c.id || !bytes.Equal(data, c.data) || buf.Len() != 0 {
+			t.Fatal("unexpected frame", id, data)
+		}
+	}
+}
+
+func TestReadLINFrame(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	_, _ = buf.Write([]byte{0x00, 0x55, 0x10, 0x55, 0x93, 0xE5, 0xE0})
+	if _, _, err := bytebuffers.ReadLINFrame(buf); !errors.Is(err, bytebuffers.ErrLINParity) {
+		t.Fatal("expected parity mismatch, got", err)
+	}
+	buf.Reset()
+	_, _ = buf.Write([]byte{0x00, 0x55, 0x50, 0x55, 0x93, 0xE5, 0xE1})
+	if _, _, err := bytebuffers.ReadLINFrame(buf); !errors.Is(err, bytebuffers.ErrLINChecksum) {
+		t.Fatal("expected checksum mismatch, got", err)
+	}
+	buf.Reset()
+	_, _ = buf.Write([]byte{0x00, 0xAA, 0x50, 0x55, 0xE0})
+	if _, _, err := bytebuffers.ReadLINFrame(buf); !errors.Is(err, bytebuffers.ErrLINInvalid) {
+		t.Fatal("expected invalid, got", err)
+	}
+	if err := bytebuffers.WriteLINFrame(buf, 0x40, []byte{1}); !errors.Is(err, bytebuffers.ErrLINInvalid) {
+		t.Fatal("expected invalid, got", err)
+	}
+}
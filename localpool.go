@@ -0,0 +1,101 @@
+package bytebuffers
+
+import (
+	"runtime"
+	"sync/atomic"
+	"unsafe"
+)
+
+//go:linkname runtime_procPin runtime.procPin
+func runtime_procPin() int
+
+//go:linkname runtime_procUnpin runtime.procUnpin
+func runtime_procUnpin()
+
+var defaultLocalPool = newLocalPool(&defaultBufferPool)
+
+// localPoolSlot
+// 每个 P 一个缓存位，填充至 cache line 以避免伪共享。
+type localPoolSlot struct {
+	p unsafe.Pointer
+	_ [64 - unsafe.Sizeof(unsafe.Pointer(nil))]byte
+}
+
+// localPool
+// 每个 P 缓存一个 Buffer 的本地池，缓存位被占用或为空时回退到 BufferPool。
+//
+// 缓存位按 procPin 得到的 P 选取，读写使用原子操作，因为每次 GC 之后会在 finalizer 中清空全部缓存位，
+// 与 sync.Pool 一样不会长期持有 Buffer。存入缓存位前同样经过 BufferPool 的校准，超过校准后最大容量的 Buffer 被丢弃。
+// 缓存位数量为创建时的 GOMAXPROCS，之后 GOMAXPROCS 变大时多出的 P 直接使用 BufferPool。
+// 开启 race 检测时不使用缓存位。
+type localPool struct {
+	slots []localPoolSlot
+	pool  *BufferPool
+}
+
+func newLocalPool(pool *BufferPool) *localPool {
+	p := &localPool{
+		slots: make([]localPoolSlot, runtime.GOMAXPROCS(0)),
+		pool:  pool,
+	}
+	if !raceEnabled {
+		p.registerCleanup()
+	}
+	return p
+}
+
+// localPoolCleanup
+// 不可达后由 GC 触发 finalizer，用作每次 GC 的钩子。
+type localPoolCleanup struct {
+	p *localPool
+}
+
+// registerCleanup
+// 在下一次 GC 之后清空缓存位，并为再下一次 GC 重新注册。
+func (p *localPool) registerCleanup() {
+	runtime.SetFinalizer(&localPoolCleanup{p}, func(c *localPoolCleanup) {
+		for i := range c.p.slots {
+			atomic.StorePointer(&c.p.slots[i].p, nil)
+		}
+		c.p.registerCleanup()
+	})
+}
+
+func (p *localPool) Acquire() Buffer {
+	if raceEnabled {
+		return p.pool.Acquire()
+	}
+	var ptr unsafe.Pointer
+	pid := runtime_procPin()
+	if pid < len(p.slots) {
+		ptr = atomic.SwapPointer(&p.slots[pid].p, nil)
+	}
+	runtime_procUnpin()
+	if ptr != nil {
+		return (*buffer)(ptr)
+	}
+	return p.pool.Acquire()
+}
+
+func (p *localPool) Release(b Buffer) {
+	if b == nil {
+		return
+	}
+	local, ok := b.(*buffer)
+	if raceEnabled || !ok || local.fixed {
+		p.pool.Release(b)
+		return
+	}
+	if !local.Reset() || !p.pool.admit(local.Capacity()) {
+		return
+	}
+	stored := false
+	pid := runtime_procPin()
+	if pid < len(p.slots) {
+		stored = atomic.CompareAndSwapPointer(&p.slots[pid].p, nil, unsafe.Pointer(local))
+	}
+	runtime_procUnpin()
+	if !stored {
+		p.pool.pool.Put(b)
+	}
+}
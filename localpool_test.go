@@ -0,0 +1,82 @@
+package bytebuffers_test
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestLocalPool(t *testing.T) {
+	wg := new(sync.WaitGroup)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				b := bytebuffers.Acquire()
+				if b.Len() != 0 {
+					t.Error("acquired buffer must be empty", b.Len())
+					return
+				}
+				_, _ = b.Write([]byte("hello world"))
+				b.Discard(b.Len())
+				bytebuffers.Release(b)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// a borrowing buffer must not be cached
+	b := bytebuffers.Acquire()
+	if _, err := b.Borrow(8); err != nil {
+		t.Fatal(err)
+	}
+	bytebuffers.Release(b)
+	if next := bytebuffers.Acquire(); next == b {
+		t.Fatal("borrowing buffer must be dropped")
+	}
+}
+
+func TestLocalPool_GC(t *testing.T) {
+	b := bytebuffers.Acquire()
+	_, _ = b.Write([]byte("hello world"))
+	bytebuffers.Release(b)
+	// the cached buffer is dropped after a GC, the cleanup runs as a finalizer
+	for i := 0; i < 4; i++ {
+		runtime.GC()
+		time.Sleep(time.Millisecond)
+	}
+	if next := bytebuffers.Acquire(); next == b {
+		t.Fatal("cached buffer must be dropped after GC")
+	}
+}
+
+func BenchmarkLocalPool(b *testing.B) {
+	p := []byte("hello world")
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			buf := bytebuffers.Acquire()
+			_, _ = buf.Write(p)
+			buf.Discard(len(p))
+			bytebuffers.Release(buf)
+		}
+	})
+}
+
+func BenchmarkBufferPool(b *testing.B) {
+	pool := bytebuffers.Pool(64)
+	p := []byte("hello world")
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			buf := pool.Acquire()
+			_, _ = buf.Write(p)
+			buf.Discard(len(p))
+			pool.Release(buf)
+		}
+	})
+}
@@ -0,0 +1,96 @@
+package bytebuffers
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"sync"
+
+	"github.com/pierrec/lz4/v4"
+)
+
+var (
+	ErrLZ4Invalid = errors.New("bytebuffers.LZ4: invalid block")
+)
+
+var lz4Compressors = sync.Pool{
+	New: func() any {
+		return new(lz4.Compressor)
+	},
+}
+
+// CompressLZ4
+// 以 LZ4 块格式压缩可读内容并替换之。
+//
+// 压缩结果以 4 字节小端的原始长度作为前缀。
+func CompressLZ4(buf Buffer) (err error) {
+	src := buf.Peek(buf.Len())
+	if uint64(len(src)) > math.MaxUint32 {
+		err = ErrTooLarge
+		return
+	}
+	dst := make([]byte, 4+lz4.CompressBlockBound(len(src)))
+	binary.LittleEndian.PutUint32(dst, uint32(len(src)))
+
+	c := lz4Compressors.Get().(*lz4.Compressor)
+	n, cErr := c.CompressBlock(src, dst[4:])
+	lz4Compressors.Put(c)
+	if cErr != nil {
+		err = cErr
+		return
+	}
+	if n == 0 && len(src) > 0 { // incompressible then write literals only
+		n = lz4LiteralBlock(dst[4:], src)
+	}
+	err = buf.Set(dst[:4+n])
+	return
+}
+
+// DecompressLZ4
+// 解压由 CompressLZ4 压缩的可读内容并替换之。
+func DecompressLZ4(buf Buffer) (err error) {
+	src := buf.Peek(buf.Len())
+	if len(src) < 4 {
+		err = ErrLZ4Invalid
+		return
+	}
+	size := binary.LittleEndian.Uint32(src)
+	src = src[4:]
+	if uint64(size) > uint64(len(src))*255 { // lz4 cannot exceed 255:1
+		err = ErrLZ4Invalid
+		return
+	}
+	dst := make([]byte, size)
+	if size > 0 {
+		n, dErr := lz4.UncompressBlock(src, dst)
+		if dErr != nil || n != int(size) {
+			err = ErrLZ4Invalid
+			return
+		}
+	}
+	err = buf.Set(dst)
+	return
+}
+
+// lz4LiteralBlock
+// 写入只含字面量的 LZ4 块。
+func lz4LiteralBlock(dst []byte, src []byte) int {
+	n := len(src)
+	i := 1
+	if n < 15 {
+		dst[0] = byte(n << 4)
+	} else {
+		dst[0] = 0xF0
+		for l := n - 15; ; l -= 255 {
+			if l < 255 {
+				dst[i] = byte(l)
+				i++
+				break
+			}
+			dst[i] = 255
+			i++
+		}
+	}
+	i += copy(dst[i:], src)
+	return i
+}
@@ -0,0 +1,53 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestCompressLZ4(t *testing.T) {
+	random := make([]byte, 4096)
+	_, _ = rand.Read(random)
+	cases := [][]byte{
+		nil,
+		[]byte("a"),
+		bytes.Repeat([]byte("0123456789"), 1024),
+		random,
+	}
+	for _, p := range cases {
+		buf := bytebuffers.NewBuffer()
+		_, _ = buf.Write(p)
+		if err := bytebuffers.CompressLZ4(buf); err != nil {
+			t.Fatal(err)
+		}
+		t.Log(len(p), "->", buf.Len())
+		if err := bytebuffers.DecompressLZ4(buf); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(buf.CloneBytes(), p) {
+			t.Fatal("round trip mismatch", len(p))
+		}
+	}
+}
+
+func TestDecompressLZ4(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	_, _ = buf.Write(bytes.Repeat([]byte("0123456789"), 100))
+	_ = bytebuffers.CompressLZ4(buf)
+	corrupted := buf.CloneBytes()
+	corrupted[len(corrupted)/2] ^= 0xFF
+	corrupted = corrupted[:len(corrupted)-2]
+	_ = buf.Set(corrupted)
+	if err := bytebuffers.DecompressLZ4(buf); !errors.Is(err, bytebuffers.ErrLZ4Invalid) {
+		t.Fatal("expected invalid, got", err)
+	}
+
+	_ = buf.Set([]byte{1, 2})
+	if err := bytebuffers.DecompressLZ4(buf); !errors.Is(err, bytebuffers.ErrLZ4Invalid) {
+		t.Fatal("expected invalid, got", err)
+	}
+}
@@ -0,0 +1,91 @@
+package bytebuffers
+
+import (
+	"errors"
+)
+
+var (
+	ErrMemcachedInvalid = errors.New("bytebuffers.Memcached: invalid command")
+)
+
+// WriteMemcachedCommand
+// 写入 Memcached 文本协议命令：cmd 与 args 以空格分隔，以 \r\n 结尾。
+//
+// cmd 为空或 cmd、args 中含空白、控制字符时返回 ErrMemcachedInvalid。
+func WriteMemcachedCommand(buf Buffer, cmd string, args ...string) (err error) {
+	if !isMemcachedToken(cmd) {
+		err = ErrMemcachedInvalid
+		return
+	}
+	size := len(cmd) + 2
+	for _, arg := range args {
+		if !isMemcachedToken(arg) {
+			err = ErrMemcachedInvalid
+			return
+		}
+		if len(arg) > maxInt-size-1 {
+			err = ErrTooLarge
+			return
+		}
+		size += len(arg) + 1
+	}
+	p, borrowErr := buf.Borrow(size)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	n := copy(p, cmd)
+	for _, arg := range args {
+		p[n] = ' '
+		n += 1 + copy(p[n+1:], arg)
+	}
+	p[n], p[n+1] = '\r', '\n'
+	buf.Return(size)
+	return
+}
+
+// WriteMemcachedData
+// 写入 Memcached 数据块（如 set 的值），以 \r\n 结尾。
+func WriteMemcachedData(buf Buffer, data []byte) (err error) {
+	if len(data) > maxInt-2 {
+		err = ErrTooLarge
+		return
+	}
+	size := len(data) + 2
+	p, borrowErr := buf.Borrow(size)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	n := copy(p, data)
+	p[n], p[n+1] = '\r', '\n'
+	buf.Return(size)
+	return
+}
+
+// ReadMemcachedResponse
+// 读取一行 Memcached 响应（不含 \r\n），如 "STORED"、"END"、"VALUE key flags bytes"。
+//
+// 没有 \r\n 时返回 io.ErrUnexpectedEOF 且不读掉。
+func ReadMemcachedResponse(buf Buffer) (line string, err error) {
+	p, n, lineErr := peekCRLFLine(buf)
+	if lineErr != nil {
+		err = lineErr
+		return
+	}
+	line = string(p)
+	buf.Discard(n)
+	return
+}
+
+func isMemcachedToken(s string) bool {
+	if len(s) == 0 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c <= ' ' || c == 0x7F {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,72 @@
+package bytebuffers_test
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestWriteMemcachedCommand(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	if err := bytebuffers.WriteMemcachedCommand(buf, "get", "foo", "bar"); err != nil {
+		t.Fatal(err)
+	}
+	if s := string(buf.Peek(buf.Len())); s != "get foo bar\r\n" {
+		t.Fatalf("unexpected command %q", s)
+	}
+	buf.Reset()
+
+	value := []byte("hello")
+	if err := bytebuffers.WriteMemcachedCommand(buf, "set", "foo", "0", "3600", strconv.Itoa(len(value))); err != nil {
+		t.Fatal(err)
+	}
+	if err := bytebuffers.WriteMemcachedData(buf, value); err != nil {
+		t.Fatal(err)
+	}
+	if s := string(buf.Peek(buf.Len())); s != "set foo 0 3600 5\r\nhello\r\n" {
+		t.Fatalf("unexpected command %q", s)
+	}
+	buf.Reset()
+
+	if err := bytebuffers.WriteMemcachedCommand(buf, "get", "foo bar"); !errors.Is(err, bytebuffers.ErrMemcachedInvalid) {
+		t.Fatal("expected invalid, got", err)
+	}
+	if err := bytebuffers.WriteMemcachedCommand(buf, ""); !errors.Is(err, bytebuffers.ErrMemcachedInvalid) {
+		t.Fatal("expected invalid, got", err)
+	}
+}
+
+func TestReadMemcachedResponse(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	_, _ = buf.WriteString("VALUE foo 0 5\r\nhello\r\nEND\r\nSERVER_ERROR out of memory\r\nSTO")
+
+	line, err := bytebuffers.ReadMemcachedResponse(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields := strings.Fields(line)
+	if len(fields) != 4 || fields[0] != "VALUE" || fields[1] != "foo" {
+		t.Fatal("unexpected response", line)
+	}
+	size, _ := strconv.Atoi(fields[3])
+	data, _ := buf.Next(size + 2)
+	if string(data) != "hello\r\n" {
+		t.Fatalf("unexpected data %q", data)
+	}
+	if line, err = bytebuffers.ReadMemcachedResponse(buf); err != nil || line != "END" {
+		t.Fatal("unexpected response", line, err)
+	}
+	if line, err = bytebuffers.ReadMemcachedResponse(buf); err != nil || line != "SERVER_ERROR out of memory" {
+		t.Fatal("unexpected response", line, err)
+	}
+	if _, err = bytebuffers.ReadMemcachedResponse(buf); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal("expected unexpected EOF, got", err)
+	}
+	if buf.Len() != 3 {
+		t.Fatal("incomplete response must not be discarded")
+	}
+}
@@ -0,0 +1,175 @@
+package bytebuffers
+
+import (
+	"bytes"
+	"errors"
+	"io"
+)
+
+const (
+	MIDINoteOff            = 0x80
+	MIDINoteOn             = 0x90
+	MIDIPolyphonicPressure = 0xA0
+	MIDIControlChange      = 0xB0
+	MIDIProgramChange      = 0xC0
+	MIDIChannelPressure    = 0xD0
+	MIDIPitchBend          = 0xE0
+	MIDISysExStart         = 0xF0
+	MIDISysExEnd           = 0xF7
+)
+
+var (
+	ErrMIDIInvalid       = errors.New("bytebuffers.MIDI: invalid message")
+	ErrMIDIRunningStatus = errors.New("bytebuffers.MIDI: running status is not supported")
+)
+
+// MIDIMessage
+// MIDI 消息，SysEx 为系统独占消息 0xF0 与 0xF7 之间的内容。
+type MIDIMessage struct {
+	Status byte
+	Data1  byte
+	Data2  byte
+	SysEx  []byte
+}
+
+// Type
+// 通道消息的类型（状态字节的高 4 位），系统消息返回状态字节本身。
+func (msg MIDIMessage) Type() byte {
+	if msg.Status >= MIDISysExStart {
+		return msg.Status
+	}
+	return msg.Status & 0xF0
+}
+
+// Channel
+// 通道消息的通道（0 至 15）。
+func (msg MIDIMessage) Channel() byte {
+	return msg.Status & 0x0F
+}
+
+// WriteMIDIMessage
+// 写入 MIDI 通道消息，Program Change 与 Channel Pressure 只有 data1，其余为 3 字节。
+//
+// 总是写入状态字节，不做 running status 省略。
+func WriteMIDIMessage(buf Buffer, status, data1, data2 byte) (err error) {
+	if status < MIDINoteOff || status >= MIDISysExStart || data1 > 0x7F || data2 > 0x7F {
+		err = ErrMIDIInvalid
+		return
+	}
+	size := midiMessageLen(status)
+	p, borrowErr := buf.Borrow(size)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	p[0], p[1] = status, data1
+	if size == 3 {
+		p[2] = data2
+	}
+	buf.Return(size)
+	return
+}
+
+// WriteMIDISysEx
+// 写入系统独占消息：0xF0、deviceID、data 与 0xF7，deviceID 与 data 须为 7 位数据字节。
+func WriteMIDISysEx(buf Buffer, deviceID byte, data []byte) (err error) {
+	if deviceID > 0x7F {
+		err = ErrMIDIInvalid
+		return
+	}
+	for _, b := range data {
+		if b > 0x7F {
+			err = ErrMIDIInvalid
+			return
+		}
+	}
+	if len(data) > maxInt-3 {
+		err = ErrTooLarge
+		return
+	}
+	size := 3 + len(data)
+	p, borrowErr := buf.Borrow(size)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	p[0], p[1] = MIDISysExStart, deviceID
+	copy(p[2:], data)
+	p[size-1] = MIDISysExEnd
+	buf.Return(size)
+	return
+}
+
+// ReadMIDIMessage
+// 读取一个 MIDI 消息，按状态字节决定数据字节数，系统独占消息读至 0xF7。
+//
+// 以数据字节开头（running status）时返回 ErrMIDIRunningStatus，不完整时不读掉。
+func ReadMIDIMessage(buf Buffer) (msg MIDIMessage, err error) {
+	p := buf.Peek(buf.Len())
+	if len(p) == 0 {
+		err = io.EOF
+		return
+	}
+	status := p[0]
+	if status < 0x80 {
+		err = ErrMIDIRunningStatus
+		return
+	}
+	if status == MIDISysExStart {
+		end := bytes.IndexByte(p, MIDISysExEnd)
+		if end < 0 {
+			err = io.ErrUnexpectedEOF
+			return
+		}
+		for _, b := range p[1:end] {
+			if b > 0x7F {
+				err = ErrMIDIInvalid
+				return
+			}
+		}
+		msg.Status = status
+		msg.SysEx = make([]byte, end-1)
+		copy(msg.SysEx, p[1:end])
+		buf.Discard(end + 1)
+		return
+	}
+	size := midiMessageLen(status)
+	if size == 0 {
+		err = ErrMIDIInvalid
+		return
+	}
+	if len(p) < size {
+		err = io.ErrUnexpectedEOF
+		return
+	}
+	for _, b := range p[1:size] {
+		if b > 0x7F {
+			err = ErrMIDIInvalid
+			return
+		}
+	}
+	msg.Status = status
+	if size > 1 {
+		msg.Data1 = p[1]
+	}
+	if size > 2 {
+		msg.Data2 = p[2]
+	}
+	buf.Discard(size)
+	return
+}
+
+// midiMessageLen
+// 含状态字节的消息长度，未定义的状态返回 0。
+func midiMessageLen(status byte) int {
+	switch {
+	case status < MIDIProgramChange, status >= MIDIPitchBend && status < MIDISysExStart, status == 0xF2:
+		return 3
+	case status < MIDIPitchBend, status == 0xF1, status == 0xF3:
+		return 2
+	case status == 0xF6 || status >= 0xF8:
+		return 1
+	default:
+		return 0
+	}
+}
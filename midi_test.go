@@ -0,0 +1,73 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestMIDIMessage(t *testing.T) {
+	cases := []struct {
+		status, data1, data2 byte
+		encoded              []byte
+	}{
+		{bytebuffers.MIDINoteOn | 0, 60, 100, []byte{0x90, 0x3C, 0x64}},        // middle C on channel 1
+		{bytebuffers.MIDINoteOff | 0, 60, 64, []byte{0x80, 0x3C, 0x40}},        // middle C off
+		{bytebuffers.MIDIControlChange | 1, 64, 127, []byte{0xB1, 0x40, 0x7F}}, // sustain pedal on channel 2
+		{bytebuffers.MIDIProgramChange | 9, 5, 0, []byte{0xC9, 0x05}},
+	}
+	buf := bytebuffers.NewBuffer()
+	for _, c := range cases {
+		if err := bytebuffers.WriteMIDIMessage(buf, c.status, c.data1, c.data2); err != nil {
+			t.Fatal(err)
+		}
+		if p := buf.CloneBytes(); !bytes.Equal(p, c.encoded) {
+			t.Fatal("unexpected message", p)
+		}
+		msg, err := bytebuffers.ReadMIDIMessage(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if msg.Status != c.status || msg.Data1 != c.data1 || (len(c.encoded) == 3 && msg.Data2 != c.data2) || buf.Len() != 0 {
+			t.Fatal("unexpected message", msg)
+		}
+		if msg.Type() != c.status&0xF0 || msg.Channel() != c.status&0x0F {
+			t.Fatal("unexpected type or channel", msg.Type(), msg.Channel())
+		}
+	}
+
+	if err := bytebuffers.WriteMIDIMessage(buf, bytebuffers.MIDINoteOn, 128, 0); !errors.Is(err, bytebuffers.ErrMIDIInvalid) {
+		t.Fatal("expected invalid, got", err)
+	}
+	_, _ = buf.Write([]byte{0x3C, 0x00})
+	if _, err := bytebuffers.ReadMIDIMessage(buf); !errors.Is(err, bytebuffers.ErrMIDIRunningStatus) {
+		t.Fatal("expected running status, got", err)
+	}
+}
+
+func TestWriteMIDISysEx(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	// universal non-realtime identity request: F0 7E 7F 06 01 F7
+	if err := bytebuffers.WriteMIDISysEx(buf, 0x7E, []byte{0x7F, 0x06, 0x01}); err != nil {
+		t.Fatal(err)
+	}
+	expected := []byte{0xF0, 0x7E, 0x7F, 0x06, 0x01, 0xF7}
+	if p := buf.CloneBytes(); !bytes.Equal(p, expected) {
+		t.Fatal("unexpected sysex", p)
+	}
+	msg, err := bytebuffers.ReadMIDIMessage(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Type() != bytebuffers.MIDISysExStart || !bytes.Equal(msg.SysEx, expected[1:5]) {
+		t.Fatal("unexpected sysex", msg)
+	}
+
+	_, _ = buf.Write(expected[:5])
+	if _, err = bytebuffers.ReadMIDIMessage(buf); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal("expected unexpected EOF, got", err)
+	}
+}
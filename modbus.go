@@ -0,0 +1,103 @@
+package bytebuffers
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+const (
+	ModbusReadCoils              = 0x01
+	ModbusReadDiscreteInputs     = 0x02
+	ModbusReadHoldingRegisters   = 0x03
+	ModbusReadInputRegisters     = 0x04
+	ModbusWriteSingleCoil        = 0x05
+	ModbusWriteSingleRegister    = 0x06
+	ModbusWriteMultipleCoils     = 0x0F
+	ModbusWriteMultipleRegisters = 0x10
+
+	modbusRequestLen = 8
+)
+
+var (
+	ErrModbusInvalid   = errors.New("bytebuffers.Modbus: invalid frame")
+	ErrModbusChecksum  = errors.New("bytebuffers.Modbus: checksum mismatch")
+	ErrModbusException = errors.New("bytebuffers.Modbus: exception response")
+)
+
+// modbusCRC
+// 计算 CRC-16/Modbus（多项式 0xA001 反射，初值 0xFFFF）。
+func modbusCRC(p []byte) uint16 {
+	crc := uint16(0xFFFF)
+	for _, b := range p {
+		crc ^= uint16(b)
+		for i := 0; i < 8; i++ {
+			if crc&1 != 0 {
+				crc = (crc >> 1) ^ 0xA001
+			} else {
+				crc >>= 1
+			}
+		}
+	}
+	return crc
+}
+
+// WriteModbusRTURequest
+// 写入 Modbus RTU 请求：从站地址、功能码、起始地址（大端）、数量（大端）与 CRC-16（低字节在前）。
+func WriteModbusRTURequest(buf Buffer, unitID, functionCode byte, startAddr, quantity uint16) (err error) {
+	p, borrowErr := buf.Borrow(modbusRequestLen)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	p[0] = unitID
+	p[1] = functionCode
+	binary.BigEndian.PutUint16(p[2:], startAddr)
+	binary.BigEndian.PutUint16(p[4:], quantity)
+	binary.LittleEndian.PutUint16(p[6:], modbusCRC(p[:6]))
+	buf.Return(modbusRequestLen)
+	return
+}
+
+// ReadModbusRTUResponse
+// 读取 Modbus RTU 响应并校验 CRC-16。
+//
+// 读功能码（1 至 4）的 data 为字节数之后的数据，寄存器为大端的 uint16；
+// 写功能码（5、6、15、16）的 data 为回显的地址与数值（4 字节）；
+// 异常响应的功能码带 0x80，data 为异常码，并返回 ErrModbusException。
+// 校验失败时不读掉。
+func ReadModbusRTUResponse(buf Buffer) (unitID, functionCode byte, data []byte, err error) {
+	p, peekErr := peekFull(buf, 3)
+	if peekErr != nil {
+		err = peekErr
+		return
+	}
+	var offset, size int
+	switch fc := p[1]; {
+	case fc&0x80 != 0:
+		offset, size = 2, 1
+	case fc >= ModbusReadCoils && fc <= ModbusReadInputRegisters:
+		offset, size = 3, int(p[2])
+	case fc == ModbusWriteSingleCoil || fc == ModbusWriteSingleRegister ||
+		fc == ModbusWriteMultipleCoils || fc == ModbusWriteMultipleRegisters:
+		offset, size = 2, 4
+	default:
+		err = ErrModbusInvalid
+		return
+	}
+	frameLen := offset + size + 2
+	if p, err = peekFull(buf, frameLen); err != nil {
+		return
+	}
+	if modbusCRC(p[:frameLen-2]) != binary.LittleEndian.Uint16(p[frameLen-2:]) {
+		err = ErrModbusChecksum
+		return
+	}
+	unitID, functionCode = p[0], p[1]
+	data = make([]byte, size)
+	copy(data, p[offset:])
+	buf.Discard(frameLen)
+	if functionCode&0x80 != 0 {
+		err = ErrModbusException
+	}
+	return
+}
@@ -0,0 +1,68 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestWriteModbusRTURequest(t *testing.T) {
+	// Modbus over serial line specification: read holding registers 108 to 110 of slave 17
+	expected, _ := hex.DecodeString("1103006b00037687")
+	buf := bytebuffers.NewBuffer()
+	if err := bytebuffers.WriteModbusRTURequest(buf, 0x11, bytebuffers.ModbusReadHoldingRegisters, 0x006B, 3); err != nil {
+		t.Fatal(err)
+	}
+	if p := buf.CloneBytes(); !bytes.Equal(p, expected) {
+		t.Fatal("unexpected request", hex.EncodeToString(p))
+	}
+}
+
+func TestReadModbusRTUResponse(t *testing.T) {
+	response, _ := hex.DecodeString("110306022b00000064c8ba")
+	buf := bytebuffers.NewBuffer()
+	_, _ = buf.Write(response[:len(response)-1])
+	if _, _, _, err := bytebuffers.ReadModbusRTUResponse(buf); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal("expected unexpected EOF, got", err)
+	}
+	_, _ = buf.Write(response[len(response)-1:])
+
+	unitID, fc, data, err := bytebuffers.ReadModbusRTUResponse(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unitID != 0x11 || fc != bytebuffers.ModbusReadHoldingRegisters || len(data) != 6 {
+		t.Fatal("unexpected response", unitID, fc, data)
+	}
+	registers := []uint16{555, 0, 100}
+	for i, register := range registers {
+		if v := binary.BigEndian.Uint16(data[i*2:]); v != register {
+			t.Fatal("unexpected register", i, v)
+		}
+	}
+
+	response[4] ^= 0xFF
+	_, _ = buf.Write(response)
+	if _, _, _, err = bytebuffers.ReadModbusRTUResponse(buf); !errors.Is(err, bytebuffers.ErrModbusChecksum) {
+		t.Fatal("expected checksum mismatch, got", err)
+	}
+	if buf.Len() != len(response) {
+		t.Fatal("corrupted response must not be discarded")
+	}
+	buf.Reset()
+
+	// illegal data address
+	_, _ = buf.Write([]byte{0x11, 0x83, 0x02, 0xC1, 0x34})
+	_, fc, data, err = bytebuffers.ReadModbusRTUResponse(buf)
+	if !errors.Is(err, bytebuffers.ErrModbusException) {
+		t.Fatal("expected exception, got", err)
+	}
+	if fc != 0x83 || len(data) != 1 || data[0] != 0x02 {
+		t.Fatal("unexpected exception", fc, data)
+	}
+}
@@ -0,0 +1,60 @@
+package bytebuffers
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+const (
+	MPLSLabelIPv4ExplicitNull = 0
+	MPLSLabelRouterAlert      = 1
+	MPLSLabelIPv6ExplicitNull = 2
+	MPLSLabelImplicitNull     = 3
+	MPLSMaxLabel              = 0xFFFFF
+
+	mplsLabelLen = 4
+)
+
+var (
+	ErrMPLSInvalid = errors.New("bytebuffers.MPLS: invalid label stack entry")
+)
+
+// WriteMPLSLabel
+// 写入 4 字节大端的 MPLS 标签栈条目：20 位标签、3 位 TC、1 位 S（栈底）与 8 位 TTL。
+//
+// 多次调用即可在 payload 前压入多个标签，最后一个标签的 stackBottom 须为 true。
+func WriteMPLSLabel(buf Buffer, label uint32, trafficClass byte, stackBottom bool, ttl byte) (err error) {
+	if label > MPLSMaxLabel || trafficClass > 7 {
+		err = ErrMPLSInvalid
+		return
+	}
+	p, borrowErr := buf.Borrow(mplsLabelLen)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	raw := label<<12 | uint32(trafficClass)<<9 | uint32(ttl)
+	if stackBottom {
+		raw |= 1 << 8
+	}
+	binary.BigEndian.PutUint32(p, raw)
+	buf.Return(mplsLabelLen)
+	return
+}
+
+// ReadMPLSLabel
+// 读取一个 MPLS 标签栈条目，返回各字段与原始的 32 位值，不完整时不读掉。
+func ReadMPLSLabel(buf Buffer) (label uint32, trafficClass byte, stackBottom bool, ttl byte, raw uint32, err error) {
+	p, peekErr := peekFull(buf, mplsLabelLen)
+	if peekErr != nil {
+		err = peekErr
+		return
+	}
+	raw = binary.BigEndian.Uint32(p)
+	label = raw >> 12
+	trafficClass = byte(raw>>9) & 0x07
+	stackBottom = raw&(1<<8) != 0
+	ttl = byte(raw)
+	buf.Discard(mplsLabelLen)
+	return
+}
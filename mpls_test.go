@@ -0,0 +1,62 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestMPLSLabel(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	// 16 is the first unreserved label; with S=1 the entry is 0x00010140.
+	if err := bytebuffers.WriteMPLSLabel(buf, 16, 0, true, 64); err != nil {
+		t.Fatal(err)
+	}
+	if p := buf.CloneBytes(); !bytes.Equal(p, []byte{0x00, 0x01, 0x01, 0x40}) {
+		t.Fatal("unexpected label", p)
+	}
+	label, tc, bottom, ttl, raw, err := bytebuffers.ReadMPLSLabel(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if label != 16 || tc != 0 || !bottom || ttl != 64 || raw != 0x00010140 {
+		t.Fatal("unexpected label", label, tc, bottom, ttl, raw)
+	}
+
+	// two entry stack
+	if err = bytebuffers.WriteMPLSLabel(buf, 0xFFFFF, 5, false, 255); err != nil {
+		t.Fatal(err)
+	}
+	if err = bytebuffers.WriteMPLSLabel(buf, bytebuffers.MPLSLabelIPv4ExplicitNull, 0, true, 1); err != nil {
+		t.Fatal(err)
+	}
+	if p := buf.CloneBytes(); !bytes.Equal(p, []byte{0xFF, 0xFF, 0xFA, 0xFF, 0x00, 0x00, 0x01, 0x01}) {
+		t.Fatal("unexpected stack", p)
+	}
+	if label, tc, bottom, ttl, _, err = bytebuffers.ReadMPLSLabel(buf); err != nil {
+		t.Fatal(err)
+	}
+	if label != 0xFFFFF || tc != 5 || bottom || ttl != 255 {
+		t.Fatal("unexpected label", label, tc, bottom, ttl)
+	}
+	if label, _, bottom, _, _, err = bytebuffers.ReadMPLSLabel(buf); err != nil {
+		t.Fatal(err)
+	}
+	if label != 0 || !bottom {
+		t.Fatal("unexpected label", label, bottom)
+	}
+
+	if err = bytebuffers.WriteMPLSLabel(buf, 0x100000, 0, true, 64); !errors.Is(err, bytebuffers.ErrMPLSInvalid) {
+		t.Fatal("expected invalid, got", err)
+	}
+	if err = bytebuffers.WriteMPLSLabel(buf, 16, 8, true, 64); !errors.Is(err, bytebuffers.ErrMPLSInvalid) {
+		t.Fatal("expected invalid, got", err)
+	}
+	_, _ = buf.Write([]byte{0x00, 0x01, 0x01})
+	if _, _, _, _, _, err = bytebuffers.ReadMPLSLabel(buf); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal("expected unexpected EOF, got", err)
+	}
+}
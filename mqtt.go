@@ -0,0 +1,144 @@
+package bytebuffers
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+)
+
+const (
+	MQTTPacketConnect     = 0x10
+	MQTTPacketConnAck     = 0x20
+	MQTTPacketPublish     = 0x30
+	MQTTPacketPubAck      = 0x40
+	MQTTPacketSubscribe   = 0x80
+	MQTTPacketSubAck      = 0x90
+	MQTTPacketPingReq     = 0xC0
+	MQTTPacketPingResp    = 0xD0
+	MQTTPacketDisconnect  = 0xE0
+	mqttProtocolLevel     = 4
+	mqttMaxRemainingBytes = 4
+	mqttMaxRemaining      = 268435455
+)
+
+var (
+	ErrMQTTMalformed = errors.New("bytebuffers.MQTT: malformed packet")
+)
+
+// MQTTConnectOptions
+// MQTT 3.1.1 CONNECT 报文的参数。
+type MQTTConnectOptions struct {
+	ClientID     string
+	Username     string
+	Password     []byte
+	KeepAlive    uint16
+	CleanSession bool
+}
+
+// WriteMQTTConnectPacket
+// 写入 MQTT 3.1.1 的 CONNECT 报文。
+//
+// Username 为空时不设置用户名标志，Password 为 nil 时不设置密码标志。
+func WriteMQTTConnectPacket(buf Buffer, opts MQTTConnectOptions) (err error) {
+	var flags byte
+	if opts.CleanSession {
+		flags |= 0x02
+	}
+	remaining := 10 + 2 + len(opts.ClientID)
+	if opts.Username != "" {
+		flags |= 0x80
+		remaining += 2 + len(opts.Username)
+	}
+	if opts.Password != nil {
+		flags |= 0x40
+		remaining += 2 + len(opts.Password)
+	}
+	if len(opts.ClientID) > math.MaxUint16 || len(opts.Username) > math.MaxUint16 || len(opts.Password) > math.MaxUint16 || remaining > mqttMaxRemaining {
+		err = ErrTooLarge
+		return
+	}
+
+	size := 1 + mqttRemainingLengthSize(remaining) + remaining
+	p, borrowErr := buf.Borrow(size)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	p[0] = MQTTPacketConnect
+	n := 1 + putMQTTRemainingLength(p[1:], remaining)
+	n += putMQTTString(p[n:], "MQTT")
+	p[n] = mqttProtocolLevel
+	p[n+1] = flags
+	binary.BigEndian.PutUint16(p[n+2:], opts.KeepAlive)
+	n += 4
+	n += putMQTTString(p[n:], opts.ClientID)
+	if opts.Username != "" {
+		n += putMQTTString(p[n:], opts.Username)
+	}
+	if opts.Password != nil {
+		binary.BigEndian.PutUint16(p[n:], uint16(len(opts.Password)))
+		n += 2 + copy(p[n+2:], opts.Password)
+	}
+	buf.Return(n)
+	return
+}
+
+// ReadMQTTPacketHeader
+// 读取 MQTT 固定头，packetType 为首字节（高 4 位为类型，低 4 位为标志），remainingLength 为剩余长度。
+//
+// 只读掉固定头，不完整时不读掉。
+func ReadMQTTPacketHeader(buf Buffer) (packetType byte, remainingLength int, err error) {
+	p := buf.Peek(1 + mqttMaxRemainingBytes)
+	if len(p) == 0 {
+		err = io.EOF
+		return
+	}
+	multiplier := 1
+	for i := 1; ; i++ {
+		if i == len(p) {
+			if i > mqttMaxRemainingBytes {
+				remainingLength, err = 0, ErrMQTTMalformed
+				return
+			}
+			remainingLength, err = 0, io.ErrUnexpectedEOF
+			return
+		}
+		remainingLength += int(p[i]&0x7F) * multiplier
+		multiplier <<= 7
+		if p[i]&0x80 == 0 {
+			packetType = p[0]
+			buf.Discard(i + 1)
+			return
+		}
+	}
+}
+
+func mqttRemainingLengthSize(n int) int {
+	size := 1
+	for n >>= 7; n > 0; n >>= 7 {
+		size++
+	}
+	return size
+}
+
+func putMQTTRemainingLength(p []byte, n int) int {
+	i := 0
+	for {
+		b := byte(n & 0x7F)
+		n >>= 7
+		if n > 0 {
+			b |= 0x80
+		}
+		p[i] = b
+		i++
+		if n == 0 {
+			return i
+		}
+	}
+}
+
+func putMQTTString(p []byte, s string) int {
+	binary.BigEndian.PutUint16(p, uint16(len(s)))
+	return 2 + copy(p[2:], s)
+}
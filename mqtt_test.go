@@ -0,0 +1,82 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestWriteMQTTConnectPacket(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	err := bytebuffers.WriteMQTTConnectPacket(buf, bytebuffers.MQTTConnectOptions{
+		ClientID:     "abc",
+		KeepAlive:    60,
+		CleanSession: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []byte{0x10, 0x0F, 0x00, 0x04, 'M', 'Q', 'T', 'T', 0x04, 0x02, 0x00, 0x3C, 0x00, 0x03, 'a', 'b', 'c'}
+	if !bytes.Equal(buf.CloneBytes(), expected) {
+		t.Fatal("unexpected packet", buf.CloneBytes())
+	}
+	buf.Reset()
+
+	err = bytebuffers.WriteMQTTConnectPacket(buf, bytebuffers.MQTTConnectOptions{
+		ClientID:  "c",
+		Username:  "u",
+		Password:  []byte("p"),
+		KeepAlive: 10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = []byte{0x10, 0x13, 0x00, 0x04, 'M', 'Q', 'T', 'T', 0x04, 0xC0, 0x00, 0x0A, 0x00, 0x01, 'c', 0x00, 0x01, 'u', 0x00, 0x01, 'p'}
+	if !bytes.Equal(buf.CloneBytes(), expected) {
+		t.Fatal("unexpected packet", buf.CloneBytes())
+	}
+	packetType, remaining, err := bytebuffers.ReadMQTTPacketHeader(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if packetType != bytebuffers.MQTTPacketConnect || remaining != 0x13 || buf.Len() != remaining {
+		t.Fatal("unexpected header", packetType, remaining, buf.Len())
+	}
+}
+
+func TestReadMQTTPacketHeader(t *testing.T) {
+	cases := []struct {
+		encoded   []byte
+		remaining int
+	}{
+		{[]byte{0xC0, 0x00}, 0},
+		{[]byte{0x30, 0x7F}, 127},
+		{[]byte{0x30, 0x80, 0x01}, 128},
+		{[]byte{0x30, 0xFF, 0x7F}, 16383},
+		{[]byte{0x30, 0x80, 0x80, 0x01}, 16384},
+		{[]byte{0x30, 0xFF, 0xFF, 0xFF, 0x7F}, 268435455},
+	}
+	buf := bytebuffers.NewBuffer()
+	for _, c := range cases {
+		_, _ = buf.Write(c.encoded)
+		_, remaining, err := bytebuffers.ReadMQTTPacketHeader(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if remaining != c.remaining || buf.Len() != 0 {
+			t.Fatal("unexpected remaining length", remaining, c.remaining)
+		}
+	}
+
+	_, _ = buf.Write([]byte{0x30, 0x80})
+	if _, _, err := bytebuffers.ReadMQTTPacketHeader(buf); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal("expected unexpected EOF, got", err)
+	}
+	_ = buf.Set([]byte{0x30, 0xFF, 0xFF, 0xFF, 0xFF, 0x01})
+	if _, _, err := bytebuffers.ReadMQTTPacketHeader(buf); !errors.Is(err, bytebuffers.ErrMQTTMalformed) {
+		t.Fatal("expected malformed, got", err)
+	}
+}
@@ -0,0 +1,55 @@
+//go:build windows
+
+package bytebuffers
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+const (
+	ndisHeaderLen = 8
+)
+
+var (
+	ErrNDISInvalid = errors.New("bytebuffers.NDIS: invalid header")
+)
+
+// WriteNDISHeader
+// 写入 NDIS 过滤驱动与用户态交换数据时的片段头：小端 ULONG 的 DataOffset 与 DataLength，与 DDK 中 NET_BUFFER 的同名字段一致。
+//
+// DataOffset 为数据相对于片段起始的偏移，DataOffset + DataLength 不能超过 ULONG 范围。
+func WriteNDISHeader(buf Buffer, dataOffset, dataLength uint32) (err error) {
+	if uint64(dataOffset)+uint64(dataLength) > math.MaxUint32 {
+		err = ErrNDISInvalid
+		return
+	}
+	p, borrowErr := buf.Borrow(ndisHeaderLen)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	binary.LittleEndian.PutUint32(p, dataOffset)
+	binary.LittleEndian.PutUint32(p[4:], dataLength)
+	buf.Return(ndisHeaderLen)
+	return
+}
+
+// ReadNDISHeader
+// 读取 8 字节的 NDIS 片段头，返回 DataOffset 与 DataLength，不完整时不读掉。
+func ReadNDISHeader(buf Buffer) (dataOffset, dataLength uint32, err error) {
+	p, peekErr := peekFull(buf, ndisHeaderLen)
+	if peekErr != nil {
+		err = peekErr
+		return
+	}
+	dataOffset = binary.LittleEndian.Uint32(p)
+	dataLength = binary.LittleEndian.Uint32(p[4:])
+	if uint64(dataOffset)+uint64(dataLength) > math.MaxUint32 {
+		err = ErrNDISInvalid
+		return
+	}
+	buf.Discard(ndisHeaderLen)
+	return
+}
@@ -0,0 +1,40 @@
+//go:build windows
+
+package bytebuffers_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestNDISHeader(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	if err := bytebuffers.WriteNDISHeader(buf, 14, 1500); err != nil {
+		t.Fatal(err)
+	}
+	// ULONG DataOffset; ULONG DataLength;
+	expected := []byte{0x0E, 0x00, 0x00, 0x00, 0xDC, 0x05, 0x00, 0x00}
+	if p := buf.CloneBytes(); !bytes.Equal(p, expected) {
+		t.Fatal("unexpected header", p)
+	}
+
+	dataOffset, dataLength, err := bytebuffers.ReadNDISHeader(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dataOffset != 14 || dataLength != 1500 {
+		t.Fatal("unexpected header", dataOffset, dataLength)
+	}
+
+	if err = bytebuffers.WriteNDISHeader(buf, 0xFFFFFFFF, 1); !errors.Is(err, bytebuffers.ErrNDISInvalid) {
+		t.Fatal("expected invalid header, got", err)
+	}
+	_, _ = buf.Write(expected[:7])
+	if _, _, err = bytebuffers.ReadNDISHeader(buf); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal("expected unexpected EOF, got", err)
+	}
+}
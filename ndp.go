@@ -0,0 +1,63 @@
+package bytebuffers
+
+import (
+	"encoding/binary"
+	"errors"
+	"net"
+)
+
+const (
+	ICMPv6TypeRouterSolicitation    = 133
+	ICMPv6TypeRouterAdvertisement   = 134
+	ICMPv6TypeNeighborSolicitation  = 135
+	ICMPv6TypeNeighborAdvertisement = 136
+
+	NDPOptionSourceLinkLayerAddress = 1
+	NDPOptionTargetLinkLayerAddress = 2
+
+	ndpNeighborSolicitationLen = 4 + 4 + net.IPv6len + 8
+)
+
+var (
+	ErrNDPInvalidAddress = errors.New("bytebuffers.NDP: invalid address")
+)
+
+// SolicitedNodeMulticast
+// 返回 ip 的 solicited-node 组播地址 ff02::1:ffXX:XXXX，即邻居请求的目的地址。
+func SolicitedNodeMulticast(ip net.IP) net.IP {
+	ip16 := ip.To16()
+	if ip16 == nil {
+		return nil
+	}
+	return net.IP{0xff, 0x02, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0x01, 0xff, ip16[13], ip16[14], ip16[15]}
+}
+
+// WriteICMPv6NeighborSolicitation
+// 写入 ICMPv6 邻居请求：类型 135、代码 0、校验和、4 字节保留、目标地址与源链路层地址选项（类型 1、长度 1、MAC）。
+//
+// 校验和基于 IPv6 伪首部计算，源地址为 src，目的地址为 target 的 solicited-node 组播地址。
+// 按 RFC 4861，承载它的 IPv6 首部跳数限制须为 255。
+func WriteICMPv6NeighborSolicitation(buf Buffer, src, target net.IP, srcMAC net.HardwareAddr) (err error) {
+	src16, target16 := src.To16(), target.To16()
+	if src16 == nil || target16 == nil || src.To4() != nil || target.To4() != nil || len(srcMAC) != 6 {
+		err = ErrNDPInvalidAddress
+		return
+	}
+	p, borrowErr := buf.Borrow(ndpNeighborSolicitationLen)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	p[0] = ICMPv6TypeNeighborSolicitation
+	p[1] = 0
+	binary.BigEndian.PutUint16(p[2:], 0) // checksum placeholder
+	binary.BigEndian.PutUint32(p[4:], 0) // reserved
+	copy(p[8:24], target16)
+	p[24] = NDPOptionSourceLinkLayerAddress
+	p[25] = 1 // in units of 8 bytes
+	copy(p[26:32], srcMAC)
+	sum := ipv6PseudoHeaderSum(src16, SolicitedNodeMulticast(target16), ndpNeighborSolicitationLen, IPv6NextHeaderICMPv6)
+	binary.BigEndian.PutUint16(p[2:], internetChecksum(sum, p[:ndpNeighborSolicitationLen]))
+	buf.Return(ndpNeighborSolicitationLen)
+	return
+}
@@ -0,0 +1,54 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestWriteICMPv6NeighborSolicitation(t *testing.T) {
+	src, target := net.ParseIP("fe80::1"), net.ParseIP("fe80::abcd:ef01")
+	mac, _ := net.ParseMAC("00:11:22:33:44:55")
+	buf := bytebuffers.NewBuffer()
+	if err := bytebuffers.WriteICMPv6NeighborSolicitation(buf, src, target, mac); err != nil {
+		t.Fatal(err)
+	}
+	p := buf.CloneBytes()
+	if len(p) != 32 {
+		t.Fatal("unexpected length", len(p))
+	}
+	if p[0] != bytebuffers.ICMPv6TypeNeighborSolicitation || p[1] != 0 {
+		t.Fatal("unexpected type or code", p[:2])
+	}
+	if !bytes.Equal(p[4:8], []byte{0, 0, 0, 0}) || !net.IP(p[8:24]).Equal(target) {
+		t.Fatal("unexpected reserved or target", p[4:24])
+	}
+	if p[24] != bytebuffers.NDPOptionSourceLinkLayerAddress || p[25] != 1 || !bytes.Equal(p[26:32], mac) {
+		t.Fatal("unexpected option", p[24:])
+	}
+
+	dst := bytebuffers.SolicitedNodeMulticast(target)
+	if !dst.Equal(net.ParseIP("ff02::1:ffcd:ef01")) {
+		t.Fatal("unexpected solicited-node multicast", dst)
+	}
+	// the checksum must verify over the pseudo-header followed by the message
+	pseudo := make([]byte, 0, 40+len(p))
+	pseudo = append(pseudo, src.To16()...)
+	pseudo = append(pseudo, dst...)
+	pseudo = binary.BigEndian.AppendUint32(pseudo, uint32(len(p)))
+	pseudo = append(pseudo, 0, 0, 0, bytebuffers.IPv6NextHeaderICMPv6)
+	if onesComplementSum(append(pseudo, p...)) != 0xFFFF {
+		t.Fatal("invalid checksum", binary.BigEndian.Uint16(p[2:]))
+	}
+	if onesComplementSum(p) == 0xFFFF {
+		t.Fatal("checksum must include the pseudo-header")
+	}
+
+	if err := bytebuffers.WriteICMPv6NeighborSolicitation(buf, src, target, mac[:4]); !errors.Is(err, bytebuffers.ErrNDPInvalidAddress) {
+		t.Fatal("expected invalid address, got", err)
+	}
+}
@@ -0,0 +1,92 @@
+package bytebuffers
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+)
+
+var (
+	ErrNMEAInvalid  = errors.New("bytebuffers.NMEA: invalid sentence")
+	ErrNMEAChecksum = errors.New("bytebuffers.NMEA: checksum mismatch")
+)
+
+// WriteNMEASentence
+// 写入 NMEA 0183 语句：$、content、*、两位十六进制的异或校验和与 \r\n。
+//
+// content 以 $ 或 !（AIS）开头时沿用该起始符，不能含有 *、\r 或 \n。
+func WriteNMEASentence(buf Buffer, content string) (err error) {
+	start := byte('$')
+	if len(content) > 0 && (content[0] == '$' || content[0] == '!') {
+		start, content = content[0], content[1:]
+	}
+	if len(content) == 0 || strings.ContainsAny(content, "*$!\r\n") {
+		err = ErrNMEAInvalid
+		return
+	}
+	size := 1 + len(content) + 5
+	p, borrowErr := buf.Borrow(size)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	const hex = "0123456789ABCDEF"
+	p[0] = start
+	n := 1 + copy(p[1:], content)
+	sum := nmeaChecksum(p[1:n])
+	p[n], p[n+1], p[n+2], p[n+3], p[n+4] = '*', hex[sum>>4], hex[sum&0x0F], '\r', '\n'
+	buf.Return(size)
+	return
+}
+
+// ReadNMEASentence
+// 读取一条 NMEA 0183 语句并校验，返回不含起始符与校验和的内容。
+//
+// 起始符前的字节会被丢弃。不完整时返回 io.ErrUnexpectedEOF 且不读掉语句；无效或校验失败时该语句会被丢弃。
+func ReadNMEASentence(buf Buffer) (content string, err error) {
+	p := buf.Peek(buf.Len())
+	if len(p) == 0 {
+		err = io.EOF
+		return
+	}
+	start := bytes.IndexAny(p, "$!")
+	if start < 0 {
+		buf.Discard(len(p))
+		err = io.ErrUnexpectedEOF
+		return
+	}
+	buf.Discard(start)
+	p = p[start:]
+	end := bytes.Index(p, []byte("\r\n"))
+	if end < 0 {
+		err = io.ErrUnexpectedEOF
+		return
+	}
+	buf.Discard(end + 2)
+	sentence := p[1:end]
+	star := bytes.IndexByte(sentence, '*')
+	if star < 1 || len(sentence)-star != 3 {
+		err = ErrNMEAInvalid
+		return
+	}
+	hi, lo := sentence[star+1], sentence[star+2]
+	if !isHexDigit(hi) || !isHexDigit(lo) {
+		err = ErrNMEAInvalid
+		return
+	}
+	if hexDigit(hi)<<4|hexDigit(lo) != nmeaChecksum(sentence[:star]) {
+		err = ErrNMEAChecksum
+		return
+	}
+	content = string(sentence[:star])
+	return
+}
+
+func nmeaChecksum(p []byte) byte {
+	var sum byte
+	for _, b := range p {
+		sum ^= b
+	}
+	return sum
+}
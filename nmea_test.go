@@ -0,0 +1,55 @@
+package bytebuffers_test
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+const ggaSentence = "GPGGA,123519,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,"
+
+func TestWriteNMEASentence(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	if err := bytebuffers.WriteNMEASentence(buf, ggaSentence); err != nil {
+		t.Fatal(err)
+	}
+	expected := "$" + ggaSentence + "*47\r\n"
+	if s := string(buf.Peek(buf.Len())); s != expected {
+		t.Fatalf("unexpected sentence %q", s)
+	}
+	content, err := bytebuffers.ReadNMEASentence(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if content != ggaSentence || buf.Len() != 0 {
+		t.Fatal("unexpected content", content)
+	}
+
+	if err = bytebuffers.WriteNMEASentence(buf, "GPGGA*"); !errors.Is(err, bytebuffers.ErrNMEAInvalid) {
+		t.Fatal("expected invalid, got", err)
+	}
+}
+
+func TestReadNMEASentence(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	_, _ = buf.WriteString("noise$" + ggaSentence + "*48\r\n$GPGSA,A,3,04,05,,09,12,,,24,,,,,2.5,1.3,2.1*39\r\n$GPRMC")
+
+	if _, err := bytebuffers.ReadNMEASentence(buf); !errors.Is(err, bytebuffers.ErrNMEAChecksum) {
+		t.Fatal("expected checksum mismatch, got", err)
+	}
+	content, err := bytebuffers.ReadNMEASentence(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if content != "GPGSA,A,3,04,05,,09,12,,,24,,,,,2.5,1.3,2.1" {
+		t.Fatal("unexpected content", content)
+	}
+	if _, err = bytebuffers.ReadNMEASentence(buf); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal("expected unexpected EOF, got", err)
+	}
+	if buf.Len() != len("$GPRMC") {
+		t.Fatal("incomplete sentence must not be discarded")
+	}
+}
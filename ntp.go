@@ -0,0 +1,121 @@
+package bytebuffers
+
+import (
+	"encoding/binary"
+	"errors"
+	"time"
+)
+
+const (
+	NTPModeClient = 3
+	NTPModeServer = 4
+
+	ntpPacketLen = 48
+	ntpEraLen    = 1 << 32
+)
+
+var (
+	ErrNTPInvalidVersion = errors.New("bytebuffers.NTP: invalid version")
+)
+
+// ntpEpoch
+// NTP 的纪元 1900-01-01 00:00:00 UTC。
+var ntpEpoch = time.Date(1900, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// NTPPacket
+// NTP 报文（RFC 5905）的 48 字节固定部分，为 0 的时间戳对应 time.Time 的零值。
+type NTPPacket struct {
+	LeapIndicator      byte
+	Version            byte
+	Mode               byte
+	Stratum            byte
+	Poll               int8
+	Precision          int8
+	RootDelay          time.Duration
+	RootDispersion     time.Duration
+	ReferenceID        uint32
+	ReferenceTimestamp time.Time
+	OriginTimestamp    time.Time
+	ReceiveTimestamp   time.Time
+	TransmitTimestamp  time.Time
+}
+
+// WriteNTPRequest
+// 写入 48 字节的 NTP 客户端请求，mode 为 3，version 为 1 至 4，其余字段为 0。
+func WriteNTPRequest(buf Buffer, version int) (err error) {
+	if version < 1 || version > 4 {
+		err = ErrNTPInvalidVersion
+		return
+	}
+	p, borrowErr := buf.Borrow(ntpPacketLen)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	clear(p[:ntpPacketLen])
+	p[0] = byte(version)<<3 | NTPModeClient
+	buf.Return(ntpPacketLen)
+	return
+}
+
+// ReadNTPPacket
+// 读取 48 字节的 NTP 报文，不完整时不读掉。
+//
+// 时间戳秒数的最高位为 0 时视为 2036 年之后的第 1 纪元。
+func ReadNTPPacket(buf Buffer) (packet NTPPacket, err error) {
+	p, peekErr := peekFull(buf, ntpPacketLen)
+	if peekErr != nil {
+		err = peekErr
+		return
+	}
+	packet = NTPPacket{
+		LeapIndicator:      p[0] >> 6,
+		Version:            p[0] >> 3 & 0x07,
+		Mode:               p[0] & 0x07,
+		Stratum:            p[1],
+		Poll:               int8(p[2]),
+		Precision:          int8(p[3]),
+		RootDelay:          ntpShortDuration(binary.BigEndian.Uint32(p[4:])),
+		RootDispersion:     ntpShortDuration(binary.BigEndian.Uint32(p[8:])),
+		ReferenceID:        binary.BigEndian.Uint32(p[12:]),
+		ReferenceTimestamp: ntpTime(binary.BigEndian.Uint64(p[16:])),
+		OriginTimestamp:    ntpTime(binary.BigEndian.Uint64(p[24:])),
+		ReceiveTimestamp:   ntpTime(binary.BigEndian.Uint64(p[32:])),
+		TransmitTimestamp:  ntpTime(binary.BigEndian.Uint64(p[40:])),
+	}
+	buf.Discard(ntpPacketLen)
+	return
+}
+
+// ntpTime
+// 将 64 位的 NTP 时间戳（32 位秒与 32 位小数）转换为 UTC 时间。
+func ntpTime(ts uint64) (t time.Time) {
+	if ts == 0 {
+		return
+	}
+	sec, frac := int64(ts>>32), ts&0xFFFFFFFF
+	if sec&0x80000000 == 0 {
+		sec += ntpEraLen
+	}
+	nsec := int64((frac*uint64(time.Second) + 1<<31) >> 32)
+	t = ntpEpoch.Add(time.Duration(sec) * time.Second).Add(time.Duration(nsec))
+	return
+}
+
+// ntpTimestamp
+// 将时间转换为 64 位的 NTP 时间戳，2036 年之后的时间使用第 1 纪元，1900 年之前为 0。
+func ntpTimestamp(t time.Time) uint64 {
+	d := t.Sub(ntpEpoch)
+	if d < 0 {
+		return 0
+	}
+	sec := uint64(d / time.Second)
+	frac := (uint64(d%time.Second)<<32 + uint64(time.Second)/2) / uint64(time.Second)
+	return sec<<32 + frac
+}
+
+// ntpShortDuration
+// 将 NTP 的 16.16 定点短格式转换为时长。
+func ntpShortDuration(v uint32) time.Duration {
+	return time.Duration((uint64(v)*uint64(time.Second) + 1<<15) >> 16)
+}
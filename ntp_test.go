@@ -0,0 +1,67 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestWriteNTPRequest(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	if err := bytebuffers.WriteNTPRequest(buf, 4); err != nil {
+		t.Fatal(err)
+	}
+	expected := make([]byte, 48)
+	expected[0] = 0x23
+	if !bytes.Equal(buf.CloneBytes(), expected) {
+		t.Fatal("unexpected request", hex.EncodeToString(buf.CloneBytes()))
+	}
+	if err := bytebuffers.WriteNTPRequest(buf, 5); err != bytebuffers.ErrNTPInvalidVersion {
+		t.Fatal("expected invalid version, got", err)
+	}
+}
+
+func TestReadNTPPacket(t *testing.T) {
+	// server response: v4, mode 4, stratum 2, reference id 192.168.1.1
+	response, _ := hex.DecodeString("240203e8" + "00000800" + "00001000" + "c0a80101" +
+		"e99eb6c000000000" + "0000000000000000" + "e99eb6c480000000" + "e99eb6c4c0000000")
+	buf := bytebuffers.NewBuffer()
+	_, _ = buf.Write(response[:47])
+	if _, err := bytebuffers.ReadNTPPacket(buf); err == nil {
+		t.Fatal("expected error for incomplete packet")
+	}
+	_, _ = buf.Write(response[47:])
+
+	packet, err := bytebuffers.ReadNTPPacket(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if packet.LeapIndicator != 0 || packet.Version != 4 || packet.Mode != bytebuffers.NTPModeServer || packet.Stratum != 2 {
+		t.Fatal("unexpected header", packet)
+	}
+	if packet.Poll != 3 || packet.Precision != -24 || packet.ReferenceID != 0xC0A80101 {
+		t.Fatal("unexpected poll, precision or reference id", packet)
+	}
+	if packet.RootDelay != 31250*time.Microsecond || packet.RootDispersion != 62500*time.Microsecond {
+		t.Fatal("unexpected root delay or dispersion", packet.RootDelay, packet.RootDispersion)
+	}
+	if !packet.OriginTimestamp.IsZero() {
+		t.Fatal("zero timestamp must be zero time", packet.OriginTimestamp)
+	}
+	reference := time.Date(2024, time.March, 15, 12, 0, 0, 0, time.UTC)
+	if !packet.ReferenceTimestamp.Equal(reference) {
+		t.Fatal("unexpected reference timestamp", packet.ReferenceTimestamp)
+	}
+	if !packet.ReceiveTimestamp.Equal(reference.Add(4500 * time.Millisecond)) {
+		t.Fatal("unexpected receive timestamp", packet.ReceiveTimestamp)
+	}
+	if !packet.TransmitTimestamp.Equal(reference.Add(4750 * time.Millisecond)) {
+		t.Fatal("unexpected transmit timestamp", packet.TransmitTimestamp)
+	}
+	if buf.Len() != 0 {
+		t.Fatal("packet must be discarded")
+	}
+}
@@ -0,0 +1,94 @@
+package bytebuffers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrOAuthBearerInvalid = errors.New("bytebuffers.OAuthBearer: invalid message")
+)
+
+var oauthBearerTerminator = []byte{0x01, 0x01}
+
+// WriteOAuthBearerToken
+// 写入 SASL OAUTHBEARER 的客户端初始响应：n,,\x01auth=Bearer TOKEN\x01\x01。
+func WriteOAuthBearerToken(buf Buffer, token string) (err error) {
+	if token == "" || strings.IndexByte(token, 0x01) >= 0 {
+		err = ErrOAuthBearerInvalid
+		return
+	}
+	_, err = buf.WriteString("n,,\x01auth=Bearer " + token + "\x01\x01")
+	return
+}
+
+// ReadOAuthBearerToken
+// 读取 SASL OAUTHBEARER 的客户端初始响应，返回 auth 中的 Bearer token，只读掉至 \x01\x01 结束符，不完整或出错时不读掉。
+func ReadOAuthBearerToken(buf Buffer) (token string, err error) {
+	p := buf.Peek(buf.Len())
+	if len(p) == 0 {
+		err = io.EOF
+		return
+	}
+	end := bytes.Index(p, oauthBearerTerminator)
+	if end < 0 {
+		err = io.ErrUnexpectedEOF
+		return
+	}
+	// gs2-header, then kvpairs each terminated by \x01
+	first := bytes.IndexByte(p[:end], ',')
+	if first < 0 {
+		err = ErrOAuthBearerInvalid
+		return
+	}
+	second := bytes.IndexByte(p[first+1:end], ',')
+	if second < 0 {
+		err = ErrOAuthBearerInvalid
+		return
+	}
+	kvs := p[first+second+2 : end]
+	if len(kvs) == 0 || kvs[0] != 0x01 {
+		err = ErrOAuthBearerInvalid
+		return
+	}
+	for _, kv := range bytes.Split(kvs[1:], []byte{0x01}) {
+		value, ok := bytes.CutPrefix(kv, []byte("auth="))
+		if !ok {
+			continue
+		}
+		if len(value) <= 7 || !strings.EqualFold(string(value[:7]), "Bearer ") {
+			err = ErrOAuthBearerInvalid
+			return
+		}
+		token = string(value[7:])
+		break
+	}
+	if token == "" {
+		err = ErrOAuthBearerInvalid
+		return
+	}
+	buf.Discard(end + len(oauthBearerTerminator))
+	return
+}
+
+// WriteOAuthBearerFailure
+// 写入服务端的 JSON 失败消息：{"status":"...","scope":"..."}，scope 为空时省略。
+func WriteOAuthBearerFailure(buf Buffer, status int, scope string) (err error) {
+	p, encodeErr := json.Marshal(struct {
+		Status string `json:"status"`
+		Scope  string `json:"scope,omitempty"`
+	}{
+		Status: strconv.Itoa(status),
+		Scope:  scope,
+	})
+	if encodeErr != nil {
+		err = encodeErr
+		return
+	}
+	_, err = buf.Write(p)
+	return
+}
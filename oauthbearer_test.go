@@ -0,0 +1,65 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestOAuthBearerToken(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	token := "vF9dft4qmTc2Nvb3RlckBhbHRhdmlzdGEuY29tCg=="
+	if err := bytebuffers.WriteOAuthBearerToken(buf, token); err != nil {
+		t.Fatal(err)
+	}
+	p := buf.CloneBytes()
+	if !bytes.Contains(p, []byte("\x01auth=Bearer ")) {
+		t.Fatal("auth=Bearer prefix must be present", string(p))
+	}
+	if !bytes.HasSuffix(p, []byte{0x01, 0x01}) {
+		t.Fatal("message must end with \\x01\\x01", p)
+	}
+	if string(p) != "n,,\x01auth=Bearer "+token+"\x01\x01" {
+		t.Fatal("unexpected message", string(p))
+	}
+
+	decoded, err := bytebuffers.ReadOAuthBearerToken(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded != token || buf.Len() != 0 {
+		t.Fatal("unexpected token", decoded, buf.Len())
+	}
+
+	// RFC 7628 section 4.1, with authzid and extra kvpairs
+	_, _ = buf.WriteString("n,a=user@example.com,\x01host=server.example.com\x01port=143\x01auth=Bearer " + token + "\x01\x01")
+	if decoded, err = bytebuffers.ReadOAuthBearerToken(buf); err != nil {
+		t.Fatal(err)
+	}
+	if decoded != token {
+		t.Fatal("unexpected token", decoded)
+	}
+
+	_, _ = buf.WriteString("n,,\x01auth=Bearer " + token + "\x01")
+	if _, err = bytebuffers.ReadOAuthBearerToken(buf); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal("expected unexpected EOF, got", err)
+	}
+	buf.Reset()
+	_, _ = buf.WriteString("n,,\x01auth=Basic dXNlcg==\x01\x01")
+	if _, err = bytebuffers.ReadOAuthBearerToken(buf); !errors.Is(err, bytebuffers.ErrOAuthBearerInvalid) {
+		t.Fatal("expected invalid, got", err)
+	}
+}
+
+func TestWriteOAuthBearerFailure(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	if err := bytebuffers.WriteOAuthBearerFailure(buf, 401, "example_scope"); err != nil {
+		t.Fatal(err)
+	}
+	if p := buf.CloneBytes(); string(p) != `{"status":"401","scope":"example_scope"}` {
+		t.Fatal("unexpected failure message", string(p))
+	}
+}
@@ -0,0 +1,88 @@
+package bytebuffers
+
+import (
+	"io"
+)
+
+func (buf *buffer) ReadAt(p []byte, off int64) (n int, err error) {
+	if off < 0 {
+		err = ErrInvalidOffset
+		return
+	}
+	if off >= int64(buf.Len()) {
+		err = io.EOF
+		return
+	}
+	n = copy(p, buf.b[buf.r+int(off):buf.w])
+	if n < len(p) {
+		err = io.EOF
+	}
+	return
+}
+
+func (buf *buffer) WriteAt(p []byte, off int64) (n int, err error) {
+	buf.ur = 0
+	if off < 0 {
+		err = ErrInvalidOffset
+		return
+	}
+	if off > int64(maxInt-len(p)) {
+		err = ErrTooLarge
+		return
+	}
+	o := int(off)
+	if end := o + len(p); end > buf.Len() {
+		if buf.Borrowing() {
+			err = ErrWriteWhenBorrowing
+			return
+		}
+		if more := end - buf.Len(); buf.c-buf.w < more {
+			if err = buf.grow(more); err != nil {
+				return
+			}
+		}
+		if gap := buf.r + o; gap > buf.w {
+			clear(buf.b[buf.w:gap])
+		}
+		buf.w = buf.r + end
+		buf.a = buf.w
+	}
+	n = copy(buf.b[buf.r+o:], p)
+	return
+}
+
+// seekTo
+// 计算 Seek 的目标位置，lo 为仍保留的最早位置，cur 为当前读位置，end 为可读内容的末尾。
+func seekTo(offset int64, whence int, lo int64, cur int64, end int64) (abs int64, err error) {
+	switch whence {
+	case io.SeekStart:
+		abs = offset
+	case io.SeekCurrent:
+		abs = cur + offset
+	case io.SeekEnd:
+		abs = end + offset
+	default:
+		err = ErrInvalidWhence
+		return
+	}
+	if abs < lo || abs > end {
+		abs = 0
+		err = ErrInvalidOffset
+		return
+	}
+	return
+}
+
+func (buf *buffer) Seek(offset int64, whence int) (abs int64, err error) {
+	buf.ur = 0
+	if buf.s == 0 { // keep the read content from here on
+		buf.s = buf.r + 1
+	}
+	origin := buf.s - 1
+	lo := int64(buf.sb)
+	if abs, err = seekTo(offset, whence, lo, lo+int64(buf.r-origin), lo+int64(buf.w-origin)); err != nil {
+		return
+	}
+	buf.r = origin + int(abs-lo)
+	return
+}
@@ -0,0 +1,200 @@
+package bytebuffers_test
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestBuffer_ReadAt(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	_, _ = buf.WriteString("xx0123456789")
+	buf.Discard(2)
+	p := make([]byte, 4)
+	if n, err := buf.ReadAt(p, 3); err != nil || n != 4 || string(p) != "3456" {
+		t.Fatal("unexpected read at", n, err, string(p))
+	}
+	if n, err := buf.ReadAt(p, 8); !errors.Is(err, io.EOF) || n != 2 || string(p[:n]) != "89" {
+		t.Fatal("unexpected read at", n, err)
+	}
+	if _, err := buf.ReadAt(p, 10); !errors.Is(err, io.EOF) {
+		t.Fatal("expected EOF, got", err)
+	}
+	if _, err := buf.ReadAt(p, -1); !errors.Is(err, bytebuffers.ErrInvalidOffset) {
+		t.Fatal("expected invalid offset, got", err)
+	}
+	section, err := io.ReadAll(io.NewSectionReader(buf, 5, 3))
+	if err != nil || string(section) != "567" {
+		t.Fatal("unexpected section", string(section), err)
+	}
+	if buf.Len() != 10 {
+		t.Fatal("read at consumed", buf.Len())
+	}
+}
+
+func TestBuffer_WriteAt(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	_, _ = buf.WriteString("xx0123456789")
+	buf.Discard(2)
+	if n, err := buf.WriteAt([]byte("ab"), 2); err != nil || n != 2 {
+		t.Fatal("unexpected write at", n, err)
+	}
+	// extends the readable content and fills the gap with zeros
+	if n, err := buf.WriteAt([]byte("cd"), 12); err != nil || n != 2 {
+		t.Fatal("unexpected write at", n, err)
+	}
+	if string(buf.CloneBytes()) != "01ab456789\x00\x00cd" {
+		t.Fatalf("unexpected bytes %q", buf.CloneBytes())
+	}
+	if _, err := buf.WriteAt([]byte("x"), -1); !errors.Is(err, bytebuffers.ErrInvalidOffset) {
+		t.Fatal("expected invalid offset, got", err)
+	}
+	if _, err := bytebuffers.NewFixedBuffer(4).WriteAt([]byte("x"), 4); !errors.Is(err, bytebuffers.ErrBufferFull) {
+		t.Fatal("expected buffer full, got", err)
+	}
+}
+
+func TestBuffer_AtImplementations(t *testing.T) {
+	buffers := []bytebuffers.Buffer{
+		bytebuffers.NewConcurrentBuffer(),
+		bytebuffers.NewRingBuffer(8),
+		bytebuffers.NewChainBuffer(4),
+	}
+	for _, buf := range buffers {
+		_, _ = buf.WriteString("xxxxx01")
+		buf.Discard(5)
+		_, _ = buf.WriteString("2345")
+		if n, err := buf.WriteAt([]byte("ab"), 1); err != nil || n != 2 {
+			t.Fatal("unexpected write at", n, err)
+		}
+		if n, err := buf.WriteAt([]byte("cdef"), 8); err != nil || n != 4 {
+			t.Fatal("unexpected write at", n, err)
+		}
+		if n, err := buf.WriteAt([]byte("gh"), 11); err != nil || n != 2 {
+			t.Fatal("unexpected write at", n, err)
+		}
+		if string(buf.CloneBytes()) != "0ab345\x00\x00cdegh" {
+			t.Fatalf("unexpected bytes %q", buf.CloneBytes())
+		}
+		p := make([]byte, 6)
+		if n, err := buf.ReadAt(p, 2); err != nil || n != 6 || string(p) != "b345\x00\x00" {
+			t.Fatalf("unexpected read at %d %v %q", n, err, p)
+		}
+		if n, err := buf.ReadAt(p, 9); !errors.Is(err, io.EOF) || n != 4 || string(p[:n]) != "degh" {
+			t.Fatal("unexpected read at", n, err)
+		}
+	}
+	if _, err := bytebuffers.NewBuffer().ReadOnly().WriteAt([]byte("x"), 0); !errors.Is(err, bytebuffers.ErrReadOnly) {
+		t.Fatal("expected read only, got", err)
+	}
+}
+
+func TestBuffer_Seek(t *testing.T) {
+	buffers := []bytebuffers.Buffer{
+		bytebuffers.NewBuffer(),
+		bytebuffers.NewConcurrentBuffer(),
+		bytebuffers.NewRingBuffer(8),
+		bytebuffers.NewChainBuffer(4),
+		bytebuffers.NewFixedBuffer(16),
+	}
+	for _, buf := range buffers {
+		var seeker io.ReadSeeker = buf
+		// position 0 is the read position at the first Seek, bytes consumed before it are gone
+		_, _ = buf.WriteString("xxxxxhead")
+		buf.Discard(5)
+		_, _ = buf.WriteString("er:body")
+		if pos, err := seeker.Seek(0, io.SeekCurrent); err != nil || pos != 0 || buf.Len() != 11 {
+			t.Fatalf("%T: unexpected position %d %v %d", buf, pos, err, buf.Len())
+		}
+		if pos, err := seeker.Seek(-4, io.SeekEnd); err != nil || pos != 7 || string(buf.CloneBytes()) != "body" {
+			t.Fatalf("%T: unexpected seek %d %v %q", buf, pos, err, buf.CloneBytes())
+		}
+		// rewind after a forward seek
+		if pos, err := seeker.Seek(0, io.SeekStart); err != nil || pos != 0 || string(buf.CloneBytes()) != "header:body" {
+			t.Fatalf("%T: unexpected rewind %d %v %q", buf, pos, err, buf.CloneBytes())
+		}
+		// reads advance the position and stay seekable
+		p := make([]byte, 7)
+		if _, err := io.ReadFull(seeker, p); err != nil || string(p) != "header:" {
+			t.Fatalf("%T: unexpected read %q %v", buf, p, err)
+		}
+		if pos, err := seeker.Seek(-1, io.SeekCurrent); err != nil || pos != 6 || string(buf.CloneBytes()) != ":body" {
+			t.Fatalf("%T: unexpected seek %d %v %q", buf, pos, err, buf.CloneBytes())
+		}
+		if b, err := buf.ReadByte(); err != nil || b != ':' {
+			t.Fatalf("%T: unexpected byte %q %v", buf, b, err)
+		}
+		if err := buf.UnreadByte(); err != nil {
+			t.Fatalf("%T: unexpected unread %v", buf, err)
+		}
+		if pos, err := seeker.Seek(0, io.SeekCurrent); err != nil || pos != 6 {
+			t.Fatalf("%T: unexpected position %d %v", buf, pos, err)
+		}
+		// seeking to the end keeps the buffered bytes
+		if pos, err := seeker.Seek(0, io.SeekEnd); err != nil || pos != 11 || buf.Len() != 0 {
+			t.Fatalf("%T: unexpected seek %d %v %d", buf, pos, err, buf.Len())
+		}
+		if pos, err := seeker.Seek(0, io.SeekStart); err != nil || pos != 0 || string(buf.CloneBytes()) != "header:body" {
+			t.Fatalf("%T: unexpected rewind %d %v %q", buf, pos, err, buf.CloneBytes())
+		}
+		if _, err := seeker.Seek(-1, io.SeekCurrent); !errors.Is(err, bytebuffers.ErrInvalidOffset) {
+			t.Fatalf("%T: expected invalid offset, got %v", buf, err)
+		}
+		if _, err := seeker.Seek(12, io.SeekStart); !errors.Is(err, bytebuffers.ErrInvalidOffset) {
+			t.Fatalf("%T: expected invalid offset, got %v", buf, err)
+		}
+		if _, err := seeker.Seek(0, 3); !errors.Is(err, bytebuffers.ErrInvalidWhence) {
+			t.Fatalf("%T: expected invalid whence, got %v", buf, err)
+		}
+		// positions keep counting after the read bytes are released
+		if _, err := io.ReadAll(seeker); err != nil {
+			t.Fatal(err)
+		}
+		_, _ = buf.WriteString("!")
+		if pos, err := seeker.Seek(0, io.SeekCurrent); err != nil || pos != 11 || string(buf.CloneBytes()) != "!" {
+			t.Fatalf("%T: unexpected seek %d %v %q", buf, pos, err, buf.CloneBytes())
+		}
+		// Reset drops the origin
+		buf.Reset()
+		_, _ = buf.WriteString("ab")
+		buf.Discard(1)
+		if pos, err := seeker.Seek(0, io.SeekEnd); err != nil || pos != 1 {
+			t.Fatalf("%T: unexpected seek %d %v", buf, pos, err)
+		}
+	}
+}
+
+func TestBuffer_SeekBoundedMemory(t *testing.T) {
+	buffers := []bytebuffers.Buffer{
+		bytebuffers.NewBufferWithCapacityHint(8),
+		bytebuffers.NewFixedBuffer(128),
+		bytebuffers.NewRingBuffer(8),
+		bytebuffers.NewChainBuffer(4),
+	}
+	p := make([]byte, 64)
+	for _, buf := range buffers {
+		if _, err := buf.Seek(0, io.SeekCurrent); err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 1000; i++ {
+			if _, err := buf.Write(p); err != nil {
+				t.Fatalf("%T: unexpected write %v", buf, err)
+			}
+			if _, err := io.ReadFull(buf, p); err != nil {
+				t.Fatalf("%T: unexpected read %v", buf, err)
+			}
+		}
+		// the content read before the last compaction is released
+		if c := buf.Capacity(); c > 256 {
+			t.Fatalf("%T: unexpected capacity %d", buf, c)
+		}
+		if _, err := buf.Seek(0, io.SeekStart); !errors.Is(err, bytebuffers.ErrInvalidOffset) {
+			t.Fatalf("%T: expected invalid offset, got %v", buf, err)
+		}
+		if pos, err := buf.Seek(-64, io.SeekCurrent); err != nil || pos != 63936 || buf.Len() != 64 {
+			t.Fatalf("%T: unexpected seek %d %v %d", buf, pos, err, buf.Len())
+		}
+	}
+}
@@ -0,0 +1,294 @@
+package bytebuffers
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"strings"
+	"time"
+)
+
+const (
+	oscBundleTag = "#bundle\x00"
+	oscImmediate = 1
+)
+
+var (
+	ErrOSCInvalid         = errors.New("bytebuffers.OSC: invalid packet")
+	ErrOSCUnsupportedType = errors.New("bytebuffers.OSC: unsupported argument type")
+)
+
+// OSCMessage
+// OSC 消息（地址与参数）。
+type OSCMessage struct {
+	Address string
+	Args    []interface{}
+}
+
+// WriteOSCMessage
+// 写入 OSC 消息：以 NUL 填充至 4 字节的地址、类型标签（如 ",iff"）与参数。
+//
+// 支持的参数类型：int32（i）、float32（f）、string（s）、[]byte（b）、int64（h）、float64（d）、bool（T/F）、nil（N）。
+func WriteOSCMessage(buf Buffer, address string, args ...interface{}) (err error) {
+	size, sizeErr := oscMessageSize(address, args)
+	if sizeErr != nil {
+		err = sizeErr
+		return
+	}
+	p, borrowErr := buf.Borrow(size)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	appendOSCMessage(p[:0], address, args)
+	buf.Return(size)
+	return
+}
+
+// WriteOSCBundle
+// 写入 OSC bundle："#bundle\0"、NTP 格式的时间标签与带 4 字节长度前缀的消息。
+//
+// time 为零值时时间标签为 1（立即执行）。
+func WriteOSCBundle(buf Buffer, time time.Time, messages []OSCMessage) (err error) {
+	size := len(oscBundleTag) + 8
+	sizes := make([]int, len(messages))
+	for i, msg := range messages {
+		if sizes[i], err = oscMessageSize(msg.Address, msg.Args); err != nil {
+			return
+		}
+		size += 4 + sizes[i]
+	}
+	p, borrowErr := buf.Borrow(size)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	p = append(p[:0], oscBundleTag...)
+	timetag := uint64(oscImmediate)
+	if !time.IsZero() {
+		timetag = ntpTimestamp(time)
+	}
+	p = binary.BigEndian.AppendUint64(p, timetag)
+	for i, msg := range messages {
+		p = binary.BigEndian.AppendUint32(p, uint32(sizes[i]))
+		p = appendOSCMessage(p, msg.Address, msg.Args)
+	}
+	buf.Return(size)
+	return
+}
+
+// ReadOSCMessage
+// 读取一个 OSC 消息，返回地址与按类型标签解析的参数。
+//
+// 参数类型与 WriteOSCMessage 对应，失败或不完整时不读掉。
+func ReadOSCMessage(buf Buffer) (address string, args []interface{}, err error) {
+	p := buf.Peek(buf.Len())
+	if len(p) == 0 {
+		err = io.EOF
+		return
+	}
+	addr, n, strErr := readOSCString(p, 0)
+	if strErr != nil {
+		err = strErr
+		return
+	}
+	if len(addr) == 0 || addr[0] != '/' {
+		err = ErrOSCInvalid
+		return
+	}
+	tags, n, strErr := readOSCString(p, n)
+	if strErr != nil {
+		err = strErr
+		return
+	}
+	if len(tags) == 0 || tags[0] != ',' {
+		err = ErrOSCInvalid
+		return
+	}
+	args = make([]interface{}, 0, len(tags)-1)
+	for _, tag := range tags[1:] {
+		var arg interface{}
+		switch tag {
+		case 'i', 'f':
+			if len(p)-n < 4 {
+				args, err = nil, io.ErrUnexpectedEOF
+				return
+			}
+			v := binary.BigEndian.Uint32(p[n:])
+			if tag == 'i' {
+				arg = int32(v)
+			} else {
+				arg = math.Float32frombits(v)
+			}
+			n += 4
+		case 'h', 'd':
+			if len(p)-n < 8 {
+				args, err = nil, io.ErrUnexpectedEOF
+				return
+			}
+			v := binary.BigEndian.Uint64(p[n:])
+			if tag == 'h' {
+				arg = int64(v)
+			} else {
+				arg = math.Float64frombits(v)
+			}
+			n += 8
+		case 's':
+			var s []byte
+			if s, n, err = readOSCString(p, n); err != nil {
+				args = nil
+				return
+			}
+			arg = string(s)
+		case 'b':
+			if len(p)-n < 4 {
+				args, err = nil, io.ErrUnexpectedEOF
+				return
+			}
+			size := uint64(binary.BigEndian.Uint32(p[n:]))
+			if size > uint64(len(p)-n-4) {
+				args, err = nil, io.ErrUnexpectedEOF
+				return
+			}
+			blob := make([]byte, size)
+			copy(blob, p[n+4:])
+			n += oscPad(4 + int(size))
+			if n > len(p) {
+				args, err = nil, io.ErrUnexpectedEOF
+				return
+			}
+			arg = blob
+		case 'T':
+			arg = true
+		case 'F':
+			arg = false
+		case 'N':
+		default:
+			args, err = nil, ErrOSCUnsupportedType
+			return
+		}
+		args = append(args, arg)
+	}
+	address = string(addr)
+	buf.Discard(n)
+	return
+}
+
+// readOSCString
+// 从 p[off:] 读取以 NUL 结尾并填充至 4 字节的字符串。
+func readOSCString(p []byte, off int) (s []byte, next int, err error) {
+	i := bytes.IndexByte(p[off:], 0)
+	if i < 0 {
+		err = io.ErrUnexpectedEOF
+		return
+	}
+	if next = off + oscPad(i+1); next > len(p) {
+		err = io.ErrUnexpectedEOF
+		return
+	}
+	s = p[off : off+i]
+	return
+}
+
+// oscPad
+// 向上对齐至 4 字节。
+func oscPad(n int) int {
+	return (n + 3) &^ 3
+}
+
+func oscMessageSize(address string, args []interface{}) (size int, err error) {
+	if len(address) == 0 || address[0] != '/' || strings.IndexByte(address, 0) >= 0 {
+		err = ErrOSCInvalid
+		return
+	}
+	size = oscPad(len(address)+1) + oscPad(len(args)+2)
+	for _, arg := range args {
+		switch v := arg.(type) {
+		case int32, float32:
+			size += 4
+		case int64, float64:
+			size += 8
+		case string:
+			if strings.IndexByte(v, 0) >= 0 {
+				err = ErrOSCInvalid
+				return
+			}
+			size += oscPad(len(v) + 1)
+		case []byte:
+			if uint64(len(v)) > math.MaxUint32 {
+				err = ErrTooLarge
+				return
+			}
+			size += oscPad(4 + len(v))
+		case bool, nil:
+		default:
+			err = ErrOSCUnsupportedType
+			return
+		}
+	}
+	return
+}
+
+// appendOSCMessage
+// 追加消息，参数须已经过 oscMessageSize 校验。
+func appendOSCMessage(p []byte, address string, args []interface{}) []byte {
+	p = appendOSCString(p, address)
+	tags := make([]byte, 1, len(args)+1)
+	tags[0] = ','
+	for _, arg := range args {
+		switch v := arg.(type) {
+		case int32:
+			tags = append(tags, 'i')
+		case float32:
+			tags = append(tags, 'f')
+		case int64:
+			tags = append(tags, 'h')
+		case float64:
+			tags = append(tags, 'd')
+		case string:
+			tags = append(tags, 's')
+		case []byte:
+			tags = append(tags, 'b')
+		case bool:
+			if v {
+				tags = append(tags, 'T')
+			} else {
+				tags = append(tags, 'F')
+			}
+		case nil:
+			tags = append(tags, 'N')
+		}
+	}
+	p = appendOSCString(p, string(tags))
+	for _, arg := range args {
+		switch v := arg.(type) {
+		case int32:
+			p = binary.BigEndian.AppendUint32(p, uint32(v))
+		case float32:
+			p = binary.BigEndian.AppendUint32(p, math.Float32bits(v))
+		case int64:
+			p = binary.BigEndian.AppendUint64(p, uint64(v))
+		case float64:
+			p = binary.BigEndian.AppendUint64(p, math.Float64bits(v))
+		case string:
+			p = appendOSCString(p, v)
+		case []byte:
+			p = binary.BigEndian.AppendUint32(p, uint32(len(v)))
+			p = append(p, v...)
+			for n := oscPad(len(v)) - len(v); n > 0; n-- {
+				p = append(p, 0)
+			}
+		}
+	}
+	return p
+}
+
+func appendOSCString(p []byte, s string) []byte {
+	p = append(p, s...)
+	for n := oscPad(len(s)+1) - len(s); n > 0; n-- {
+		p = append(p, 0)
+	}
+	return p
+}
@@ -0,0 +1,104 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestOSCMessage(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	// example from the OSC 1.0 specification
+	if err := bytebuffers.WriteOSCMessage(buf, "/foo", int32(1000), int32(-1), "hello", float32(1.234), float32(5.678)); err != nil {
+		t.Fatal(err)
+	}
+	expected := []byte{
+		'/', 'f', 'o', 'o', 0, 0, 0, 0,
+		',', 'i', 'i', 's', 'f', 'f', 0, 0,
+		0x00, 0x00, 0x03, 0xE8,
+		0xFF, 0xFF, 0xFF, 0xFF,
+		'h', 'e', 'l', 'l', 'o', 0, 0, 0,
+		0x3F, 0x9D, 0xF3, 0xB6,
+		0x40, 0xB5, 0xB2, 0x2D,
+	}
+	if p := buf.CloneBytes(); !bytes.Equal(p, expected) {
+		t.Fatal("unexpected message", p)
+	}
+
+	address, args, err := bytebuffers.ReadOSCMessage(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if address != "/foo" || len(args) != 5 {
+		t.Fatal("unexpected message", address, args)
+	}
+	if args[0] != int32(1000) || args[1] != int32(-1) || args[2] != "hello" || args[3] != float32(1.234) || args[4] != float32(5.678) {
+		t.Fatal("unexpected args", args)
+	}
+	if buf.Len() != 0 {
+		t.Fatal("message must be discarded")
+	}
+
+	if err = bytebuffers.WriteOSCMessage(buf, "/blob", []byte{1, 2, 3, 4, 5}, true, nil, int64(-2), 0.5); err != nil {
+		t.Fatal(err)
+	}
+	if size := buf.Len(); size%4 != 0 || size != 8+8+12+8+8 {
+		t.Fatal("unexpected message size", size)
+	}
+	if _, args, err = bytebuffers.ReadOSCMessage(buf); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(args[0].([]byte), []byte{1, 2, 3, 4, 5}) || args[1] != true || args[2] != nil || args[3] != int64(-2) || args[4] != 0.5 {
+		t.Fatal("unexpected args", args)
+	}
+
+	if err = bytebuffers.WriteOSCMessage(buf, "/foo", 1); !errors.Is(err, bytebuffers.ErrOSCUnsupportedType) {
+		t.Fatal("expected unsupported type, got", err)
+	}
+	_, _ = buf.Write(expected[:len(expected)-1])
+	if _, _, err = bytebuffers.ReadOSCMessage(buf); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal("expected unexpected EOF, got", err)
+	}
+}
+
+func TestWriteOSCBundle(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	at := time.Date(2024, time.March, 15, 12, 0, 0, 500_000_000, time.UTC)
+	err := bytebuffers.WriteOSCBundle(buf, at, []bytebuffers.OSCMessage{
+		{Address: "/a", Args: []interface{}{int32(1)}},
+		{Address: "/b"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := buf.CloneBytes()
+	if !bytes.Equal(p[:8], []byte("#bundle\x00")) {
+		t.Fatal("unexpected bundle tag", p[:8])
+	}
+	if timetag := binary.BigEndian.Uint64(p[8:]); timetag != 0xE99EB6C0_80000000 {
+		t.Fatalf("unexpected timetag %#x", timetag)
+	}
+	if size := binary.BigEndian.Uint32(p[16:]); size != 12 {
+		t.Fatal("unexpected message size", size)
+	}
+	buf.Discard(20)
+	if address, args, err := bytebuffers.ReadOSCMessage(buf); err != nil || address != "/a" || args[0] != int32(1) {
+		t.Fatal("unexpected message", address, args, err)
+	}
+	if size := binary.BigEndian.Uint32(buf.Peek(4)); size != 8 {
+		t.Fatal("unexpected message size", size)
+	}
+	buf.Reset()
+
+	if err = bytebuffers.WriteOSCBundle(buf, time.Time{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if p = buf.CloneBytes(); binary.BigEndian.Uint64(p[8:]) != 1 {
+		t.Fatal("zero time must be immediate")
+	}
+}
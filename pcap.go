@@ -0,0 +1,64 @@
+package bytebuffers
+
+import (
+	"encoding/binary"
+	"math"
+	"time"
+)
+
+const (
+	PCAPLinkTypeNull     = 0
+	PCAPLinkTypeEthernet = 1
+	PCAPLinkTypeRaw      = 101
+	PCAPLinkTypeLinuxSLL = 113
+
+	pcapMagic           = 0xA1B2C3D4
+	pcapVersionMajor    = 2
+	pcapVersionMinor    = 4
+	pcapSnapLen         = 262144
+	pcapGlobalHeaderLen = 24
+	pcapRecordHeaderLen = 16
+)
+
+// WritePCAPGlobalHeader
+// 写入 24 字节的 pcap 文件头：魔数 0xA1B2C3D4、版本 2.4、时区与精度为 0、snaplen 为 262144 以及链路类型。
+//
+// 所有字段为小端，读取方通过魔数识别字节序。
+func WritePCAPGlobalHeader(buf Buffer, linkType uint32) (err error) {
+	p, borrowErr := buf.Borrow(pcapGlobalHeaderLen)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	binary.LittleEndian.PutUint32(p, pcapMagic)
+	binary.LittleEndian.PutUint16(p[4:], pcapVersionMajor)
+	binary.LittleEndian.PutUint16(p[6:], pcapVersionMinor)
+	binary.LittleEndian.PutUint32(p[8:], 0)  // thiszone
+	binary.LittleEndian.PutUint32(p[12:], 0) // sigfigs
+	binary.LittleEndian.PutUint32(p[16:], pcapSnapLen)
+	binary.LittleEndian.PutUint32(p[20:], linkType)
+	buf.Return(pcapGlobalHeaderLen)
+	return
+}
+
+// WritePCAPRecord
+// 写入 16 字节的包头（秒、微秒、捕获长度、原始长度）与包内容，捕获长度与原始长度均为 len(pkt)。
+func WritePCAPRecord(buf Buffer, ts time.Time, pkt []byte) (err error) {
+	if uint64(len(pkt)) > math.MaxUint32 || len(pkt) > maxInt-pcapRecordHeaderLen {
+		err = ErrTooLarge
+		return
+	}
+	size := pcapRecordHeaderLen + len(pkt)
+	p, borrowErr := buf.Borrow(size)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	binary.LittleEndian.PutUint32(p, uint32(ts.Unix()))
+	binary.LittleEndian.PutUint32(p[4:], uint32(ts.Nanosecond()/1000))
+	binary.LittleEndian.PutUint32(p[8:], uint32(len(pkt)))
+	binary.LittleEndian.PutUint32(p[12:], uint32(len(pkt)))
+	copy(p[pcapRecordHeaderLen:], pkt)
+	buf.Return(size)
+	return
+}
@@ -0,0 +1,63 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestWritePCAP(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	if err := bytebuffers.WritePCAPGlobalHeader(buf, bytebuffers.PCAPLinkTypeEthernet); err != nil {
+		t.Fatal(err)
+	}
+	dst, _ := net.ParseMAC("ff:ff:ff:ff:ff:ff")
+	src, _ := net.ParseMAC("00:11:22:33:44:55")
+	start := time.Date(2024, time.March, 15, 12, 0, 0, 123456789, time.UTC)
+	frames := make([][]byte, 3)
+	for i := range frames {
+		frame := bytebuffers.NewBuffer()
+		if err := bytebuffers.WriteEthernetFrame(frame, dst, src, bytebuffers.EtherTypeIPv4, bytes.Repeat([]byte{byte(i)}, 46+i)); err != nil {
+			t.Fatal(err)
+		}
+		frames[i] = frame.CloneBytes()
+		if err := bytebuffers.WritePCAPRecord(buf, start.Add(time.Duration(i)*time.Second), frames[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// read back the way libpcap does
+	p := buf.CloneBytes()
+	if !bytes.Equal(p[:4], []byte{0xD4, 0xC3, 0xB2, 0xA1}) {
+		t.Fatal("unexpected magic", p[:4])
+	}
+	le := binary.LittleEndian
+	if le.Uint16(p[4:]) != 2 || le.Uint16(p[6:]) != 4 || le.Uint32(p[8:]) != 0 || le.Uint32(p[12:]) != 0 {
+		t.Fatal("unexpected version, zone or sigfigs", p[:16])
+	}
+	if le.Uint32(p[16:]) < 1514 || le.Uint32(p[20:]) != bytebuffers.PCAPLinkTypeEthernet {
+		t.Fatal("unexpected snaplen or link type", p[16:24])
+	}
+	p = p[24:]
+	for i, frame := range frames {
+		ts := time.Unix(int64(le.Uint32(p)), int64(le.Uint32(p[4:]))*1000).UTC()
+		if expected := start.Add(time.Duration(i) * time.Second).Truncate(time.Microsecond); !ts.Equal(expected) {
+			t.Fatal("unexpected timestamp", ts, expected)
+		}
+		inclLen, origLen := int(le.Uint32(p[8:])), int(le.Uint32(p[12:]))
+		if inclLen != len(frame) || origLen != len(frame) {
+			t.Fatal("unexpected lengths", inclLen, origLen)
+		}
+		if !bytes.Equal(p[16:16+inclLen], frame) {
+			t.Fatal("unexpected packet", i)
+		}
+		p = p[16+inclLen:]
+	}
+	if len(p) != 0 {
+		t.Fatal("unexpected trailing bytes", len(p))
+	}
+}
@@ -0,0 +1,31 @@
+package bytebuffers
+
+import (
+	"encoding/pem"
+	"errors"
+)
+
+var (
+	ErrPEMNotFound = errors.New("bytebuffers.PEM: no pem block found")
+)
+
+// WritePEM
+// 以 PEM 编码可读内容（如 DER）并替换之，pemType 为块类型，如 "CERTIFICATE"。
+func WritePEM(buf Buffer, pemType string) (err error) {
+	p := pem.EncodeToMemory(&pem.Block{Type: pemType, Bytes: buf.Peek(buf.Len())})
+	err = buf.Set(p)
+	return
+}
+
+// ReadPEM
+// 读取下一个 PEM 块，并读掉该块及其之前的内容。未找到时返回 ErrPEMNotFound 且不读掉。
+func ReadPEM(buf Buffer) (block *pem.Block, err error) {
+	p := buf.Peek(buf.Len())
+	block, rest := pem.Decode(p)
+	if block == nil {
+		err = ErrPEMNotFound
+		return
+	}
+	buf.Discard(len(p) - len(rest))
+	return
+}
@@ -0,0 +1,57 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestWritePEM(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf := bytebuffers.NewBuffer()
+	_, _ = buf.Write(der)
+	if err = bytebuffers.WritePEM(buf, "PUBLIC KEY"); err != nil {
+		t.Fatal(err)
+	}
+	expected := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+	if !bytes.Equal(buf.CloneBytes(), expected) {
+		t.Fatal("unexpected pem", string(buf.CloneBytes()))
+	}
+
+	_, _ = buf.Write(expected)
+	for i := 0; i < 2; i++ {
+		block, readErr := bytebuffers.ReadPEM(buf)
+		if readErr != nil {
+			t.Fatal(readErr)
+		}
+		if block.Type != "PUBLIC KEY" || !bytes.Equal(block.Bytes, der) {
+			t.Fatal("round trip mismatch")
+		}
+	}
+	if buf.Len() != 0 {
+		t.Fatal("pem blocks not consumed", buf.Len())
+	}
+}
+
+func TestReadPEM(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	_, _ = buf.WriteString("-----BEGIN PUBLIC KEY-----\nAAAA\n")
+	if _, err := bytebuffers.ReadPEM(buf); !errors.Is(err, bytebuffers.ErrPEMNotFound) {
+		t.Fatal("expected not found, got", err)
+	}
+	if buf.Len() == 0 {
+		t.Fatal("incomplete block consumed")
+	}
+}
@@ -0,0 +1,125 @@
+package bytebuffers
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+const (
+	OpenPGPTagSignature   = 2
+	OpenPGPTagSecretKey   = 5
+	OpenPGPTagPublicKey   = 6
+	OpenPGPTagCompressed  = 8
+	OpenPGPTagLiteralData = 11
+	OpenPGPTagUserID      = 13
+)
+
+var (
+	ErrOpenPGPInvalid       = errors.New("bytebuffers.OpenPGP: invalid packet header")
+	ErrOpenPGPPartialLength = errors.New("bytebuffers.OpenPGP: partial body length is not supported")
+)
+
+// WriteOpenPGPPacketHeader
+// 写入新格式的 OpenPGP 包头：ctb 为 0xC0|tag，随后按长度为 1、2 或 5 字节的包体长度（RFC 4880 4.2.2）。
+func WriteOpenPGPPacketHeader(buf Buffer, tag byte, bodyLength int) (err error) {
+	if tag > 63 || bodyLength < 0 {
+		err = ErrOpenPGPInvalid
+		return
+	}
+	if uint64(bodyLength) > math.MaxUint32 {
+		err = ErrTooLarge
+		return
+	}
+	size := 2
+	switch {
+	case bodyLength < 192:
+	case bodyLength < 8384:
+		size = 3
+	default:
+		size = 6
+	}
+	p, borrowErr := buf.Borrow(size)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	p[0] = 0xC0 | tag
+	switch size {
+	case 2:
+		p[1] = byte(bodyLength)
+	case 3:
+		n := bodyLength - 192
+		p[1] = byte(n>>8) + 192
+		p[2] = byte(n)
+	default:
+		p[1] = 0xFF
+		binary.BigEndian.PutUint32(p[2:], uint32(bodyLength))
+	}
+	buf.Return(size)
+	return
+}
+
+// ReadOpenPGPPacketHeader
+// 读取新格式或旧格式的 OpenPGP 包头，只读掉包头。
+//
+// 旧格式的不定长度返回 bodyLength 为 -1，新格式的分段长度返回 ErrOpenPGPPartialLength 且不读掉。
+func ReadOpenPGPPacketHeader(buf Buffer) (tag byte, bodyLength int, err error) {
+	p, peekErr := peekFull(buf, 1)
+	if peekErr != nil {
+		err = peekErr
+		return
+	}
+	ctb := p[0]
+	if ctb&0x80 == 0 {
+		err = ErrOpenPGPInvalid
+		return
+	}
+	var size int
+	if ctb&0x40 == 0 { // old format
+		switch ctb & 0x03 {
+		case 0:
+			size = 2
+		case 1:
+			size = 3
+		case 2:
+			size = 5
+		default:
+			tag, bodyLength = (ctb>>2)&0x0F, -1
+			buf.Discard(1)
+			return
+		}
+		if p, err = peekFull(buf, size); err != nil {
+			return
+		}
+		for _, b := range p[1:size] {
+			bodyLength = bodyLength<<8 | int(b)
+		}
+		tag = (ctb >> 2) & 0x0F
+		buf.Discard(size)
+		return
+	}
+	if p, err = peekFull(buf, 2); err != nil {
+		return
+	}
+	switch first := int(p[1]); {
+	case first < 192:
+		size, bodyLength = 2, first
+	case first < 224:
+		if p, err = peekFull(buf, 3); err != nil {
+			return
+		}
+		size, bodyLength = 3, (first-192)<<8+int(p[2])+192
+	case first < 255:
+		err = ErrOpenPGPPartialLength
+		return
+	default:
+		if p, err = peekFull(buf, 6); err != nil {
+			return
+		}
+		size, bodyLength = 6, int(binary.BigEndian.Uint32(p[2:]))
+	}
+	tag = ctb & 0x3F
+	buf.Discard(size)
+	return
+}
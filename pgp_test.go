@@ -0,0 +1,77 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestWriteOpenPGPPacketHeader(t *testing.T) {
+	// RFC 4880 4.2.3 examples
+	cases := []struct {
+		length  int
+		encoded []byte
+	}{
+		{1, []byte{0xCB, 0x01}},
+		{100, []byte{0xCB, 0x64}},
+		{191, []byte{0xCB, 0xBF}},
+		{192, []byte{0xCB, 0xC0, 0x00}},
+		{1723, []byte{0xCB, 0xC5, 0xFB}},
+		{8383, []byte{0xCB, 0xDF, 0xFF}},
+		{8384, []byte{0xCB, 0xFF, 0x00, 0x00, 0x20, 0xC0}},
+		{100000, []byte{0xCB, 0xFF, 0x00, 0x01, 0x86, 0xA0}},
+	}
+	buf := bytebuffers.NewBuffer()
+	for _, c := range cases {
+		if err := bytebuffers.WriteOpenPGPPacketHeader(buf, bytebuffers.OpenPGPTagLiteralData, c.length); err != nil {
+			t.Fatal(err)
+		}
+		if p := buf.CloneBytes(); !bytes.Equal(p, c.encoded) {
+			t.Fatal("unexpected header for length", c.length, p)
+		}
+		tag, length, err := bytebuffers.ReadOpenPGPPacketHeader(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tag != bytebuffers.OpenPGPTagLiteralData || length != c.length || buf.Len() != 0 {
+			t.Fatal("unexpected header", tag, length)
+		}
+	}
+
+	if err := bytebuffers.WriteOpenPGPPacketHeader(buf, 64, 1); !errors.Is(err, bytebuffers.ErrOpenPGPInvalid) {
+		t.Fatal("expected invalid, got", err)
+	}
+}
+
+func TestReadOpenPGPPacketHeader(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	// old format: tag 2 with one, two and four octet lengths, then indeterminate
+	_, _ = buf.Write([]byte{0x88, 0x10, 0x89, 0x01, 0x00, 0x8A, 0x00, 0x01, 0x00, 0x00, 0x8B})
+	for _, expected := range []int{0x10, 0x100, 0x10000, -1} {
+		tag, length, err := bytebuffers.ReadOpenPGPPacketHeader(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tag != bytebuffers.OpenPGPTagSignature || length != expected {
+			t.Fatal("unexpected header", tag, length)
+		}
+	}
+
+	_, _ = buf.Write([]byte{0xCB, 0xE1})
+	if _, _, err := bytebuffers.ReadOpenPGPPacketHeader(buf); !errors.Is(err, bytebuffers.ErrOpenPGPPartialLength) {
+		t.Fatal("expected partial length, got", err)
+	}
+	buf.Reset()
+	_, _ = buf.Write([]byte{0xCB, 0xFF, 0x00})
+	if _, _, err := bytebuffers.ReadOpenPGPPacketHeader(buf); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal("expected unexpected EOF, got", err)
+	}
+	buf.Reset()
+	_, _ = buf.Write([]byte{0x3F})
+	if _, _, err := bytebuffers.ReadOpenPGPPacketHeader(buf); !errors.Is(err, bytebuffers.ErrOpenPGPInvalid) {
+		t.Fatal("expected invalid, got", err)
+	}
+}
@@ -28,12 +28,13 @@ var defaultBufferPool = BufferPool{
 
 // Acquire
 // 请求一个 Buffer。
-func Acquire() Buffer { return defaultBufferPool.Acquire() }
+func Acquire() Buffer { return defaultLocalPool.Acquire() }
 
 // Release
 // 回收 Buffer，只有当 Buffer.Reset 成功才回收，否则关闭并丢弃。
 // 即无可读或无未完成分配的情况下可回收。
-func Release(b Buffer) { defaultBufferPool.Release(b) }
+// 固定容量及非 NewBuffer 创建的 Buffer 不回收。
+func Release(b Buffer) { defaultLocalPool.Release(b) }
 
 // Pool
 // 创建一个缓冲池。
@@ -78,26 +79,28 @@ func (p *BufferPool) Acquire() Buffer {
 }
 
 func (p *BufferPool) Release(b Buffer) {
-	if b == nil {
+	if local, ok := b.(*buffer); !ok || local.fixed { // only growable buffers are pooled
 		return
 	}
-	if ok := b.Reset(); ok {
-		bCap := b.Capacity()
-		if bCap >= maxSize {
-			return
-		}
+	if ok := b.Reset(); ok && p.admit(b.Capacity()) {
+		p.pool.Put(b)
+	}
+}
 
-		idx := p.index(bCap)
-		if atomic.AddUint64(&p.calls[idx], 1) > calibrateCallsThreshold {
-			p.calibrate()
-		}
+// admit
+// 记录容量用于校准，返回该容量的 Buffer 是否可以回收，即不超过校准后的最大容量。
+func (p *BufferPool) admit(bCap int) bool {
+	if bCap >= maxSize {
+		return false
+	}
 
-		size := int(atomic.LoadUint64(&p.maxSize))
-		if size == 0 || bCap <= size {
-			p.pool.Put(b)
-		}
-		return
+	idx := p.index(bCap)
+	if atomic.AddUint64(&p.calls[idx], 1) > calibrateCallsThreshold {
+		p.calibrate()
 	}
+
+	size := int(atomic.LoadUint64(&p.maxSize))
+	return size == 0 || bCap <= size
 }
 
 func (p *BufferPool) index(n int) int {
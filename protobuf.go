@@ -0,0 +1,65 @@
+package bytebuffers
+
+import (
+	"encoding/binary"
+)
+
+// protobuf wire types
+const (
+	ProtoWireVarint  = 0
+	ProtoWireFixed64 = 1
+	ProtoWireBytes   = 2
+	ProtoWireFixed32 = 5
+)
+
+// WriteProtoFixed32
+// 写入 protobuf wire type 5 的值（4 字节小端），用于 fixed32、sfixed32 与 float。
+func WriteProtoFixed32(buf Buffer, v uint32) (err error) {
+	p, borrowErr := buf.Borrow(4)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	binary.LittleEndian.PutUint32(p, v)
+	buf.Return(4)
+	return
+}
+
+// WriteProtoFixed64
+// 写入 protobuf wire type 1 的值（8 字节小端），用于 fixed64、sfixed64 与 double。
+func WriteProtoFixed64(buf Buffer, v uint64) (err error) {
+	p, borrowErr := buf.Borrow(8)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	binary.LittleEndian.PutUint64(p, v)
+	buf.Return(8)
+	return
+}
+
+// ReadProtoFixed32
+// 读取 protobuf wire type 5 的值，不完整时不读掉。
+func ReadProtoFixed32(buf Buffer) (v uint32, err error) {
+	p, peekErr := peekFull(buf, 4)
+	if peekErr != nil {
+		err = peekErr
+		return
+	}
+	v = binary.LittleEndian.Uint32(p)
+	buf.Discard(4)
+	return
+}
+
+// ReadProtoFixed64
+// 读取 protobuf wire type 1 的值，不完整时不读掉。
+func ReadProtoFixed64(buf Buffer) (v uint64, err error) {
+	p, peekErr := peekFull(buf, 8)
+	if peekErr != nil {
+		err = peekErr
+		return
+	}
+	v = binary.LittleEndian.Uint64(p)
+	buf.Discard(8)
+	return
+}
@@ -0,0 +1,62 @@
+package bytebuffers_test
+
+import (
+	"errors"
+	"io"
+	"math"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestWriteProtoFixed32(t *testing.T) {
+	if bytebuffers.ProtoWireFixed32 != int(protowire.Fixed32Type) || bytebuffers.ProtoWireFixed64 != int(protowire.Fixed64Type) {
+		t.Fatal("wire type mismatch")
+	}
+	values := []uint32{0, 1, 0x01020304, math.Float32bits(3.14), math.MaxUint32}
+	buf := bytebuffers.NewBuffer()
+	for _, v := range values {
+		if err := bytebuffers.WriteProtoFixed32(buf, v); err != nil {
+			t.Fatal(err)
+		}
+		if string(buf.CloneBytes()) != string(protowire.AppendFixed32(nil, v)) {
+			t.Fatal("encoding mismatch", v)
+		}
+		rv, err := bytebuffers.ReadProtoFixed32(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rv != v {
+			t.Fatal("round trip mismatch", rv, v)
+		}
+	}
+}
+
+func TestWriteProtoFixed64(t *testing.T) {
+	values := []uint64{0, 1, 0x0102030405060708, math.Float64bits(-2.5), math.MaxUint64}
+	buf := bytebuffers.NewBuffer()
+	for _, v := range values {
+		if err := bytebuffers.WriteProtoFixed64(buf, v); err != nil {
+			t.Fatal(err)
+		}
+		if string(buf.CloneBytes()) != string(protowire.AppendFixed64(nil, v)) {
+			t.Fatal("encoding mismatch", v)
+		}
+		rv, err := bytebuffers.ReadProtoFixed64(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rv != v {
+			t.Fatal("round trip mismatch", rv, v)
+		}
+	}
+
+	_, _ = buf.Write([]byte{1, 2, 3, 4, 5})
+	if _, err := bytebuffers.ReadProtoFixed64(buf); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal("expected unexpected EOF, got", err)
+	}
+	if buf.Len() != 5 {
+		t.Fatal("incomplete value consumed")
+	}
+}
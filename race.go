@@ -0,0 +1,5 @@
+//go:build race
+
+package bytebuffers
+
+const raceEnabled = true
@@ -0,0 +1,110 @@
+package bytebuffers
+
+import (
+	"crypto/md5"
+	"encoding/binary"
+	"errors"
+)
+
+const (
+	RadiusAccessRequest      = 1
+	RadiusAccessAccept       = 2
+	RadiusAccessReject       = 3
+	RadiusAccountingRequest  = 4
+	RadiusAccountingResponse = 5
+	RadiusAccessChallenge    = 11
+
+	RadiusUserName      = 1
+	RadiusUserPassword  = 2
+	RadiusNASIPAddress  = 4
+	RadiusNASPort       = 5
+	RadiusReplyMessage  = 18
+	RadiusNASIdentifier = 32
+
+	radiusHeaderLen = 20
+	radiusMaxLen    = 4096
+)
+
+var (
+	ErrRadiusInvalid = errors.New("bytebuffers.Radius: invalid packet")
+)
+
+// RadiusAttribute
+// RADIUS 属性（类型、值），值不超过 253 字节。
+type RadiusAttribute struct {
+	Type  byte
+	Value []byte
+}
+
+// WriteRadius
+// 写入 RADIUS 报文：代码、标识、长度、认证字与属性。
+//
+// 认证字为 MD5(Code+ID+Length+16 个 0+属性+secret)，即 RFC 2866 Accounting-Request 的算法。
+func WriteRadius(buf Buffer, code, id byte, secret string, attrs []RadiusAttribute) (err error) {
+	size := radiusHeaderLen
+	for _, attr := range attrs {
+		if len(attr.Value) > 253 {
+			err = ErrRadiusInvalid
+			return
+		}
+		size += 2 + len(attr.Value)
+	}
+	if size > radiusMaxLen {
+		err = ErrTooLarge
+		return
+	}
+	p, borrowErr := buf.Borrow(size)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	p[0] = code
+	p[1] = id
+	binary.BigEndian.PutUint16(p[2:], uint16(size))
+	clear(p[4:radiusHeaderLen])
+	n := radiusHeaderLen
+	for _, attr := range attrs {
+		p[n] = attr.Type
+		p[n+1] = byte(2 + len(attr.Value))
+		n += 2 + copy(p[n+2:], attr.Value)
+	}
+	h := md5.New()
+	h.Write(p[:size])
+	h.Write([]byte(secret))
+	h.Sum(p[4:4])
+	buf.Return(size)
+	return
+}
+
+// ReadRadius
+// 读取 RADIUS 报文并校验长度，返回代码、标识与属性，不校验认证字。
+//
+// 不完整时不读掉。
+func ReadRadius(buf Buffer) (code, id byte, attrs []RadiusAttribute, err error) {
+	p, peekErr := peekFull(buf, radiusHeaderLen)
+	if peekErr != nil {
+		err = peekErr
+		return
+	}
+	size := int(binary.BigEndian.Uint16(p[2:]))
+	if size < radiusHeaderLen || size > radiusMaxLen {
+		err = ErrRadiusInvalid
+		return
+	}
+	if p, err = peekFull(buf, size); err != nil {
+		return
+	}
+	for n := radiusHeaderLen; n < size; {
+		if n+2 > size || p[n+1] < 2 || n+int(p[n+1]) > size {
+			attrs, err = nil, ErrRadiusInvalid
+			return
+		}
+		value := make([]byte, p[n+1]-2)
+		copy(value, p[n+2:])
+		attrs = append(attrs, RadiusAttribute{Type: p[n], Value: value})
+		n += int(p[n+1])
+	}
+	code, id = p[0], p[1]
+	buf.Discard(size)
+	return
+}
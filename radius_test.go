@@ -0,0 +1,68 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestWriteRadius(t *testing.T) {
+	attrs := []bytebuffers.RadiusAttribute{
+		{Type: bytebuffers.RadiusUserName, Value: []byte("nemo")},
+		{Type: bytebuffers.RadiusNASIPAddress, Value: []byte{192, 168, 1, 16}},
+	}
+	buf := bytebuffers.NewBuffer()
+	if err := bytebuffers.WriteRadius(buf, bytebuffers.RadiusAccessRequest, 0x2A, "xyzzy5461", attrs); err != nil {
+		t.Fatal(err)
+	}
+	// authenticator computed by python hashlib.md5 over the same fields
+	expected, _ := hex.DecodeString("012a0020" + "21180abd8b9b7b50d31f19b8685a5498" + "0106" + hex.EncodeToString([]byte("nemo")) + "0406c0a80110")
+	if p := buf.CloneBytes(); !bytes.Equal(p, expected) {
+		t.Fatal("unexpected packet", hex.EncodeToString(p))
+	}
+
+	code, id, read, err := bytebuffers.ReadRadius(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code != bytebuffers.RadiusAccessRequest || id != 0x2A || len(read) != 2 {
+		t.Fatal("unexpected packet", code, id, read)
+	}
+	for i, attr := range read {
+		if attr.Type != attrs[i].Type || !bytes.Equal(attr.Value, attrs[i].Value) {
+			t.Fatal("unexpected attribute", attr)
+		}
+	}
+}
+
+func TestReadRadius(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	_ = bytebuffers.WriteRadius(buf, bytebuffers.RadiusAccessAccept, 1, "secret", []bytebuffers.RadiusAttribute{
+		{Type: bytebuffers.RadiusReplyMessage, Value: []byte("welcome")},
+	})
+	p := buf.CloneBytes()
+	buf.Reset()
+
+	_, _ = buf.Write(p[:len(p)-1])
+	if _, _, _, err := bytebuffers.ReadRadius(buf); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal("expected unexpected EOF, got", err)
+	}
+	buf.Reset()
+
+	p[21] = 200 // attribute length beyond the packet
+	_, _ = buf.Write(p)
+	if _, _, _, err := bytebuffers.ReadRadius(buf); !errors.Is(err, bytebuffers.ErrRadiusInvalid) {
+		t.Fatal("expected invalid, got", err)
+	}
+	buf.Reset()
+
+	p[3] = 19 // length below the header
+	_, _ = buf.Write(p)
+	if _, _, _, err := bytebuffers.ReadRadius(buf); !errors.Is(err, bytebuffers.ErrRadiusInvalid) {
+		t.Fatal("expected invalid, got", err)
+	}
+}
@@ -0,0 +1,206 @@
+package bytebuffers
+
+import (
+	"encoding/base32"
+	"errors"
+	"io"
+	"time"
+)
+
+var (
+	ErrReadOnly = errors.New("bytebuffers.Buffer: read only")
+)
+
+func (buf *buffer) ReadOnly() Buffer {
+	return readOnlyBuffer{buf}
+}
+
+func (c codec) ReadOnly() Buffer {
+	return readOnlyBuffer{c.buf}
+}
+
+// readOnlyBuffer
+// 只读视图，读取方法直接作用于原 Buffer，写入方法均返回 ErrReadOnly，Reset 返回 false，Return 无效。
+type readOnlyBuffer struct {
+	Buffer
+}
+
+func (buf readOnlyBuffer) ReadOnly() Buffer { return buf }
+
+func (buf readOnlyBuffer) Truncate(n int) (err error) {
+	err = ErrReadOnly
+	return
+}
+
+func (buf readOnlyBuffer) Write(p []byte) (n int, err error) {
+	err = ErrReadOnly
+	return
+}
+
+func (buf readOnlyBuffer) WriteAt(p []byte, off int64) (n int, err error) {
+	err = ErrReadOnly
+	return
+}
+
+func (buf readOnlyBuffer) WriteByte(c byte) (err error) {
+	err = ErrReadOnly
+	return
+}
+
+func (buf readOnlyBuffer) WriteString(s string) (n int, err error) {
+	err = ErrReadOnly
+	return
+}
+
+func (buf readOnlyBuffer) WriteRune(r rune) (n int, err error) {
+	err = ErrReadOnly
+	return
+}
+
+func (buf readOnlyBuffer) Set(p []byte) (err error) {
+	err = ErrReadOnly
+	return
+}
+
+func (buf readOnlyBuffer) SetString(s string) (err error) {
+	err = ErrReadOnly
+	return
+}
+
+func (buf readOnlyBuffer) ReadFrom(r io.Reader) (n int64, err error) {
+	err = ErrReadOnly
+	return
+}
+
+func (buf readOnlyBuffer) ReadFromWithHint(r io.Reader, hint int) (n int64, err error) {
+	err = ErrReadOnly
+	return
+}
+
+func (buf readOnlyBuffer) ReadFromLimited(r io.Reader, n int) (nn int, err error) {
+	err = ErrReadOnly
+	return
+}
+
+func (buf readOnlyBuffer) CopyFromReader(r io.Reader, n int) (nn int, err error) {
+	err = ErrReadOnly
+	return
+}
+
+func (buf readOnlyBuffer) Grow(n int) (err error) {
+	err = ErrReadOnly
+	return
+}
+
+func (buf readOnlyBuffer) Available() int { return 0 }
+
+func (buf readOnlyBuffer) AvailableBuffer() []byte { return nil }
+
+func (buf readOnlyBuffer) Commit(n int) (err error) {
+	err = ErrReadOnly
+	return
+}
+
+func (buf readOnlyBuffer) Borrow(size int) (p []byte, err error) {
+	err = ErrReadOnly
+	return
+}
+
+func (buf readOnlyBuffer) Return(used int) {}
+
+func (buf readOnlyBuffer) Reset() bool { return false }
+
+func (buf readOnlyBuffer) WriteUvarint(v uint64) (err error) {
+	err = ErrReadOnly
+	return
+}
+
+func (buf readOnlyBuffer) WriteZigzagVarint(v int64) (err error) {
+	err = ErrReadOnly
+	return
+}
+
+func (buf readOnlyBuffer) WriteVarBytes(p []byte) (err error) {
+	err = ErrReadOnly
+	return
+}
+
+func (buf readOnlyBuffer) WriteVarString(s string) (err error) {
+	err = ErrReadOnly
+	return
+}
+
+func (buf readOnlyBuffer) WriteMap(m map[string]string) (err error) {
+	err = ErrReadOnly
+	return
+}
+
+func (buf readOnlyBuffer) WriteSlice(elems [][]byte) (err error) {
+	err = ErrReadOnly
+	return
+}
+
+func (buf readOnlyBuffer) WriteTimestamp(t time.Time) (err error) {
+	err = ErrReadOnly
+	return
+}
+
+func (buf readOnlyBuffer) WriteUint16BE(v uint16) (err error) {
+	err = ErrReadOnly
+	return
+}
+
+func (buf readOnlyBuffer) WriteUint16LE(v uint16) (err error) {
+	err = ErrReadOnly
+	return
+}
+
+func (buf readOnlyBuffer) WriteUint32BE(v uint32) (err error) {
+	err = ErrReadOnly
+	return
+}
+
+func (buf readOnlyBuffer) WriteUint32LE(v uint32) (err error) {
+	err = ErrReadOnly
+	return
+}
+
+func (buf readOnlyBuffer) WriteUint64BE(v uint64) (err error) {
+	err = ErrReadOnly
+	return
+}
+
+func (buf readOnlyBuffer) WriteUint64LE(v uint64) (err error) {
+	err = ErrReadOnly
+	return
+}
+
+func (buf readOnlyBuffer) EncodeBase32(enc *base32.Encoding) (err error) {
+	err = ErrReadOnly
+	return
+}
+
+func (buf readOnlyBuffer) DecodeBase32(enc *base32.Encoding) (err error) {
+	err = ErrReadOnly
+	return
+}
+
+func (buf readOnlyBuffer) EncodeURL(padded bool) (err error) {
+	err = ErrReadOnly
+	return
+}
+
+func (buf readOnlyBuffer) DecodeURL(padded bool) (err error) {
+	err = ErrReadOnly
+	return
+}
+
+func (buf readOnlyBuffer) WriteBinaryFixed(v interface{}) (err error) {
+	err = ErrReadOnly
+	return
+}
+
+func (buf readOnlyBuffer) WriteGob(v interface{}) (err error) {
+	err = ErrReadOnly
+	return
+}
@@ -0,0 +1,71 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestBuffer_ReadOnly(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	_ = buf.WriteVarString("hello")
+	_, _ = buf.WriteString("world")
+	view := buf.ReadOnly()
+	if view.ReadOnly() != view {
+		t.Fatal("read only of a view must be itself")
+	}
+	if _, err := view.WriteString("x"); !errors.Is(err, bytebuffers.ErrReadOnly) {
+		t.Fatal("expected read only, got", err)
+	}
+	if _, err := view.ReadFrom(strings.NewReader("x")); !errors.Is(err, bytebuffers.ErrReadOnly) {
+		t.Fatal("expected read only, got", err)
+	}
+	if _, err := view.Borrow(1); !errors.Is(err, bytebuffers.ErrReadOnly) {
+		t.Fatal("expected read only, got", err)
+	}
+	if err := view.WriteUvarint(1); !errors.Is(err, bytebuffers.ErrReadOnly) {
+		t.Fatal("expected read only, got", err)
+	}
+	if err := view.EncodeURL(false); !errors.Is(err, bytebuffers.ErrReadOnly) {
+		t.Fatal("expected read only, got", err)
+	}
+	if view.Reset() {
+		t.Fatal("read only view was reset")
+	}
+	// reads share the read position with the origin
+	if s, err := view.ReadVarString(); err != nil || s != "hello" {
+		t.Fatal("unexpected string", s, err)
+	}
+	if buf.Len() != 5 || view.Len() != 5 || string(view.Peek(5)) != "world" {
+		t.Fatal("unexpected length", buf.Len(), view.Len())
+	}
+	dst := new(bytes.Buffer)
+	if _, err := view.WriteTo(dst); err != nil || dst.String() != "world" {
+		t.Fatal("unexpected write to", dst.String(), err)
+	}
+	if buf.Len() != 0 {
+		t.Fatal("unexpected length", buf.Len())
+	}
+}
+
+func TestBuffer_ReadOnlyImplementations(t *testing.T) {
+	buffers := []bytebuffers.Buffer{
+		bytebuffers.NewConcurrentBuffer(),
+		bytebuffers.NewRingBuffer(8),
+		bytebuffers.NewChainBuffer(8),
+		bytebuffers.NewFixedBuffer(8),
+	}
+	for _, buf := range buffers {
+		_, _ = buf.WriteString("abc")
+		view := buf.ReadOnly()
+		if _, err := view.WriteString("x"); !errors.Is(err, bytebuffers.ErrReadOnly) {
+			t.Fatal("expected read only, got", err)
+		}
+		if b, err := view.ReadByte(); err != nil || b != 'a' || buf.Len() != 2 {
+			t.Fatal("unexpected byte", b, err)
+		}
+	}
+}
@@ -0,0 +1,250 @@
+package bytebuffers
+
+import (
+	"bytes"
+	"errors"
+	"io"
+)
+
+var (
+	ErrRedisInvalid = errors.New("bytebuffers.Redis: invalid inline command")
+)
+
+// WriteRedisInlineCommand
+// 写入 Redis 内联命令：参数以空格分隔，以 \r\n 结尾。
+//
+// 空参数或含空白、引号、反斜杠及不可打印字符的参数会以双引号包裹并转义。
+func WriteRedisInlineCommand(buf Buffer, args ...string) (err error) {
+	if len(args) == 0 {
+		err = ErrRedisInvalid
+		return
+	}
+	size := 2 + len(args) - 1 // \r\n and separators
+	for _, arg := range args {
+		if len(arg) > (maxInt-size)/4-2 {
+			err = ErrTooLarge
+			return
+		}
+		size += redisArgLen(arg)
+	}
+	p, borrowErr := buf.Borrow(size)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	p = p[:0]
+	for i, arg := range args {
+		if i > 0 {
+			p = append(p, ' ')
+		}
+		p = appendRedisArg(p, arg)
+	}
+	p = append(p, '\r', '\n')
+	buf.Return(len(p))
+	return
+}
+
+// ReadRedisInlineCommand
+// 读取一行 Redis 内联命令并按 Redis 的规则拆分参数（支持单引号与双引号）。
+//
+// 没有 \r\n 时返回 io.ErrUnexpectedEOF，失败或不完整时不读掉。
+func ReadRedisInlineCommand(buf Buffer) (args []string, err error) {
+	line, n, lineErr := peekCRLFLine(buf)
+	if lineErr != nil {
+		err = lineErr
+		return
+	}
+	if args, err = splitRedisArgs(line); err != nil {
+		return
+	}
+	buf.Discard(n)
+	return
+}
+
+// peekCRLFLine
+// 查看以 \r\n 结尾的一行（不含 \r\n），n 为含 \r\n 的长度。
+func peekCRLFLine(buf Buffer) (line []byte, n int, err error) {
+	p := buf.Peek(buf.Len())
+	if len(p) == 0 {
+		err = io.EOF
+		return
+	}
+	i := bytes.Index(p, []byte("\r\n"))
+	if i < 0 {
+		err = io.ErrUnexpectedEOF
+		return
+	}
+	line, n = p[:i], i+2
+	return
+}
+
+// redisArgQuoted
+// 参数是否需要以双引号包裹并转义。
+func redisArgQuoted(arg string) bool {
+	quote := len(arg) == 0
+	for i := 0; i < len(arg) && !quote; i++ {
+		c := arg[i]
+		quote = c <= ' ' || c >= 0x7F || c == '"' || c == '\'' || c == '\\'
+	}
+	return quote
+}
+
+// redisArgLen
+// 参数经 appendRedisArg 编码后的长度。
+func redisArgLen(arg string) (n int) {
+	if !redisArgQuoted(arg) {
+		return len(arg)
+	}
+	n = 2
+	for i := 0; i < len(arg); i++ {
+		switch c := arg[i]; c {
+		case '"', '\\', '\n', '\r', '\t', '\a', '\b':
+			n += 2
+		default:
+			if c < ' ' || c >= 0x7F {
+				n += 4
+			} else {
+				n++
+			}
+		}
+	}
+	return
+}
+
+func appendRedisArg(p []byte, arg string) []byte {
+	if !redisArgQuoted(arg) {
+		return append(p, arg...)
+	}
+	const hex = "0123456789abcdef"
+	p = append(p, '"')
+	for i := 0; i < len(arg); i++ {
+		switch c := arg[i]; c {
+		case '"', '\\':
+			p = append(p, '\\', c)
+		case '\n':
+			p = append(p, '\\', 'n')
+		case '\r':
+			p = append(p, '\\', 'r')
+		case '\t':
+			p = append(p, '\\', 't')
+		case '\a':
+			p = append(p, '\\', 'a')
+		case '\b':
+			p = append(p, '\\', 'b')
+		default:
+			if c < ' ' || c >= 0x7F {
+				p = append(p, '\\', 'x', hex[c>>4], hex[c&0x0F])
+			} else {
+				p = append(p, c)
+			}
+		}
+	}
+	return append(p, '"')
+}
+
+// splitRedisArgs
+// 与 Redis 的 sdssplitargs 规则一致：双引号内支持 \n \r \t \b \a \xHH 等转义，单引号内仅支持 \'，
+// 闭合引号后必须是空白或行尾。
+func splitRedisArgs(line []byte) (args []string, err error) {
+	args = make([]string, 0, 4)
+	i := 0
+	for {
+		for i < len(line) && isRedisSpace(line[i]) {
+			i++
+		}
+		if i == len(line) {
+			return
+		}
+		var (
+			arg      []byte
+			inDouble bool
+			inSingle bool
+			done     bool
+		)
+		for !done {
+			if i == len(line) {
+				if inDouble || inSingle {
+					args, err = nil, ErrRedisInvalid
+					return
+				}
+				break
+			}
+			c := line[i]
+			switch {
+			case inDouble:
+				if c == '\\' && i+3 < len(line) && line[i+1] == 'x' && isHexDigit(line[i+2]) && isHexDigit(line[i+3]) {
+					arg = append(arg, hexDigit(line[i+2])<<4|hexDigit(line[i+3]))
+					i += 3
+				} else if c == '\\' && i+1 < len(line) {
+					i++
+					switch c = line[i]; c {
+					case 'n':
+						c = '\n'
+					case 'r':
+						c = '\r'
+					case 't':
+						c = '\t'
+					case 'b':
+						c = '\b'
+					case 'a':
+						c = '\a'
+					}
+					arg = append(arg, c)
+				} else if c == '"' {
+					if i+1 < len(line) && !isRedisSpace(line[i+1]) {
+						args, err = nil, ErrRedisInvalid
+						return
+					}
+					done = true
+				} else {
+					arg = append(arg, c)
+				}
+			case inSingle:
+				if c == '\\' && i+1 < len(line) && line[i+1] == '\'' {
+					arg = append(arg, '\'')
+					i++
+				} else if c == '\'' {
+					if i+1 < len(line) && !isRedisSpace(line[i+1]) {
+						args, err = nil, ErrRedisInvalid
+						return
+					}
+					done = true
+				} else {
+					arg = append(arg, c)
+				}
+			default:
+				switch c {
+				case ' ', '\t', '\n', '\r', '\v', '\f':
+					done = true
+				case '"':
+					inDouble = true
+				case '\'':
+					inSingle = true
+				default:
+					arg = append(arg, c)
+				}
+			}
+			i++
+		}
+		args = append(args, string(arg))
+	}
+}
+
+func isRedisSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\v' || c == '\f'
+}
+
+func isHexDigit(c byte) bool {
+	return ('0' <= c && c <= '9') || ('a' <= c && c <= 'f') || ('A' <= c && c <= 'F')
+}
+
+func hexDigit(c byte) byte {
+	switch {
+	case c <= '9':
+		return c - '0'
+	case c <= 'F':
+		return c - 'A' + 10
+	default:
+		return c - 'a' + 10
+	}
+}
@@ -0,0 +1,77 @@
+package bytebuffers_test
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestRedisInlineCommand(t *testing.T) {
+	cases := []struct {
+		args    []string
+		encoded string
+	}{
+		{[]string{"PING"}, "PING\r\n"},
+		{[]string{"SET", "key", "value"}, "SET key value\r\n"},
+		{[]string{"SET", "key", "hello world"}, "SET key \"hello world\"\r\n"},
+		{[]string{"SET", "key", ""}, "SET key \"\"\r\n"},
+		{[]string{"SET", "key", "a\"b\\c\r\n\x01"}, "SET key \"a\\\"b\\\\c\\r\\n\\x01\"\r\n"},
+	}
+	buf := bytebuffers.NewBuffer()
+	for _, c := range cases {
+		if err := bytebuffers.WriteRedisInlineCommand(buf, c.args...); err != nil {
+			t.Fatal(err)
+		}
+		if s := string(buf.Peek(buf.Len())); s != c.encoded {
+			t.Fatalf("expected %q, got %q", c.encoded, s)
+		}
+		args, err := bytebuffers.ReadRedisInlineCommand(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(args, c.args) || buf.Len() != 0 {
+			t.Fatal("unexpected args", args)
+		}
+		// only the exact encoded size is reserved
+		fixed := bytebuffers.NewFixedBuffer(len(c.encoded))
+		if err = bytebuffers.WriteRedisInlineCommand(fixed, c.args...); err != nil || string(fixed.CloneBytes()) != c.encoded {
+			t.Fatalf("unexpected fixed write %q %v", fixed.CloneBytes(), err)
+		}
+		fixed = bytebuffers.NewFixedBuffer(len(c.encoded) - 1)
+		if err = bytebuffers.WriteRedisInlineCommand(fixed, c.args...); !errors.Is(err, bytebuffers.ErrBufferFull) {
+			t.Fatal("expected buffer full, got", err)
+		}
+	}
+}
+
+func TestReadRedisInlineCommand(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	_, _ = buf.WriteString("  SET 'it\\'s' \"\\x41\\tB\"  \r\n")
+	args, err := bytebuffers.ReadRedisInlineCommand(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(args, []string{"SET", "it's", "A\tB"}) {
+		t.Fatalf("unexpected args %q", args)
+	}
+
+	_, _ = buf.WriteString("PING")
+	if _, err = bytebuffers.ReadRedisInlineCommand(buf); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal("expected unexpected EOF, got", err)
+	}
+	if buf.Len() != 4 {
+		t.Fatal("incomplete command must not be discarded")
+	}
+	buf.Reset()
+
+	for _, line := range []string{"SET \"key\r\n", "SET 'key\r\n", "SET \"a\"b\r\n"} {
+		_, _ = buf.WriteString(line)
+		if _, err = bytebuffers.ReadRedisInlineCommand(buf); !errors.Is(err, bytebuffers.ErrRedisInvalid) {
+			t.Fatalf("%q: expected invalid, got %v", line, err)
+		}
+		buf.Reset()
+	}
+}
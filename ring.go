@@ -0,0 +1,688 @@
+package bytebuffers
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"unicode/utf8"
+	"unsafe"
+)
+
+// NewRingBuffer
+// 创建一个环形 Buffer，读写位置在 capacity 大小的内存上回绕，持续读写时不需要左移整理内存。
+//
+// 可读内容超过容量时会扩容；Borrow 需要连续空间，回绕处空间不足时会整理一次内存；Peek 跨越回绕处时同样会整理内存。
+func NewRingBuffer(capacity int) Buffer {
+	if capacity <= 0 {
+		capacity = minHint
+	}
+	buf := &ringBuffer{
+		h: capacity,
+		b: make([]byte, capacity),
+	}
+	buf.codec = codec{buf}
+	return buf
+}
+
+type ringBuffer struct {
+	codec
+	h int
+	b []byte
+	r int // read index
+	n int // readable length
+	a int // borrowed size
+	// state of the last ReadByte or ReadRune, ur is the read index after it plus 1
+	// and is cleared by any other read or write, us is the size of the rune and 0 after ReadByte
+	ur int
+	un int
+	us int
+	ub [utf8.UTFMax]byte
+	// state of Seek, sp is the position of the read index since the first Seek,
+	// k is the count of read bytes right before the read index that are not overwritten yet
+	seeking bool
+	sp      int
+	k       int
+}
+
+func (buf *ringBuffer) Len() int { return buf.n }
+
+func (buf *ringBuffer) Capacity() int { return len(buf.b) }
+
+func (buf *ringBuffer) CapacityHint() int { return buf.h }
+
+// segments
+// 可读内容，回绕时分为两段。
+func (buf *ringBuffer) segments() (head, tail []byte) {
+	end := buf.r + buf.n
+	if end <= len(buf.b) {
+		head = buf.b[buf.r:end]
+		return
+	}
+	head, tail = buf.b[buf.r:], buf.b[:end-len(buf.b)]
+	return
+}
+
+// writable
+// 写位置起的连续空闲空间。
+func (buf *ringBuffer) writable() []byte {
+	end := buf.r + buf.n
+	if end < len(buf.b) {
+		return buf.b[end:]
+	}
+	return buf.b[end-len(buf.b) : buf.r]
+}
+
+// linearize
+// 整理内存使可读内容从 0 开始连续存放，空闲空间足够时原地移动。
+func (buf *ringBuffer) linearize() {
+	if buf.r == 0 {
+		return
+	}
+	head, tail := buf.segments()
+	switch {
+	case tail == nil:
+		copy(buf.b, head)
+	case len(head)+len(tail) <= buf.r:
+		// the gap between tail and head is large enough to hold head
+		copy(buf.b[len(head):], tail)
+		copy(buf.b, head)
+	default:
+		nb := make([]byte, len(buf.b))
+		copy(nb[copy(nb, head):], tail)
+		buf.b = nb
+	}
+	buf.r = 0
+	buf.k = 0
+}
+
+func (buf *ringBuffer) grow(n int) (err error) {
+	if n > maxInt-buf.n {
+		err = ErrTooLarge
+		return
+	}
+	size := len(buf.b)
+	if size > maxInt-size {
+		size = maxInt
+	} else {
+		size += size
+	}
+	if need := buf.n + n; size < need {
+		size = adjustBufferSize(need, buf.h)
+	}
+	nb := make([]byte, size)
+	head, tail := buf.segments()
+	copy(nb[copy(nb, head):], tail)
+	buf.b = nb
+	buf.r = 0
+	buf.k = 0
+	return
+}
+
+func (buf *ringBuffer) shrink() {
+	if buf.n == 0 && buf.a == 0 && buf.kept() == 0 {
+		buf.r = 0
+	}
+}
+
+func (buf *ringBuffer) Peek(n int) (p []byte) {
+	if n < 1 || buf.n == 0 {
+		return
+	}
+	if n > buf.n {
+		n = buf.n
+	}
+	if buf.r+n > len(buf.b) {
+		if buf.Borrowing() { // keep the borrowed area in place
+			p = make([]byte, n)
+			head, tail := buf.segments()
+			copy(p[copy(p, head):], tail)
+			return
+		}
+		buf.linearize()
+	}
+	p = buf.b[buf.r : buf.r+n]
+	return
+}
+
+func (buf *ringBuffer) CloneBytes() []byte {
+	if buf.n == 0 {
+		return nil
+	}
+	p := make([]byte, buf.n)
+	head, tail := buf.segments()
+	copy(p[copy(p, head):], tail)
+	return p
+}
+
+func (buf *ringBuffer) Next(n int) (p []byte, err error) {
+	if n < 1 {
+		return
+	}
+	if buf.n == 0 {
+		err = io.EOF
+		return
+	}
+	if n > buf.n {
+		n = buf.n
+	}
+	p = make([]byte, n)
+	_, _ = buf.Read(p)
+	return
+}
+
+func (buf *ringBuffer) Discard(n int) {
+	buf.ur = 0
+	if n < 1 || buf.n == 0 {
+		return
+	}
+	if n > buf.n {
+		n = buf.n
+	}
+	if buf.seeking {
+		buf.k = buf.kept() + n
+		buf.sp += n
+	}
+	buf.r += n
+	if buf.r >= len(buf.b) {
+		buf.r -= len(buf.b)
+	}
+	buf.n -= n
+	buf.shrink()
+}
+
+func (buf *ringBuffer) Truncate(n int) (err error) {
+	buf.ur = 0
+	if buf.Borrowing() {
+		err = ErrWriteWhenBorrowing
+		return
+	}
+	if n < 0 || n > buf.n {
+		err = ErrInvalidOffset
+		return
+	}
+	buf.k = buf.kept() // the truncated bytes may have overwritten the kept ones
+	buf.n = n
+	buf.shrink()
+	return
+}
+
+func (buf *ringBuffer) Read(p []byte) (n int, err error) {
+	if len(p) == 0 {
+		return
+	}
+	if buf.n == 0 {
+		err = io.EOF
+		return
+	}
+	head, tail := buf.segments()
+	n = copy(p, head)
+	n += copy(p[n:], tail)
+	buf.Discard(n)
+	return
+}
+
+func (buf *ringBuffer) ReadByte() (b byte, err error) {
+	buf.ur = 0
+	if buf.n == 0 {
+		err = io.EOF
+		return
+	}
+	b = buf.b[buf.r]
+	buf.Discard(1)
+	buf.ur, buf.un, buf.us, buf.ub[0] = buf.r+1, buf.n, 0, b
+	return
+}
+
+func (buf *ringBuffer) UnreadByte() (err error) {
+	if buf.ur != buf.r+1 || buf.un != buf.n || buf.us != 0 || buf.Borrowing() {
+		err = ErrUnreadByte
+		return
+	}
+	buf.unread(buf.ub[:1])
+	return
+}
+
+func (buf *ringBuffer) ReadRune() (r rune, size int, err error) {
+	buf.ur = 0
+	if buf.n == 0 {
+		err = io.EOF
+		return
+	}
+	if c := buf.b[buf.r]; c < utf8.RuneSelf {
+		r, size = rune(c), 1
+		buf.ub[0] = c
+	} else {
+		p := buf.Peek(utf8.UTFMax)
+		if !utf8.FullRune(p) {
+			err = io.ErrUnexpectedEOF
+			return
+		}
+		r, size = utf8.DecodeRune(p)
+		copy(buf.ub[:], p[:size])
+	}
+	buf.Discard(size)
+	buf.ur, buf.un, buf.us = buf.r+1, buf.n, size
+	return
+}
+
+func (buf *ringBuffer) UnreadRune() (err error) {
+	if buf.ur != buf.r+1 || buf.un != buf.n || buf.us == 0 || buf.Borrowing() {
+		err = ErrUnreadRune
+		return
+	}
+	buf.unread(buf.ub[:buf.us])
+	return
+}
+
+// unread
+// 把最近一次读取的 p 放回可读内容之前。
+func (buf *ringBuffer) unread(p []byte) {
+	buf.ur = 0
+	if buf.seeking {
+		buf.k = buf.kept() - len(p)
+		buf.sp -= len(p)
+	}
+	buf.r -= len(p)
+	if buf.r < 0 {
+		buf.r += len(buf.b)
+	}
+	buf.n += len(p)
+	for i, c := range p {
+		buf.b[(buf.r+i)%len(buf.b)] = c
+	}
+}
+
+func (buf *ringBuffer) ReadBytes(delim byte) (line []byte, err error) {
+	if buf.n == 0 {
+		err = io.EOF
+		return
+	}
+	n := buf.n
+	if i := buf.Index(delim); i >= 0 {
+		n = i + 1
+	}
+	line, err = buf.Next(n)
+	return
+}
+
+func (buf *ringBuffer) Index(delim byte) (i int) {
+	head, tail := buf.segments()
+	if i = bytes.IndexByte(head, delim); i >= 0 {
+		return
+	}
+	if i = bytes.IndexByte(tail, delim); i >= 0 {
+		i += len(head)
+	}
+	return
+}
+
+// region
+// 可读内容中 [off, off+n) 的部分，回绕时分为两段。
+func (buf *ringBuffer) region(off int, n int) (first, second []byte) {
+	start := buf.r + off
+	if start >= len(buf.b) {
+		start -= len(buf.b)
+	}
+	end := start + n
+	if end <= len(buf.b) {
+		first = buf.b[start:end]
+		return
+	}
+	first, second = buf.b[start:], buf.b[:end-len(buf.b)]
+	return
+}
+
+func (buf *ringBuffer) ReadAt(p []byte, off int64) (n int, err error) {
+	if off < 0 {
+		err = ErrInvalidOffset
+		return
+	}
+	if off >= int64(buf.n) {
+		err = io.EOF
+		return
+	}
+	o := int(off)
+	size := len(p)
+	if size > buf.n-o {
+		size = buf.n - o
+	}
+	first, second := buf.region(o, size)
+	n = copy(p, first)
+	n += copy(p[n:], second)
+	if n < len(p) {
+		err = io.EOF
+	}
+	return
+}
+
+func (buf *ringBuffer) WriteAt(p []byte, off int64) (n int, err error) {
+	buf.ur = 0
+	if off < 0 {
+		err = ErrInvalidOffset
+		return
+	}
+	if off > int64(maxInt-len(p)) {
+		err = ErrTooLarge
+		return
+	}
+	o := int(off)
+	if end := o + len(p); end > buf.n {
+		if buf.Borrowing() {
+			err = ErrWriteWhenBorrowing
+			return
+		}
+		if len(buf.b) < end {
+			if err = buf.grow(end - buf.n); err != nil {
+				return
+			}
+		}
+		prev := buf.n
+		buf.n = end
+		if o > prev {
+			first, second := buf.region(prev, o-prev)
+			clear(first)
+			clear(second)
+		}
+	}
+	first, second := buf.region(o, len(p))
+	n = copy(first, p)
+	n += copy(second, p[n:])
+	return
+}
+
+// Seek
+// 向后移动时只能移动到读位置之前尚未被写入覆盖的内容，整理内存后不可向后移动。
+func (buf *ringBuffer) Seek(offset int64, whence int) (abs int64, err error) {
+	buf.ur = 0
+	buf.seeking = true
+	kept := buf.kept()
+	cur := int64(buf.sp)
+	if abs, err = seekTo(offset, whence, cur-int64(kept), cur, cur+int64(buf.n)); err != nil {
+		return
+	}
+	if back := int(cur - abs); back > 0 {
+		buf.r -= back
+		if buf.r < 0 {
+			buf.r += len(buf.b)
+		}
+		buf.n += back
+		buf.k = kept - back
+		buf.sp -= back
+		return
+	}
+	buf.Discard(int(abs - cur))
+	return
+}
+
+// kept
+// 读位置之前仍可由 Seek 移回的字节数，写入与借出的空间会覆盖其中最早的部分。
+func (buf *ringBuffer) kept() int {
+	if free := len(buf.b) - buf.n - buf.a; buf.k > free {
+		return free
+	}
+	return buf.k
+}
+
+func (buf *ringBuffer) Write(p []byte) (n int, err error) {
+	buf.ur = 0
+	if buf.Borrowing() {
+		err = ErrWriteWhenBorrowing
+		return
+	}
+	if len(p) == 0 {
+		return
+	}
+	if len(buf.b)-buf.n < len(p) {
+		if err = buf.grow(len(p)); err != nil {
+			return
+		}
+	}
+	for n < len(p) {
+		wn := copy(buf.writable(), p[n:])
+		buf.n += wn
+		n += wn
+	}
+	return
+}
+
+func (buf *ringBuffer) WriteByte(c byte) (err error) {
+	_, err = buf.Write([]byte{c})
+	return
+}
+
+func (buf *ringBuffer) WriteString(s string) (n int, err error) {
+	if s == "" {
+		return
+	}
+	return buf.Write(unsafe.Slice(unsafe.StringData(s), len(s)))
+}
+
+func (buf *ringBuffer) Set(p []byte) (err error) {
+	buf.ur = 0
+	if buf.Borrowing() {
+		err = ErrWriteWhenBorrowing
+		return
+	}
+	buf.r, buf.n, buf.k = 0, 0, 0
+	_, err = buf.Write(p)
+	return
+}
+
+func (buf *ringBuffer) SetString(s string) (err error) {
+	err = buf.Set(unsafe.Slice(unsafe.StringData(s), len(s)))
+	return
+}
+
+func (buf *ringBuffer) ReadFrom(r io.Reader) (n int64, err error) {
+	n, err = buf.ReadFromWithHint(r, 1)
+	return
+}
+
+func (buf *ringBuffer) ReadFromWithHint(r io.Reader, hint int) (n int64, err error) {
+	buf.ur = 0
+	if buf.Borrowing() {
+		err = ErrWriteWhenBorrowing
+		return
+	}
+	if hint < buf.h {
+		hint = buf.h
+	}
+	for {
+		if buf.n == len(buf.b) {
+			if err = buf.grow(hint); err != nil {
+				return
+			}
+		}
+		rn, rErr := r.Read(buf.writable())
+		buf.n += rn
+		n += int64(rn)
+		if rErr != nil {
+			if errors.Is(rErr, io.EOF) {
+				break
+			}
+			err = rErr
+			return
+		}
+	}
+	return
+}
+
+func (buf *ringBuffer) ReadFromLimited(r io.Reader, n int) (nn int, err error) {
+	buf.ur = 0
+	if buf.Borrowing() {
+		err = ErrWriteWhenBorrowing
+		return
+	}
+	if n < 1 {
+		return
+	}
+	if len(buf.b)-buf.n < n {
+		if err = buf.grow(n); err != nil {
+			return
+		}
+	}
+	for nn < n {
+		p := buf.writable()
+		if len(p) > n-nn {
+			p = p[:n-nn]
+		}
+		rn, rErr := r.Read(p)
+		buf.n += rn
+		nn += rn
+		if rErr != nil {
+			if errors.Is(rErr, io.EOF) {
+				break
+			}
+			err = rErr
+			return
+		}
+	}
+	return
+}
+
+func (buf *ringBuffer) CopyFromReader(r io.Reader, n int) (nn int, err error) {
+	if n < 1 {
+		return
+	}
+	p, borrowErr := buf.Borrow(n)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	if _, err = io.ReadFull(r, p); err != nil {
+		buf.Return(0)
+		return
+	}
+	nn = n
+	buf.Return(n)
+	return
+}
+
+func (buf *ringBuffer) WriteTo(w io.Writer) (n int64, err error) {
+	for buf.n > 0 {
+		head, _ := buf.segments()
+		wn, wErr := w.Write(head)
+		buf.Discard(wn)
+		n += int64(wn)
+		if wErr != nil {
+			err = wErr
+			return
+		}
+	}
+	return
+}
+
+func (buf *ringBuffer) WriteToLimited(w io.Writer, n int) (nn int, err error) {
+	if n > buf.n {
+		n = buf.n
+	}
+	for nn < n {
+		head, _ := buf.segments()
+		if len(head) > n-nn {
+			head = head[:n-nn]
+		}
+		wn, wErr := w.Write(head)
+		buf.Discard(wn)
+		nn += wn
+		if wErr != nil {
+			err = wErr
+			return
+		}
+	}
+	return
+}
+
+func (buf *ringBuffer) Borrowing() bool {
+	return buf.a != 0
+}
+
+// Grow
+// 预留的空间可能跨越回绕处，之后的写入不一定连续，但不会再扩容。
+func (buf *ringBuffer) Grow(n int) (err error) {
+	buf.ur = 0
+	if buf.Borrowing() {
+		err = ErrWriteWhenBorrowing
+		return
+	}
+	if len(buf.b)-buf.n < n {
+		err = buf.grow(n)
+	}
+	return
+}
+
+// Available
+// 只计算写位置起的连续空闲空间，回绕处之后的空间不计入。
+func (buf *ringBuffer) Available() int {
+	if buf.Borrowing() {
+		return 0
+	}
+	return len(buf.writable())
+}
+
+func (buf *ringBuffer) AvailableBuffer() []byte {
+	if buf.Borrowing() {
+		return nil
+	}
+	return buf.writable()[:0]
+}
+
+func (buf *ringBuffer) Commit(n int) (err error) {
+	buf.ur = 0
+	if buf.Borrowing() {
+		err = ErrWriteWhenBorrowing
+		return
+	}
+	if n < 0 || n > len(buf.writable()) {
+		err = ErrInvalidOffset
+		return
+	}
+	buf.n += n
+	return
+}
+
+func (buf *ringBuffer) Borrow(size int) (p []byte, err error) {
+	buf.ur = 0
+	if buf.Borrowing() {
+		err = ErrWriteWhenBorrowing
+		return
+	}
+	if size < 1 {
+		err = ErrBorrowZero
+		return
+	}
+	if len(buf.writable()) < size {
+		if len(buf.b)-buf.n >= size {
+			buf.linearize()
+		} else if err = buf.grow(size); err != nil {
+			return
+		}
+	}
+	p = buf.writable()[:size]
+	buf.a = size
+	buf.k = buf.kept() // the borrowed area may be written before Return
+	return
+}
+
+func (buf *ringBuffer) Return(used int) {
+	buf.ur = 0
+	if buf.a == 0 {
+		return
+	}
+	if used < 0 {
+		panic(errors.New("negative used"))
+	}
+	buf.n += used
+	buf.a = 0
+	buf.shrink()
+}
+
+func (buf *ringBuffer) Reset() bool {
+	ok := !buf.Borrowing()
+	if ok {
+		buf.r = 0
+		buf.n = 0
+		buf.ur = 0
+		buf.seeking, buf.sp, buf.k = false, 0, 0
+	}
+	return ok
+}
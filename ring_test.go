@@ -0,0 +1,178 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestRingBuffer(t *testing.T) {
+	buf := bytebuffers.NewRingBuffer(8)
+	_, _ = buf.WriteString("012345")
+	buf.Discard(4)
+	// wraps around without growing
+	if _, err := buf.WriteString("6789ab"); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Capacity() != 8 || buf.Len() != 8 {
+		t.Fatal("unexpected capacity or length", buf.Capacity(), buf.Len())
+	}
+	if i := buf.Index('a'); i != 6 {
+		t.Fatal("unexpected index", i)
+	}
+	if p := buf.CloneBytes(); string(p) != "456789ab" {
+		t.Fatal("unexpected bytes", string(p))
+	}
+	p := make([]byte, 3)
+	if n, _ := buf.Read(p); n != 3 || string(p) != "456" {
+		t.Fatal("unexpected read", string(p[:n]))
+	}
+	// peeking across the wrap point returns a contiguous slice
+	if p = buf.Peek(5); string(p) != "789ab" {
+		t.Fatal("unexpected peek", string(p))
+	}
+	if p = buf.Bytes(); string(p) != "789ab" {
+		t.Fatal("unexpected bytes", string(p))
+	}
+	if b, _ := buf.ReadByte(); b != '7' {
+		t.Fatal("unexpected byte", b)
+	}
+	if err := buf.UnreadByte(); err != nil || buf.Len() != 5 {
+		t.Fatal("unexpected unread", buf.Len(), err)
+	}
+	// grows when full
+	_, _ = buf.WriteString("cdefghij")
+	if buf.Capacity() < 13 || string(buf.CloneBytes()) != "789abcdefghij" {
+		t.Fatal("unexpected grow", buf.Capacity(), string(buf.CloneBytes()))
+	}
+	line, err := buf.ReadBytes('c')
+	if err != nil || string(line) != "789abc" {
+		t.Fatal("unexpected line", string(line), err)
+	}
+	if !buf.Reset() || buf.Len() != 0 {
+		t.Fatal("reset failed")
+	}
+	// unread across the wrap point
+	_, _ = buf.WriteString("0")
+	if b, _ := buf.ReadByte(); b != '0' || buf.Len() != 0 {
+		t.Fatal("unexpected byte", b)
+	}
+	if err := buf.UnreadByte(); err != nil || string(buf.CloneBytes()) != "0" {
+		t.Fatal("unexpected unread", string(buf.CloneBytes()), err)
+	}
+	buf.Discard(1)
+	if _, err = buf.ReadByte(); !errors.Is(err, io.EOF) {
+		t.Fatal("expected EOF, got", err)
+	}
+}
+
+func TestRingBuffer_Borrow(t *testing.T) {
+	buf := bytebuffers.NewRingBuffer(8)
+	_, _ = buf.WriteString("0123456")
+	buf.Discard(5)
+	// 1 byte at the tail, 5 in front of the read index: the 4 bytes borrow needs compaction, not growth
+	p, err := buf.Borrow(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = buf.WriteString("x"); !errors.Is(err, bytebuffers.ErrWriteWhenBorrowing) {
+		t.Fatal("expected write when borrowing, got", err)
+	}
+	copy(p, "abcd")
+	buf.Return(3)
+	if buf.Capacity() != 8 || string(buf.CloneBytes()) != "56abc" {
+		t.Fatal("unexpected bytes", buf.Capacity(), string(buf.CloneBytes()))
+	}
+	if _, err = buf.Borrow(16); err != nil {
+		t.Fatal(err)
+	}
+	buf.Return(0)
+	if buf.Capacity() < 21 || string(buf.CloneBytes()) != "56abc" {
+		t.Fatal("unexpected bytes", buf.Capacity(), string(buf.CloneBytes()))
+	}
+}
+
+func TestRingBuffer_Stream(t *testing.T) {
+	buf := bytebuffers.NewRingBuffer(64)
+	data := strings.Repeat("0123456789abcdef", 64)
+	src := strings.NewReader(data)
+	out := new(bytes.Buffer)
+	// keep some residue so the read index never resets and the data wraps
+	if _, err := buf.ReadFromLimited(src, 16); err != nil {
+		t.Fatal(err)
+	}
+	for {
+		n, err := buf.ReadFromLimited(src, 40)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n == 0 {
+			break
+		}
+		if _, err = buf.WriteToLimited(out, 40); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := buf.WriteTo(out); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != data {
+		t.Fatal("unexpected stream")
+	}
+	if buf.Capacity() != 64 {
+		t.Fatal("steady state streaming must not grow", buf.Capacity())
+	}
+}
+
+func TestRingBuffer_Codec(t *testing.T) {
+	buf := bytebuffers.NewRingBuffer(16)
+	_, _ = buf.WriteString("0123456789")
+	buf.Discard(10)
+	_, _ = buf.WriteString("0123456789")
+	buf.Discard(10)
+	// the following values wrap around the end of the ring
+	if err := buf.WriteUvarint(300); err != nil {
+		t.Fatal(err)
+	}
+	if err := buf.WriteVarString("hello"); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	if err := buf.WriteTimestamp(now); err != nil {
+		t.Fatal(err)
+	}
+	if err := buf.WriteMap(map[string]string{"k": "v"}); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := buf.ReadUvarint(); err != nil || v != 300 {
+		t.Fatal("unexpected uvarint", v, err)
+	}
+	if s, err := buf.ReadVarString(); err != nil || s != "hello" {
+		t.Fatal("unexpected string", s, err)
+	}
+	if ts, err := buf.ReadTimestamp(); err != nil || !ts.Equal(now) {
+		t.Fatal("unexpected timestamp", ts, err)
+	}
+	if m, err := buf.ReadMap(); err != nil || len(m) != 1 || m["k"] != "v" {
+		t.Fatal("unexpected map", m, err)
+	}
+	if buf.Len() != 0 {
+		t.Fatal("unexpected remaining", buf.Len())
+	}
+
+	_, _ = buf.WriteString("hello")
+	if err := buf.EncodeURL(false); err != nil {
+		t.Fatal(err)
+	}
+	if err := buf.DecodeURL(false); err != nil {
+		t.Fatal(err)
+	}
+	if string(buf.CloneBytes()) != "hello" {
+		t.Fatal("unexpected bytes", string(buf.CloneBytes()))
+	}
+}
@@ -0,0 +1,60 @@
+package bytebuffers
+
+import (
+	"io"
+	"unicode/utf8"
+)
+
+func (buf *buffer) ReadRune() (r rune, size int, err error) {
+	buf.ur = 0
+	bLen := buf.Len()
+	if bLen == 0 {
+		err = io.EOF
+		return
+	}
+	p := buf.b[buf.r:buf.w]
+	if c := p[0]; c < utf8.RuneSelf {
+		r, size = rune(c), 1
+	} else {
+		if !utf8.FullRune(p) {
+			err = io.ErrUnexpectedEOF
+			return
+		}
+		r, size = utf8.DecodeRune(p)
+	}
+	copy(buf.ub[:], p[:size])
+	buf.r += size
+	buf.shrink()
+	buf.ur, buf.uw, buf.us = buf.r+1, buf.w, size
+	return
+}
+
+func (buf *buffer) UnreadRune() (err error) {
+	if buf.ur != buf.r+1 || buf.uw != buf.w || buf.us == 0 || buf.Borrowing() {
+		err = ErrUnreadRune
+		return
+	}
+	buf.unread(buf.ub[:buf.us])
+	return
+}
+
+func (buf *buffer) WriteRune(r rune) (n int, err error) {
+	if uint32(r) < utf8.RuneSelf {
+		if err = buf.WriteByte(byte(r)); err == nil {
+			n = 1
+		}
+		return
+	}
+	size := utf8.RuneLen(r)
+	if size < 0 { // invalid runes are written as utf8.RuneError
+		size = utf8.RuneLen(utf8.RuneError)
+	}
+	p, borrowErr := buf.Borrow(size)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	n = utf8.EncodeRune(p, r)
+	buf.Return(n)
+	return
+}
@@ -0,0 +1,164 @@
+package bytebuffers_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestBuffer_Rune(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	var scanner io.RuneScanner = buf
+	for _, r := range "a字😀" {
+		if _, err := buf.WriteRune(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if string(buf.CloneBytes()) != "a字😀" {
+		t.Fatal("unexpected bytes", string(buf.CloneBytes()))
+	}
+	for _, expected := range "a字" {
+		r, size, err := scanner.ReadRune()
+		if err != nil || r != expected || size != utf8.RuneLen(expected) {
+			t.Fatal("unexpected rune", r, size, err)
+		}
+	}
+	if err := buf.UnreadRune(); err != nil {
+		t.Fatal(err)
+	}
+	if err := buf.UnreadRune(); !errors.Is(err, bytebuffers.ErrUnreadRune) {
+		t.Fatal("expected unread rune error, got", err)
+	}
+	if err := buf.UnreadByte(); !errors.Is(err, bytebuffers.ErrUnreadByte) {
+		t.Fatal("expected unread byte error, got", err)
+	}
+	buf.Discard(3)
+	// unread after the last rune was read
+	if r, _, _ := buf.ReadRune(); r != '😀' || buf.Len() != 0 {
+		t.Fatal("unexpected rune", r)
+	}
+	if err := buf.UnreadRune(); err != nil || string(buf.CloneBytes()) != "😀" {
+		t.Fatal("unexpected unread", string(buf.CloneBytes()), err)
+	}
+
+	// incomplete rune is not consumed
+	buf.Reset()
+	_, _ = buf.WriteString("字"[:2])
+	if _, _, err := buf.ReadRune(); !errors.Is(err, io.ErrUnexpectedEOF) || buf.Len() != 2 {
+		t.Fatal("expected unexpected EOF, got", err)
+	}
+	// invalid encoding consumes one byte
+	buf.Reset()
+	_, _ = buf.Write([]byte{0xff, 'a'})
+	if r, size, err := buf.ReadRune(); err != nil || r != utf8.RuneError || size != 1 {
+		t.Fatal("unexpected rune", r, size, err)
+	}
+	if _, _, err := buf.ReadRune(); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := buf.ReadRune(); !errors.Is(err, io.EOF) {
+		t.Fatal("expected EOF, got", err)
+	}
+}
+
+func TestBuffer_RuneFscan(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	_, _ = buf.WriteString("42 字符")
+	var n int
+	var s string
+	if _, err := fmt.Fscan(buf, &n, &s); err != nil {
+		t.Fatal(err)
+	}
+	if n != 42 || s != "字符" {
+		t.Fatal("unexpected scan", n, s)
+	}
+}
+
+func TestBuffer_RuneImplementations(t *testing.T) {
+	buffers := []bytebuffers.Buffer{
+		bytebuffers.NewConcurrentBuffer(),
+		bytebuffers.NewRingBuffer(8),
+		bytebuffers.NewChainBuffer(4),
+	}
+	for _, buf := range buffers {
+		_, _ = buf.WriteString("0123456")
+		buf.Discard(6)
+		// the rune crosses the wrap point of the ring and the chunk boundary of the chain
+		_, _ = buf.WriteRune('字')
+		buf.Discard(1)
+		r, size, err := buf.ReadRune()
+		if err != nil || r != '字' || size != 3 || buf.Len() != 0 {
+			t.Fatal("unexpected rune", r, size, err)
+		}
+		if err = buf.UnreadRune(); err != nil || string(buf.CloneBytes()) != "字" {
+			t.Fatal("unexpected unread", string(buf.CloneBytes()), err)
+		}
+		if b, _ := buf.ReadByte(); b != "字"[0] {
+			t.Fatal("unexpected byte", b)
+		}
+		if err = buf.UnreadRune(); !errors.Is(err, bytebuffers.ErrUnreadRune) {
+			t.Fatal("expected unread rune error, got", err)
+		}
+		if err = buf.UnreadByte(); err != nil || buf.Len() != 3 {
+			t.Fatal("unexpected unread", buf.Len(), err)
+		}
+	}
+	if _, err := bytebuffers.NewRingBuffer(8).ReadOnly().WriteRune('a'); !errors.Is(err, bytebuffers.ErrReadOnly) {
+		t.Fatal("expected read only, got", err)
+	}
+}
+
+func TestBuffer_UnreadRuneAfterOtherOps(t *testing.T) {
+	buffers := []bytebuffers.Buffer{
+		bytebuffers.NewBuffer(),
+		bytebuffers.NewConcurrentBuffer(),
+		bytebuffers.NewRingBuffer(8),
+		bytebuffers.NewChainBuffer(4),
+	}
+	for _, buf := range buffers {
+		_, _ = buf.WriteString("éb")
+		if r, _, err := buf.ReadRune(); err != nil || r != 'é' {
+			t.Fatal("unexpected rune", r, err)
+		}
+		// the read and write positions end up where they were after ReadRune
+		buf.Discard(1)
+		_, _ = buf.WriteString("zzq")
+		buf.Discard(2)
+		if err := buf.UnreadRune(); !errors.Is(err, bytebuffers.ErrUnreadRune) {
+			t.Fatalf("%T: expected unread rune error, got %v", buf, err)
+		}
+		if string(buf.CloneBytes()) != "q" {
+			t.Fatalf("%T: unexpected bytes %q", buf, buf.CloneBytes())
+		}
+		// a failed ReadRune invalidates as well
+		_, _ = buf.WriteString("字"[:2])
+		_, _, _ = buf.ReadRune()
+		if _, _, err := buf.ReadRune(); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Fatalf("%T: expected unexpected EOF, got %v", buf, err)
+		}
+		if err := buf.UnreadRune(); !errors.Is(err, bytebuffers.ErrUnreadRune) {
+			t.Fatalf("%T: expected unread rune error, got %v", buf, err)
+		}
+	}
+}
+
+func TestBuffer_WriteRuneFixed(t *testing.T) {
+	buf := bytebuffers.NewFixedBuffer(4)
+	_, _ = buf.WriteString("ab")
+	// only the encoded length is reserved, not utf8.UTFMax
+	if n, err := buf.WriteRune('é'); err != nil || n != 2 || string(buf.CloneBytes()) != "abé" {
+		t.Fatal("unexpected write", n, err, string(buf.CloneBytes()))
+	}
+	if _, err := buf.WriteRune('é'); !errors.Is(err, bytebuffers.ErrBufferFull) {
+		t.Fatal("expected buffer full, got", err)
+	}
+	ring := bytebuffers.NewRingBuffer(4)
+	_, _ = ring.WriteString("ab")
+	if n, err := ring.WriteRune('é'); err != nil || n != 2 || ring.Capacity() != 4 {
+		t.Fatal("unexpected write", n, err, ring.Capacity())
+	}
+}
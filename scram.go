@@ -0,0 +1,176 @@
+package bytebuffers
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+var (
+	ErrSCRAMInvalid           = errors.New("bytebuffers.SCRAM: invalid message")
+	ErrSCRAMNonceMismatch     = errors.New("bytebuffers.SCRAM: nonce mismatch")
+	ErrSCRAMServerError       = errors.New("bytebuffers.SCRAM: server error")
+	ErrSCRAMSignatureMismatch = errors.New("bytebuffers.SCRAM: server signature mismatch")
+)
+
+var scramNameReplacer = strings.NewReplacer("=", "=3D", ",", "=2C")
+
+// WriteSCRAMClientFirstMessage
+// 写入 SCRAM 的 client-first-message：n,,n=username,r=nonce，用户名中的 = 与 , 会被转义。
+//
+// nonce 须为除 , 外的可打印 ASCII 字符。
+func WriteSCRAMClientFirstMessage(buf Buffer, username, nonce string) (err error) {
+	if !isSCRAMNonce(nonce) {
+		err = ErrSCRAMInvalid
+		return
+	}
+	_, err = buf.WriteString("n,,n=" + scramNameReplacer.Replace(username) + ",r=" + nonce)
+	return
+}
+
+// WriteSCRAMClientFinalMessage
+// 根据 client-first-message、server-first-message 与密码计算 SCRAM-SHA-256 的 ClientProof，并写入 client-final-message：c=biws,r=nonce,p=proof。
+//
+// 返回期望的 ServerSignature，用于 ReadSCRAMServerFinalMessage 校验。密码须已经过 SASLprep。
+func WriteSCRAMClientFinalMessage(buf Buffer, clientFirst, serverFirst []byte, password string) (serverSignature []byte, err error) {
+	// gs2-header is everything up to and including the second comma.
+	first := bytes.IndexByte(clientFirst, ',')
+	if first < 0 {
+		err = ErrSCRAMInvalid
+		return
+	}
+	second := bytes.IndexByte(clientFirst[first+1:], ',')
+	if second < 0 {
+		err = ErrSCRAMInvalid
+		return
+	}
+	gs2 := first + second + 2
+	clientFirstBare := string(clientFirst[gs2:])
+	clientNonce, ok := scramAttribute(clientFirstBare, 'r')
+	if !ok {
+		err = ErrSCRAMInvalid
+		return
+	}
+	nonce, ok := scramAttribute(string(serverFirst), 'r')
+	if !ok {
+		err = ErrSCRAMInvalid
+		return
+	}
+	if len(nonce) <= len(clientNonce) || !strings.HasPrefix(nonce, clientNonce) {
+		err = ErrSCRAMNonceMismatch
+		return
+	}
+	encodedSalt, ok := scramAttribute(string(serverFirst), 's')
+	if !ok {
+		err = ErrSCRAMInvalid
+		return
+	}
+	salt, decodeErr := base64.StdEncoding.DecodeString(encodedSalt)
+	if decodeErr != nil {
+		err = ErrSCRAMInvalid
+		return
+	}
+	encodedIterations, ok := scramAttribute(string(serverFirst), 'i')
+	if !ok {
+		err = ErrSCRAMInvalid
+		return
+	}
+	iterations, parseErr := strconv.Atoi(encodedIterations)
+	if parseErr != nil || iterations < 1 {
+		err = ErrSCRAMInvalid
+		return
+	}
+
+	clientFinalWithoutProof := "c=" + base64.StdEncoding.EncodeToString(clientFirst[:gs2]) + ",r=" + nonce
+	authMessage := []byte(clientFirstBare + "," + string(serverFirst) + "," + clientFinalWithoutProof)
+
+	saltedPassword := pbkdf2.Key([]byte(password), salt, iterations, sha256.Size, sha256.New)
+	clientKey := scramHMAC(saltedPassword, []byte("Client Key"))
+	storedKey := sha256.Sum256(clientKey)
+	clientProof := scramHMAC(storedKey[:], authMessage)
+	for i := range clientProof {
+		clientProof[i] ^= clientKey[i]
+	}
+	serverKey := scramHMAC(saltedPassword, []byte("Server Key"))
+
+	if _, err = buf.WriteString(clientFinalWithoutProof + ",p=" + base64.StdEncoding.EncodeToString(clientProof)); err != nil {
+		return
+	}
+	serverSignature = scramHMAC(serverKey, authMessage)
+	return
+}
+
+// ReadSCRAMServerFinalMessage
+// 读取整个可读内容作为 server-final-message，并与 serverSignature 比较。
+//
+// 服务端返回 e= 时返回 ErrSCRAMServerError，签名不一致时返回 ErrSCRAMSignatureMismatch，出错时不读掉。
+func ReadSCRAMServerFinalMessage(buf Buffer, serverSignature []byte) (err error) {
+	n := buf.Len()
+	if n == 0 {
+		err = io.EOF
+		return
+	}
+	message := string(buf.Peek(n))
+	if _, ok := scramAttribute(message, 'e'); ok {
+		err = ErrSCRAMServerError
+		return
+	}
+	verifier, ok := scramAttribute(message, 'v')
+	if !ok {
+		err = ErrSCRAMInvalid
+		return
+	}
+	signature, decodeErr := base64.StdEncoding.DecodeString(verifier)
+	if decodeErr != nil {
+		err = ErrSCRAMInvalid
+		return
+	}
+	if !hmac.Equal(signature, serverSignature) {
+		err = ErrSCRAMSignatureMismatch
+		return
+	}
+	buf.Discard(n)
+	return
+}
+
+func scramHMAC(key, message []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(message)
+	return mac.Sum(nil)
+}
+
+// scramAttribute returns the value of the first "key=value" attribute in message.
+func scramAttribute(message string, key byte) (value string, ok bool) {
+	for len(message) > 0 {
+		attr := message
+		if i := strings.IndexByte(message, ','); i >= 0 {
+			attr, message = message[:i], message[i+1:]
+		} else {
+			message = ""
+		}
+		if len(attr) >= 2 && attr[0] == key && attr[1] == '=' {
+			value, ok = attr[2:], true
+			return
+		}
+	}
+	return
+}
+
+func isSCRAMNonce(nonce string) bool {
+	if len(nonce) == 0 {
+		return false
+	}
+	for i := 0; i < len(nonce); i++ {
+		if c := nonce[i]; c < 0x21 || c > 0x7E || c == ',' {
+			return false
+		}
+	}
+	return true
+}
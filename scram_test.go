@@ -0,0 +1,75 @@
+package bytebuffers_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+// RFC 7677 Appendix B
+func TestSCRAMSHA256(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	if err := bytebuffers.WriteSCRAMClientFirstMessage(buf, "user", "rOprNGfwEbeRWgbNEkqO"); err != nil {
+		t.Fatal(err)
+	}
+	clientFirst := buf.CloneBytes()
+	if string(clientFirst) != "n,,n=user,r=rOprNGfwEbeRWgbNEkqO" {
+		t.Fatal("unexpected client first message", string(clientFirst))
+	}
+	buf.Reset()
+
+	serverFirst := []byte("r=rOprNGfwEbeRWgbNEkqO%hvYDpWUa2RaTCAfuxFIlj)hNlF$k0,s=W22ZaJ0SNY7soEsUEjb6gQ==,i=4096")
+	serverSignature, err := bytebuffers.WriteSCRAMClientFinalMessage(buf, clientFirst, serverFirst, "pencil")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "c=biws,r=rOprNGfwEbeRWgbNEkqO%hvYDpWUa2RaTCAfuxFIlj)hNlF$k0,p=dHzbZapWIk4jUhN+Ute9ytag9zjfMHgsqmmiz7AndVQ="
+	if p := buf.CloneBytes(); string(p) != expected {
+		t.Fatal("unexpected client final message", string(p))
+	}
+	if s := base64.StdEncoding.EncodeToString(serverSignature); s != "6rriTRBi23WpRR/wtup+mMhUZUn/dB5nLTJRsjl95G4=" {
+		t.Fatal("unexpected server signature", s)
+	}
+	buf.Reset()
+
+	_, _ = buf.WriteString("v=6rriTRBi23WpRR/wtup+mMhUZUn/dB5nLTJRsjl95G4=")
+	if err = bytebuffers.ReadSCRAMServerFinalMessage(buf, serverSignature); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Fatal("server final message must be discarded")
+	}
+
+	_, _ = buf.WriteString("v=AAAATRBi23WpRR/wtup+mMhUZUn/dB5nLTJRsjl95G4=")
+	if err = bytebuffers.ReadSCRAMServerFinalMessage(buf, serverSignature); !errors.Is(err, bytebuffers.ErrSCRAMSignatureMismatch) {
+		t.Fatal("expected signature mismatch, got", err)
+	}
+	buf.Reset()
+	_, _ = buf.WriteString("e=invalid-proof")
+	if err = bytebuffers.ReadSCRAMServerFinalMessage(buf, serverSignature); !errors.Is(err, bytebuffers.ErrSCRAMServerError) {
+		t.Fatal("expected server error, got", err)
+	}
+	buf.Reset()
+
+	if _, err = bytebuffers.WriteSCRAMClientFinalMessage(buf, clientFirst, []byte("r=other,s=W22ZaJ0SNY7soEsUEjb6gQ==,i=4096"), "pencil"); !errors.Is(err, bytebuffers.ErrSCRAMNonceMismatch) {
+		t.Fatal("expected nonce mismatch, got", err)
+	}
+	if buf.Len() != 0 {
+		t.Fatal("nothing must be written on error")
+	}
+}
+
+func TestWriteSCRAMClientFirstMessageEscape(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	if err := bytebuffers.WriteSCRAMClientFirstMessage(buf, "a=b,c", "nonce"); err != nil {
+		t.Fatal(err)
+	}
+	if p := buf.CloneBytes(); string(p) != "n,,n=a=3Db=2Cc,r=nonce" {
+		t.Fatal("unexpected client first message", string(p))
+	}
+	if err := bytebuffers.WriteSCRAMClientFirstMessage(buf, "user", "a,b"); !errors.Is(err, bytebuffers.ErrSCRAMInvalid) {
+		t.Fatal("expected invalid, got", err)
+	}
+}
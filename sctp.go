@@ -0,0 +1,96 @@
+package bytebuffers
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+const (
+	SCTPChunkData             = 0
+	SCTPChunkInit             = 1
+	SCTPChunkInitAck          = 2
+	SCTPChunkSack             = 3
+	SCTPChunkHeartbeat        = 4
+	SCTPChunkHeartbeatAck     = 5
+	SCTPChunkAbort            = 6
+	SCTPChunkShutdown         = 7
+	SCTPChunkShutdownAck      = 8
+	SCTPChunkError            = 9
+	SCTPChunkCookieEcho       = 10
+	SCTPChunkCookieAck        = 11
+	SCTPChunkShutdownComplete = 14
+
+	sctpCommonHeaderLen = 12
+	sctpChunkHeaderLen  = 4
+)
+
+var (
+	ErrSCTPInvalid = errors.New("bytebuffers.SCTP: invalid chunk")
+)
+
+// WriteSCTPCommonHeader
+// 写入 12 字节的 SCTP 公共头：源端口、目的端口、验证标签与校验和，均为大端。
+func WriteSCTPCommonHeader(buf Buffer, srcPort, dstPort uint16, verificationTag, checksum uint32) (err error) {
+	p, borrowErr := buf.Borrow(sctpCommonHeaderLen)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	binary.BigEndian.PutUint16(p, srcPort)
+	binary.BigEndian.PutUint16(p[2:], dstPort)
+	binary.BigEndian.PutUint32(p[4:], verificationTag)
+	binary.BigEndian.PutUint32(p[8:], checksum)
+	buf.Return(sctpCommonHeaderLen)
+	return
+}
+
+// WriteSCTPChunk
+// 写入 SCTP 块：类型、标志、包含头部的长度（大端）、值及填充至 4 字节对齐。
+//
+// 长度不包含填充。
+func WriteSCTPChunk(buf Buffer, chunkType, chunkFlags byte, value []byte) (err error) {
+	if len(value) > math.MaxUint16-sctpChunkHeaderLen {
+		err = ErrTooLarge
+		return
+	}
+	length := sctpChunkHeaderLen + len(value)
+	size := (length + 3) &^ 3
+	p, borrowErr := buf.Borrow(size)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	p[0] = chunkType
+	p[1] = chunkFlags
+	binary.BigEndian.PutUint16(p[2:], uint16(length))
+	copy(p[sctpChunkHeaderLen:], value)
+	clear(p[length:size])
+	buf.Return(size)
+	return
+}
+
+// ReadSCTPChunk
+// 读取 SCTP 块及其填充，不完整时不读掉。
+func ReadSCTPChunk(buf Buffer) (chunkType, chunkFlags byte, value []byte, err error) {
+	p, peekErr := peekFull(buf, sctpChunkHeaderLen)
+	if peekErr != nil {
+		err = peekErr
+		return
+	}
+	length := int(binary.BigEndian.Uint16(p[2:]))
+	if length < sctpChunkHeaderLen {
+		err = ErrSCTPInvalid
+		return
+	}
+	size := (length + 3) &^ 3
+	if p, err = peekFull(buf, size); err != nil {
+		return
+	}
+	chunkType = p[0]
+	chunkFlags = p[1]
+	value = make([]byte, length-sctpChunkHeaderLen)
+	copy(value, p[sctpChunkHeaderLen:length])
+	buf.Discard(size)
+	return
+}
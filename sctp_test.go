@@ -0,0 +1,60 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestWriteSCTPCommonHeader(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	if err := bytebuffers.WriteSCTPCommonHeader(buf, 5000, 36412, 0, 0x12345678); err != nil {
+		t.Fatal(err)
+	}
+	expected, _ := hex.DecodeString("1388" + "8e3c" + "00000000" + "12345678")
+	if p := buf.CloneBytes(); !bytes.Equal(p, expected) {
+		t.Fatal("unexpected header", hex.EncodeToString(p))
+	}
+}
+
+func TestSCTPChunk(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	// INIT: initiate tag, a_rwnd, outbound streams, inbound streams, initial TSN,
+	// followed by a Supported Address Types parameter (IPv4) which leaves the chunk unaligned.
+	value, _ := hex.DecodeString("a1b2c3d4" + "00010000" + "000a" + "ffff" + "00000001" + "000c0006" + "0005")
+	if err := bytebuffers.WriteSCTPChunk(buf, bytebuffers.SCTPChunkInit, 0, value); err != nil {
+		t.Fatal(err)
+	}
+	expected, _ := hex.DecodeString("0100001a" + hex.EncodeToString(value) + "0000")
+	if p := buf.CloneBytes(); !bytes.Equal(p, expected) {
+		t.Fatal("unexpected chunk", hex.EncodeToString(p))
+	}
+	if buf.Len()%4 != 0 {
+		t.Fatal("chunk must be padded to 4 bytes", buf.Len())
+	}
+
+	chunkType, chunkFlags, data, err := bytebuffers.ReadSCTPChunk(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chunkType != bytebuffers.SCTPChunkInit || chunkFlags != 0 || !bytes.Equal(data, value) {
+		t.Fatal("unexpected chunk", chunkType, chunkFlags, data)
+	}
+	if buf.Len() != 0 {
+		t.Fatal("padding must be discarded")
+	}
+
+	_, _ = buf.Write(expected[:27])
+	if _, _, _, err = bytebuffers.ReadSCTPChunk(buf); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal("expected unexpected EOF, got", err)
+	}
+	buf.Reset()
+	_, _ = buf.Write([]byte{bytebuffers.SCTPChunkCookieAck, 0, 0, 2})
+	if _, _, _, err = bytebuffers.ReadSCTPChunk(buf); !errors.Is(err, bytebuffers.ErrSCTPInvalid) {
+		t.Fatal("expected invalid, got", err)
+	}
+}
@@ -0,0 +1,185 @@
+package bytebuffers
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/textproto"
+	"strconv"
+	"strings"
+)
+
+const (
+	sipVersion     = "SIP/2.0"
+	sipMaxForwards = 70
+)
+
+var (
+	ErrSIPInvalid = errors.New("bytebuffers.SIP: invalid message")
+)
+
+// sipHeaderNames
+// SIP 头的规范名称，含 RFC 3261 7.3.3 的紧凑形式。
+var sipHeaderNames = map[string]string{
+	"call-id":          "Call-ID",
+	"i":                "Call-ID",
+	"cseq":             "CSeq",
+	"via":              "Via",
+	"v":                "Via",
+	"from":             "From",
+	"f":                "From",
+	"to":               "To",
+	"t":                "To",
+	"contact":          "Contact",
+	"m":                "Contact",
+	"content-length":   "Content-Length",
+	"l":                "Content-Length",
+	"content-type":     "Content-Type",
+	"c":                "Content-Type",
+	"content-encoding": "Content-Encoding",
+	"e":                "Content-Encoding",
+	"subject":          "Subject",
+	"s":                "Subject",
+	"supported":        "Supported",
+	"k":                "Supported",
+	"www-authenticate": "WWW-Authenticate",
+	"max-forwards":     "Max-Forwards",
+}
+
+// WriteSIPRequest
+// 写入 SIP 请求：请求行与必需的头（Via、Max-Forwards、From、To、Call-ID、CSeq、Content-Length）及 body。
+//
+// CSeq 为 cseq 加上 method，Content-Length 按 body 自动设置。各字段不能含有 CR 或 LF。
+func WriteSIPRequest(buf Buffer, method, requestURI, via, from, to, callID string, cseq uint32, body []byte) (err error) {
+	fields := [...]string{method, requestURI, via, from, to, callID}
+	size := 128 // request line, header names and numbers
+	for _, field := range fields {
+		if len(field) == 0 || strings.ContainsAny(field, "\r\n") {
+			err = ErrSIPInvalid
+			return
+		}
+		size += len(field)
+	}
+	if strings.ContainsAny(method, " \t") || strings.ContainsAny(requestURI, " \t") {
+		err = ErrSIPInvalid
+		return
+	}
+	size += len(method) // CSeq method
+	if len(body) > maxInt-size {
+		err = ErrTooLarge
+		return
+	}
+	size += len(body)
+	p, borrowErr := buf.Borrow(size)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	p = p[:0]
+	p = append(p, method...)
+	p = append(p, ' ')
+	p = append(p, requestURI...)
+	p = append(p, " "+sipVersion+"\r\nVia: "...)
+	p = append(p, via...)
+	p = append(p, "\r\nMax-Forwards: "...)
+	p = strconv.AppendInt(p, sipMaxForwards, 10)
+	p = append(p, "\r\nFrom: "...)
+	p = append(p, from...)
+	p = append(p, "\r\nTo: "...)
+	p = append(p, to...)
+	p = append(p, "\r\nCall-ID: "...)
+	p = append(p, callID...)
+	p = append(p, "\r\nCSeq: "...)
+	p = strconv.AppendUint(p, uint64(cseq), 10)
+	p = append(p, ' ')
+	p = append(p, method...)
+	p = append(p, "\r\nContent-Length: "...)
+	p = strconv.AppendInt(p, int64(len(body)), 10)
+	p = append(p, "\r\n\r\n"...)
+	p = append(p, body...)
+	buf.Return(len(p))
+	return
+}
+
+// ReadSIPMessage
+// 读取一个 SIP 消息，前导的 CRLF 保活会被读掉。
+//
+// 请求时 method 与 requestURI 为请求行的方法与 Request-URI；响应时 method 为 "SIP/2.0"，requestURI 为状态码与原因短语。
+// 头名称转为规范形式（紧凑形式展开），折叠的头会合并为一行。没有 Content-Length 时 body 为空。
+// 不完整时返回 io.ErrUnexpectedEOF 且不读掉。
+func ReadSIPMessage(buf Buffer) (method, requestURI string, headers map[string][]string, body []byte, err error) {
+	p := buf.Peek(buf.Len())
+	skip := 0
+	for skip+1 < len(p) && p[skip] == '\r' && p[skip+1] == '\n' {
+		skip += 2
+	}
+	if skip == len(p) {
+		buf.Discard(skip)
+		err = io.EOF
+		return
+	}
+	p = p[skip:]
+	end := bytes.Index(p, []byte("\r\n\r\n"))
+	if end < 0 {
+		err = io.ErrUnexpectedEOF
+		return
+	}
+	lines := strings.Split(string(p[:end]), "\r\n")
+	start := strings.SplitN(lines[0], " ", 3)
+	if len(start) != 3 {
+		err = ErrSIPInvalid
+		return
+	}
+	if start[0] == sipVersion {
+		method, requestURI = start[0], start[1]+" "+start[2]
+	} else if start[2] == sipVersion {
+		method, requestURI = start[0], start[1]
+	} else {
+		err = ErrSIPInvalid
+		return
+	}
+	headers = make(map[string][]string)
+	name := ""
+	for _, line := range lines[1:] {
+		if line[0] == ' ' || line[0] == '\t' { // folded
+			if name == "" {
+				method, requestURI, headers, err = "", "", nil, ErrSIPInvalid
+				return
+			}
+			values := headers[name]
+			values[len(values)-1] += " " + strings.TrimSpace(line)
+			continue
+		}
+		i := strings.IndexByte(line, ':')
+		if i < 1 {
+			method, requestURI, headers, err = "", "", nil, ErrSIPInvalid
+			return
+		}
+		name = sipHeaderName(strings.TrimSpace(line[:i]))
+		headers[name] = append(headers[name], strings.TrimSpace(line[i+1:]))
+	}
+	n := end + 4
+	if cl, has := headers["Content-Length"]; has {
+		size, convErr := strconv.Atoi(cl[0])
+		if convErr != nil || size < 0 {
+			method, requestURI, headers, err = "", "", nil, ErrSIPInvalid
+			return
+		}
+		if len(p)-n < size {
+			method, requestURI, headers, err = "", "", nil, io.ErrUnexpectedEOF
+			return
+		}
+		body = make([]byte, size)
+		copy(body, p[n:])
+		n += size
+	}
+	buf.Discard(skip + n)
+	return
+}
+
+func sipHeaderName(name string) string {
+	if canonical, has := sipHeaderNames[strings.ToLower(name)]; has {
+		return canonical
+	}
+	return textproto.CanonicalMIMEHeaderKey(name)
+}
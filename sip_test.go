@@ -0,0 +1,103 @@
+package bytebuffers_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestWriteSIPRequest(t *testing.T) {
+	sdp := []byte("v=0\r\no=alice 2890844526 2890844526 IN IP4 pc33.atlanta.com\r\n")
+	buf := bytebuffers.NewBuffer()
+	err := bytebuffers.WriteSIPRequest(buf, "INVITE", "sip:bob@biloxi.com",
+		"SIP/2.0/UDP pc33.atlanta.com;branch=z9hG4bK776asdhds",
+		"Alice <sip:alice@atlanta.com>;tag=1928301774",
+		"Bob <sip:bob@biloxi.com>",
+		"a84b4c76e66710@pc33.atlanta.com", 314159, sdp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "INVITE sip:bob@biloxi.com SIP/2.0\r\n" +
+		"Via: SIP/2.0/UDP pc33.atlanta.com;branch=z9hG4bK776asdhds\r\n" +
+		"Max-Forwards: 70\r\n" +
+		"From: Alice <sip:alice@atlanta.com>;tag=1928301774\r\n" +
+		"To: Bob <sip:bob@biloxi.com>\r\n" +
+		"Call-ID: a84b4c76e66710@pc33.atlanta.com\r\n" +
+		"CSeq: 314159 INVITE\r\n" +
+		"Content-Length: 60\r\n" +
+		"\r\n" + string(sdp)
+	if s := string(buf.Peek(buf.Len())); s != expected {
+		t.Fatalf("unexpected request %q", s)
+	}
+
+	method, uri, headers, body, err := bytebuffers.ReadSIPMessage(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if method != "INVITE" || uri != "sip:bob@biloxi.com" || string(body) != string(sdp) {
+		t.Fatal("unexpected request", method, uri, string(body))
+	}
+	if headers["CSeq"][0] != "314159 INVITE" || headers["Content-Length"][0] != "60" {
+		t.Fatal("unexpected headers", headers)
+	}
+
+	if err = bytebuffers.WriteSIPRequest(buf, "BYE", "sip:alice@pc33.atlanta.com",
+		"SIP/2.0/UDP 192.0.2.4;branch=z9hG4bKnashds10",
+		"Bob <sip:bob@biloxi.com>;tag=a6c85cf",
+		"Alice <sip:alice@atlanta.com>;tag=1928301774",
+		"a84b4c76e66710", 231, nil); err != nil {
+		t.Fatal(err)
+	}
+	if method, _, headers, body, err = bytebuffers.ReadSIPMessage(buf); err != nil {
+		t.Fatal(err)
+	}
+	if method != "BYE" || headers["CSeq"][0] != "231 BYE" || headers["Content-Length"][0] != "0" || len(body) != 0 {
+		t.Fatal("unexpected request", method, headers, body)
+	}
+
+	if err = bytebuffers.WriteSIPRequest(buf, "BYE", "sip:alice@atlanta.com", "v", "f\r\nX: y", "t", "c", 1, nil); !errors.Is(err, bytebuffers.ErrSIPInvalid) {
+		t.Fatal("expected invalid, got", err)
+	}
+}
+
+func TestReadSIPMessage(t *testing.T) {
+	response := "\r\n" +
+		"SIP/2.0 200 OK\r\n" +
+		"v: SIP/2.0/UDP server10.biloxi.com;branch=z9hG4bKnashds8\r\n" +
+		"Via: SIP/2.0/UDP pc33.atlanta.com\r\n" +
+		" ;branch=z9hG4bK776asdhds\r\n" +
+		"From: Alice <sip:alice@atlanta.com>;tag=1928301774\r\n" +
+		"To: Bob <sip:bob@biloxi.com>;tag=a6c85cf\r\n" +
+		"i: a84b4c76e66710@pc33.atlanta.com\r\n" +
+		"CSeq: 314159 INVITE\r\n" +
+		"l: 4\r\n" +
+		"\r\n" +
+		"v=0\n"
+	buf := bytebuffers.NewBuffer()
+	_, _ = buf.WriteString(response[:len(response)-1])
+	if _, _, _, _, err := bytebuffers.ReadSIPMessage(buf); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal("expected unexpected EOF, got", err)
+	}
+	_, _ = buf.WriteString(response[len(response)-1:])
+
+	method, status, headers, body, err := bytebuffers.ReadSIPMessage(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if method != "SIP/2.0" || status != "200 OK" || string(body) != "v=0\n" {
+		t.Fatal("unexpected response", method, status, string(body))
+	}
+	via := headers["Via"]
+	if len(via) != 2 || via[1] != "SIP/2.0/UDP pc33.atlanta.com ;branch=z9hG4bK776asdhds" {
+		t.Fatalf("unexpected via %q", via)
+	}
+	if headers["Call-ID"][0] != "a84b4c76e66710@pc33.atlanta.com" || !strings.HasSuffix(headers["To"][0], "tag=a6c85cf") {
+		t.Fatal("unexpected headers", headers)
+	}
+	if buf.Len() != 0 {
+		t.Fatal("message must be discarded")
+	}
+}
@@ -0,0 +1,25 @@
+package bytebuffers
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/golang/snappy"
+)
+
+// CompressSnappyFramed
+// 以 Snappy 帧格式压缩可读内容并替换之，每个块均带有 CRC32C 校验。
+func CompressSnappyFramed(buf Buffer) (err error) {
+	tmp := Acquire()
+	defer Release(tmp)
+	err = compress(buf, tmp, snappy.NewBufferedWriter(tmp))
+	return
+}
+
+// DecompressSnappyFramed
+// 解压 Snappy 帧格式的可读内容并替换之，校验流标识与每个块的 CRC32C，失败时可读内容不变。
+func DecompressSnappyFramed(buf Buffer) (err error) {
+	sr := snappy.NewReader(bytes.NewReader(buf.Peek(buf.Len())))
+	err = decompress(buf, io.NopCloser(sr))
+	return
+}
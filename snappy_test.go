@@ -0,0 +1,61 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"errors"
+	"hash/crc32"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+	"github.com/golang/snappy"
+)
+
+var snappyStreamIdentifier = []byte{0xFF, 0x06, 0x00, 0x00, 's', 'N', 'a', 'P', 'p', 'Y'}
+
+func TestCompressSnappyFramed(t *testing.T) {
+	src := bytes.Repeat([]byte("0123456789"), 10*1024)
+	buf := bytebuffers.NewBuffer()
+	_, _ = buf.Write(src)
+	if err := bytebuffers.CompressSnappyFramed(buf); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(buf.Peek(buf.Len()), snappyStreamIdentifier) {
+		t.Fatal("missing stream identifier")
+	}
+	if err := bytebuffers.DecompressSnappyFramed(buf); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.CloneBytes(), src) {
+		t.Fatal("round trip mismatch")
+	}
+}
+
+func TestDecompressSnappyFramed(t *testing.T) {
+	// a stream with one uncompressed chunk, built per framing_format.txt
+	data := []byte("hello, snappy")
+	crc := crc32.Checksum(data, crc32.MakeTable(crc32.Castagnoli))
+	masked := (crc>>15 | crc<<17) + 0xa282ead8
+	chunkLen := 4 + len(data)
+	stream := append([]byte{}, snappyStreamIdentifier...)
+	stream = append(stream, 0x01, byte(chunkLen), byte(chunkLen>>8), byte(chunkLen>>16))
+	stream = append(stream, byte(masked), byte(masked>>8), byte(masked>>16), byte(masked>>24))
+	stream = append(stream, data...)
+
+	buf := bytebuffers.NewBuffer()
+	_, _ = buf.Write(stream)
+	if err := bytebuffers.DecompressSnappyFramed(buf); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.CloneBytes(), data) {
+		t.Fatal("unexpected content", string(buf.CloneBytes()))
+	}
+
+	stream[len(stream)-1] ^= 0xFF
+	_ = buf.Set(stream)
+	if err := bytebuffers.DecompressSnappyFramed(buf); !errors.Is(err, snappy.ErrCorrupt) {
+		t.Fatal("expected crc mismatch, got", err)
+	}
+	if !bytes.Equal(buf.CloneBytes(), stream) {
+		t.Fatal("buffer changed on failure")
+	}
+}
@@ -0,0 +1,240 @@
+package bytebuffers
+
+import (
+	"encoding/asn1"
+	"errors"
+	"net"
+)
+
+const (
+	SNMPVersion1  = 0
+	SNMPVersion2c = 1
+
+	snmpTagInteger        = 0x02
+	snmpTagOctetString    = 0x04
+	snmpTagNull           = 0x05
+	snmpTagIPAddress      = 0x40
+	snmpTagCounter32      = 0x41
+	snmpTagGauge32        = 0x42
+	snmpTagTimeTicks      = 0x43
+	snmpTagOpaque         = 0x44
+	snmpTagCounter64      = 0x46
+	snmpTagNoSuchObject   = 0x80
+	snmpTagNoSuchInstance = 0x81
+	snmpTagEndOfMibView   = 0x82
+	snmpTagGetRequest     = 0xA0
+	snmpTagGetResponse    = 0xA2
+)
+
+var (
+	ErrSNMPInvalid     = errors.New("bytebuffers.SNMP: invalid message")
+	ErrSNMPErrorStatus = errors.New("bytebuffers.SNMP: response has error status")
+	ErrSNMPNoSuchName  = errors.New("bytebuffers.SNMP: no such object or instance")
+)
+
+// WriteSNMPGetRequest
+// 写入 BER 编码的 SNMP v2c GetRequest 报文，只包含一个值为 NULL 的 oid 变量绑定。
+func WriteSNMPGetRequest(buf Buffer, oid asn1.ObjectIdentifier, community string, requestID int32) (err error) {
+	value, oidErr := appendOID(make([]byte, 0, len(oid)*2), oid)
+	if oidErr != nil {
+		err = oidErr
+		return
+	}
+	err = writeDERConstructed(buf, derTagSequence, func(msg Buffer) (err error) {
+		if err = writeDER(msg, snmpTagInteger, appendDERInt(nil, SNMPVersion2c)); err != nil {
+			return
+		}
+		if err = writeDER(msg, snmpTagOctetString, []byte(community)); err != nil {
+			return
+		}
+		err = writeDERConstructed(msg, snmpTagGetRequest, func(pdu Buffer) (err error) {
+			if err = writeDER(pdu, snmpTagInteger, appendDERInt(nil, int64(requestID))); err != nil {
+				return
+			}
+			if err = writeDER(pdu, snmpTagInteger, []byte{0}); err != nil { // error-status
+				return
+			}
+			if err = writeDER(pdu, snmpTagInteger, []byte{0}); err != nil { // error-index
+				return
+			}
+			err = writeDERConstructed(pdu, derTagSequence, func(list Buffer) error {
+				return writeDERConstructed(list, derTagSequence, func(bind Buffer) (err error) {
+					if err = writeDER(bind, derTagOID, value); err != nil {
+						return
+					}
+					err = writeDER(bind, snmpTagNull, nil)
+					return
+				})
+			})
+			return
+		})
+		return
+	})
+	return
+}
+
+// ReadSNMPResponse
+// 读取 SNMP v1/v2c 的 GetResponse 报文，返回第一个变量绑定的 oid 与值。
+//
+// 值的类型：INTEGER 为 int64，OCTET STRING 与 Opaque 为 []byte，OBJECT IDENTIFIER 为 asn1.ObjectIdentifier，
+// IpAddress 为 net.IP，Counter32、Gauge32、TimeTicks 为 uint32，Counter64 为 uint64，NULL 为 nil。
+// error-status 不为 0 时返回 ErrSNMPErrorStatus，noSuchObject 等异常值返回 ErrSNMPNoSuchName，报文均会读掉。
+// 不完整时不读掉。
+func ReadSNMPResponse(buf Buffer) (oid asn1.ObjectIdentifier, value interface{}, err error) {
+	msg, n, peekErr := peekBER(buf, derTagSequence)
+	if peekErr != nil {
+		err = peekErr
+		return
+	}
+	oid, value, err = parseSNMPResponse(msg)
+	if err == ErrSNMPInvalid {
+		return
+	}
+	buf.Discard(n)
+	return
+}
+
+func parseSNMPResponse(msg []byte) (oid asn1.ObjectIdentifier, value interface{}, err error) {
+	var version, errorStatus, pdu, list, bind, name, raw []byte
+	ok := nextBER(&msg, snmpTagInteger, &version) &&
+		nextBER(&msg, snmpTagOctetString, nil) && // community
+		nextBER(&msg, snmpTagGetResponse, &pdu) &&
+		nextBER(&pdu, snmpTagInteger, nil) && // request-id
+		nextBER(&pdu, snmpTagInteger, &errorStatus) &&
+		nextBER(&pdu, snmpTagInteger, nil) && // error-index
+		nextBER(&pdu, derTagSequence, &list) &&
+		nextBER(&list, derTagSequence, &bind) &&
+		nextBER(&bind, derTagOID, &name) &&
+		len(bind) > 0
+	tag := byte(0)
+	if ok {
+		tag = bind[0]
+		ok = nextBER(&bind, tag, &raw)
+	}
+	if !ok {
+		err = ErrSNMPInvalid
+		return
+	}
+	if v, intErr := parseDERInt(version); intErr != nil || (v != SNMPVersion1 && v != SNMPVersion2c) {
+		err = ErrSNMPInvalid
+		return
+	}
+	status, statusErr := parseDERInt(errorStatus)
+	if statusErr != nil {
+		err = ErrSNMPInvalid
+		return
+	}
+	if oid, err = parseOID(name); err != nil {
+		err = ErrSNMPInvalid
+		return
+	}
+	if value, err = parseSNMPValue(tag, raw); err != nil {
+		return
+	}
+	if status != 0 {
+		err = ErrSNMPErrorStatus
+	}
+	return
+}
+
+// nextBER
+// 从 *p 的开头解析一个标签为 tag 的 BER 值存入 *value（可为 nil），并将 *p 前移。
+func nextBER(p *[]byte, tag byte, value *[]byte) bool {
+	v, n, err := parseDER(*p, tag, true)
+	if err != nil {
+		return false
+	}
+	if value != nil {
+		*value = v
+	}
+	*p = (*p)[n:]
+	return true
+}
+
+func parseSNMPValue(tag byte, raw []byte) (value interface{}, err error) {
+	switch tag {
+	case snmpTagInteger:
+		value, err = parseDERInt(raw)
+	case snmpTagOctetString, snmpTagOpaque:
+		value = append([]byte(nil), raw...)
+	case snmpTagNull:
+		if len(raw) != 0 {
+			err = ErrSNMPInvalid
+		}
+	case derTagOID:
+		value, err = parseOID(raw)
+	case snmpTagIPAddress:
+		if len(raw) != net.IPv4len {
+			err = ErrSNMPInvalid
+			return
+		}
+		value = net.IPv4(raw[0], raw[1], raw[2], raw[3])
+	case snmpTagCounter32, snmpTagGauge32, snmpTagTimeTicks:
+		var v uint64
+		if v, err = parseDERUint(raw, 32); err == nil {
+			value = uint32(v)
+		}
+	case snmpTagCounter64:
+		value, err = parseDERUint(raw, 64)
+	case snmpTagNoSuchObject, snmpTagNoSuchInstance, snmpTagEndOfMibView:
+		err = ErrSNMPNoSuchName
+		return
+	default:
+		err = ErrSNMPInvalid
+	}
+	if err != nil && err != ErrSNMPNoSuchName {
+		value, err = nil, ErrSNMPInvalid
+	}
+	return
+}
+
+// appendDERInt
+// 追加最短的二进制补码 INTEGER 值内容。
+func appendDERInt(p []byte, v int64) []byte {
+	size := 1
+	for i := v; i > 127 || i < -128; i >>= 8 {
+		size++
+	}
+	for i := size - 1; i >= 0; i-- {
+		p = append(p, byte(v>>(8*i)))
+	}
+	return p
+}
+
+// parseDERInt
+// 解析二进制补码的 INTEGER 值内容，不是最短编码或超出 int64 时返回 ErrDERInvalid。
+func parseDERInt(value []byte) (v int64, err error) {
+	if len(value) == 0 || len(value) > 8 {
+		err = ErrDERInvalid
+		return
+	}
+	if len(value) > 1 && ((value[0] == 0 && value[1]&0x80 == 0) || (value[0] == 0xFF && value[1]&0x80 != 0)) {
+		err = ErrDERInvalid
+		return
+	}
+	v = int64(int8(value[0]))
+	for _, b := range value[1:] {
+		v = v<<8 | int64(b)
+	}
+	return
+}
+
+// parseDERUint
+// 解析 bits 位无符号整数的 INTEGER 值内容，允许为表示正数而加的前导 0。
+func parseDERUint(value []byte, bits int) (v uint64, err error) {
+	if len(value) == 0 || value[0]&0x80 != 0 {
+		err = ErrDERInvalid
+		return
+	}
+	if len(value) > 1 && value[0] == 0 {
+		value = value[1:]
+	}
+	if len(value) > bits/8 {
+		err = ErrDERInvalid
+		return
+	}
+	for _, b := range value {
+		v = v<<8 | uint64(b)
+	}
+	return
+}
@@ -0,0 +1,101 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"encoding/asn1"
+	"encoding/hex"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+var sysDescr = asn1.ObjectIdentifier{1, 3, 6, 1, 2, 1, 1, 1, 0}
+
+func TestWriteSNMPGetRequest(t *testing.T) {
+	// snmpget -v2c -c public host 1.3.6.1.2.1.1.1.0
+	expected, _ := hex.DecodeString("302602010104067075626c6963a019020101020100020100300e300c06082b060102010101000500")
+	buf := bytebuffers.NewBuffer()
+	if err := bytebuffers.WriteSNMPGetRequest(buf, sysDescr, "public", 1); err != nil {
+		t.Fatal(err)
+	}
+	if p := buf.CloneBytes(); !bytes.Equal(p, expected) {
+		t.Fatal("unexpected request", hex.EncodeToString(p))
+	}
+	buf.Reset()
+
+	if err := bytebuffers.WriteSNMPGetRequest(buf, sysDescr, "public", 0x12345678); err != nil {
+		t.Fatal(err)
+	}
+	if p := buf.CloneBytes(); !bytes.Contains(p, []byte{0xA0, 0x1C, 0x02, 0x04, 0x12, 0x34, 0x56, 0x78}) {
+		t.Fatal("unexpected request id", hex.EncodeToString(p))
+	}
+
+	if err := bytebuffers.WriteSNMPGetRequest(buf, asn1.ObjectIdentifier{1}, "public", 1); !errors.Is(err, bytebuffers.ErrDERInvalid) {
+		t.Fatal("expected invalid oid, got", err)
+	}
+}
+
+func TestReadSNMPResponse(t *testing.T) {
+	response, _ := hex.DecodeString("302b02010104067075626c6963a21e0201010201000201003013301106082b0601020101010004054c696e7578")
+	buf := bytebuffers.NewBuffer()
+	_, _ = buf.Write(response[:len(response)-1])
+	if _, _, err := bytebuffers.ReadSNMPResponse(buf); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal("expected unexpected EOF, got", err)
+	}
+	_, _ = buf.Write(response[len(response)-1:])
+
+	oid, value, err := bytebuffers.ReadSNMPResponse(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !oid.Equal(sysDescr) {
+		t.Fatal("unexpected oid", oid)
+	}
+	if v, ok := value.([]byte); !ok || string(v) != "Linux" {
+		t.Fatal("unexpected value", value)
+	}
+	if buf.Len() != 0 {
+		t.Fatal("response must be discarded")
+	}
+
+	// sysUpTime.0 as TimeTicks
+	_, _ = buf.Write([]byte{
+		0x30, 0x29, 0x02, 0x01, 0x01, 0x04, 0x06, 'p', 'u', 'b', 'l', 'i', 'c',
+		0xA2, 0x1C, 0x02, 0x01, 0x02, 0x02, 0x01, 0x00, 0x02, 0x01, 0x00,
+		0x30, 0x11, 0x30, 0x0F, 0x06, 0x08, 0x2B, 0x06, 0x01, 0x02, 0x01, 0x01, 0x03, 0x00,
+		0x43, 0x03, 0x01, 0xE2, 0x40,
+	})
+	if _, value, err = bytebuffers.ReadSNMPResponse(buf); err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := value.(uint32); !ok || v != 123456 {
+		t.Fatal("unexpected value", value)
+	}
+
+	// BER allows non-minimal long-form lengths, as some agents send for the outer sequence
+	_, _ = buf.Write([]byte{0x30, 0x82, 0x00, 0x2B})
+	_, _ = buf.Write(response[2:])
+	if oid, value, err = bytebuffers.ReadSNMPResponse(buf); err != nil || !oid.Equal(sysDescr) || buf.Len() != 0 {
+		t.Fatal("unexpected long-form response", oid, value, err)
+	}
+	if v, ok := value.([]byte); !ok || string(v) != "Linux" {
+		t.Fatal("unexpected value", value)
+	}
+
+	// a length beyond int32 is invalid rather than overflowing
+	_, _ = buf.Write([]byte{0x30, 0x84, 0x80, 0x00, 0x00, 0x00})
+	if _, _, err = bytebuffers.ReadSNMPResponse(buf); !errors.Is(err, bytebuffers.ErrDERInvalid) {
+		t.Fatal("expected invalid length, got", err)
+	}
+	buf.Reset()
+
+	// GetRequest is not a response
+	if err = bytebuffers.WriteSNMPGetRequest(buf, sysDescr, "public", 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err = bytebuffers.ReadSNMPResponse(buf); !errors.Is(err, bytebuffers.ErrSNMPInvalid) {
+		t.Fatal("expected invalid, got", err)
+	}
+}
@@ -0,0 +1,146 @@
+package bytebuffers
+
+import (
+	"encoding/binary"
+	"errors"
+	"net"
+)
+
+const (
+	SOCKS5Version = 0x05
+
+	SOCKS5CmdConnect      = 0x01
+	SOCKS5CmdBind         = 0x02
+	SOCKS5CmdUDPAssociate = 0x03
+
+	SOCKS5AddrIPv4   = 0x01
+	SOCKS5AddrDomain = 0x03
+	SOCKS5AddrIPv6   = 0x04
+)
+
+var (
+	ErrSOCKS5Version        = errors.New("bytebuffers.SOCKS5: invalid version")
+	ErrSOCKS5InvalidMethods = errors.New("bytebuffers.SOCKS5: invalid methods")
+	ErrSOCKS5InvalidAddress = errors.New("bytebuffers.SOCKS5: invalid address")
+)
+
+// WriteSOCKS5Greeting
+// 写入 SOCKS5 客户端问候：[5, nMethods, methods...]。
+func WriteSOCKS5Greeting(buf Buffer, methods []byte) (err error) {
+	n := len(methods)
+	if n == 0 || n > 255 {
+		err = ErrSOCKS5InvalidMethods
+		return
+	}
+	p, borrowErr := buf.Borrow(2 + n)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	p[0] = SOCKS5Version
+	p[1] = byte(n)
+	copy(p[2:], methods)
+	buf.Return(2 + n)
+	return
+}
+
+// ReadSOCKS5Greeting
+// 读取 SOCKS5 客户端问候，版本不为 5 时返回 ErrSOCKS5Version，不完整时不读掉。
+func ReadSOCKS5Greeting(buf Buffer) (version byte, methods []byte, err error) {
+	p, peekErr := peekFull(buf, 2)
+	if peekErr != nil {
+		err = peekErr
+		return
+	}
+	if p[0] != SOCKS5Version {
+		err = ErrSOCKS5Version
+		return
+	}
+	size := 2 + int(p[1])
+	if p, err = peekFull(buf, size); err != nil {
+		return
+	}
+	version = p[0]
+	methods = make([]byte, size-2)
+	copy(methods, p[2:])
+	buf.Discard(size)
+	return
+}
+
+// WriteSOCKS5Request
+// 写入 SOCKS5 请求：[5, cmd, 0, atyp, addr, port]。
+//
+// host 为 IPv4 或 IPv6 字面量时使用对应地址类型，否则作为域名（最长 255 字节）。
+func WriteSOCKS5Request(buf Buffer, cmd byte, host string, port uint16) (err error) {
+	var atyp byte
+	var addr []byte
+	if ip := net.ParseIP(host); ip != nil {
+		if ip4 := ip.To4(); ip4 != nil {
+			atyp, addr = SOCKS5AddrIPv4, ip4
+		} else {
+			atyp, addr = SOCKS5AddrIPv6, ip.To16()
+		}
+	} else {
+		if host == "" || len(host) > 255 {
+			err = ErrSOCKS5InvalidAddress
+			return
+		}
+		atyp = SOCKS5AddrDomain
+		addr = append(make([]byte, 0, 1+len(host)), byte(len(host)))
+		addr = append(addr, host...)
+	}
+	size := 4 + len(addr) + 2
+	p, borrowErr := buf.Borrow(size)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	p[0] = SOCKS5Version
+	p[1] = cmd
+	p[2] = 0
+	p[3] = atyp
+	n := 4 + copy(p[4:], addr)
+	binary.BigEndian.PutUint16(p[n:], port)
+	buf.Return(size)
+	return
+}
+
+// ReadSOCKS5Request
+// 读取 SOCKS5 请求，返回命令、主机与端口，不完整时不读掉。
+func ReadSOCKS5Request(buf Buffer) (cmd byte, host string, port uint16, err error) {
+	p, peekErr := peekFull(buf, 5)
+	if peekErr != nil {
+		err = peekErr
+		return
+	}
+	if p[0] != SOCKS5Version {
+		err = ErrSOCKS5Version
+		return
+	}
+	var addrLen, addrOff int
+	switch p[3] {
+	case SOCKS5AddrIPv4:
+		addrLen, addrOff = net.IPv4len, 4
+	case SOCKS5AddrIPv6:
+		addrLen, addrOff = net.IPv6len, 4
+	case SOCKS5AddrDomain:
+		addrLen, addrOff = int(p[4]), 5
+	default:
+		err = ErrSOCKS5InvalidAddress
+		return
+	}
+	size := addrOff + addrLen + 2
+	if p, err = peekFull(buf, size); err != nil {
+		return
+	}
+	addr := p[addrOff : addrOff+addrLen]
+	if p[3] == SOCKS5AddrDomain {
+		host = string(addr)
+	} else {
+		host = net.IP(addr).String()
+	}
+	cmd = p[1]
+	port = binary.BigEndian.Uint16(p[addrOff+addrLen:])
+	buf.Discard(size)
+	return
+}
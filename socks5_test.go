@@ -0,0 +1,75 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestWriteSOCKS5Greeting(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	if err := bytebuffers.WriteSOCKS5Greeting(buf, []byte{0x00, 0x02}); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.CloneBytes(), []byte{0x05, 0x02, 0x00, 0x02}) {
+		t.Fatal("unexpected greeting", buf.CloneBytes())
+	}
+	version, methods, err := bytebuffers.ReadSOCKS5Greeting(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != 5 || !bytes.Equal(methods, []byte{0x00, 0x02}) {
+		t.Fatal("round trip mismatch", version, methods)
+	}
+
+	_, _ = buf.Write([]byte{0x04, 0x01, 0x00})
+	if _, _, err = bytebuffers.ReadSOCKS5Greeting(buf); !errors.Is(err, bytebuffers.ErrSOCKS5Version) {
+		t.Fatal("expected version error, got", err)
+	}
+	_ = buf.Set([]byte{0x05, 0x02, 0x00})
+	if _, _, err = bytebuffers.ReadSOCKS5Greeting(buf); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal("expected unexpected EOF, got", err)
+	}
+	if err = bytebuffers.WriteSOCKS5Greeting(buf, nil); !errors.Is(err, bytebuffers.ErrSOCKS5InvalidMethods) {
+		t.Fatal("expected invalid methods, got", err)
+	}
+}
+
+func TestWriteSOCKS5Request(t *testing.T) {
+	cases := []struct {
+		host    string
+		encoded []byte
+	}{
+		{"192.168.1.1", []byte{0x05, 0x01, 0x00, 0x01, 192, 168, 1, 1, 0x01, 0xBB}},
+		{"example.com", append(append([]byte{0x05, 0x01, 0x00, 0x03, 11}, "example.com"...), 0x01, 0xBB)},
+		{"2001:db8::1", []byte{0x05, 0x01, 0x00, 0x04, 0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0x01, 0xBB}},
+	}
+	buf := bytebuffers.NewBuffer()
+	for _, c := range cases {
+		if err := bytebuffers.WriteSOCKS5Request(buf, bytebuffers.SOCKS5CmdConnect, c.host, 443); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(buf.CloneBytes(), c.encoded) {
+			t.Fatal("unexpected request", c.host, buf.CloneBytes())
+		}
+		cmd, host, port, err := bytebuffers.ReadSOCKS5Request(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cmd != bytebuffers.SOCKS5CmdConnect || host != c.host || port != 443 {
+			t.Fatal("round trip mismatch", cmd, host, port)
+		}
+	}
+
+	_, _ = buf.Write(cases[1].encoded[:8])
+	if _, _, _, err := bytebuffers.ReadSOCKS5Request(buf); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal("expected unexpected EOF, got", err)
+	}
+	_ = buf.Set([]byte{0x04, 0x01, 0x00, 0x01, 1, 2, 3, 4, 0, 80})
+	if _, _, _, err := bytebuffers.ReadSOCKS5Request(buf); !errors.Is(err, bytebuffers.ErrSOCKS5Version) {
+		t.Fatal("expected version error, got", err)
+	}
+}
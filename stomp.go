@@ -0,0 +1,157 @@
+package bytebuffers
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrSTOMPInvalid = errors.New("bytebuffers.STOMP: invalid frame")
+)
+
+var (
+	stompEscaper   = strings.NewReplacer("\\", "\\\\", "\r", "\\r", "\n", "\\n", ":", "\\c")
+	stompUnescaper = strings.NewReplacer("\\\\", "\\", "\\r", "\r", "\\n", "\n", "\\c", ":")
+)
+
+// WriteStompFrame
+// 写入 STOMP 帧：命令、按键排序的头、空行、消息体与 NUL 结束符。
+//
+// 除 CONNECT 与 CONNECTED 帧外，头会按 STOMP 1.2 转义。当消息体非空且未指定 content-length 时自动添加。
+func WriteStompFrame(buf Buffer, command string, headers map[string]string, body []byte) (err error) {
+	if command == "" || strings.ContainsAny(command, "\r\n\x00") {
+		err = ErrSTOMPInvalid
+		return
+	}
+	escape := command != "CONNECT" && command != "CONNECTED"
+	keys := make([]string, 0, len(headers)+1)
+	for k := range headers {
+		keys = append(keys, k)
+	}
+	if _, has := headers["content-length"]; !has && len(body) > 0 {
+		keys = append(keys, "content-length")
+	}
+	sort.Strings(keys)
+
+	frame := make([]byte, 0, len(command)+len(body)+32*len(keys))
+	frame = append(frame, command...)
+	frame = append(frame, '\n')
+	for _, k := range keys {
+		v, has := headers[k]
+		if !has {
+			v = strconv.Itoa(len(body))
+		}
+		if escape {
+			k, v = stompEscaper.Replace(k), stompEscaper.Replace(v)
+		}
+		frame = append(frame, k...)
+		frame = append(frame, ':')
+		frame = append(frame, v...)
+		frame = append(frame, '\n')
+	}
+	frame = append(frame, '\n')
+	frame = append(frame, body...)
+	frame = append(frame, 0)
+	_, err = buf.Write(frame)
+	return
+}
+
+// ReadStompFrame
+// 读取 STOMP 帧，先导的心跳换行会被读掉。
+//
+// 有 content-length 时按其读取消息体，否则读至 NUL。帧不完整时返回 io.ErrUnexpectedEOF 且不读掉。
+func ReadStompFrame(buf Buffer) (command string, headers map[string]string, body []byte, err error) {
+	p := buf.Peek(buf.Len())
+	skip := 0
+	for skip < len(p) && (p[skip] == '\n' || p[skip] == '\r') {
+		skip++
+	}
+	if skip == len(p) {
+		buf.Discard(skip)
+		err = io.EOF
+		return
+	}
+	p = p[skip:]
+	line, n, lineErr := stompLine(p, 0)
+	if lineErr != nil {
+		err = lineErr
+		return
+	}
+	if len(line) == 0 {
+		err = ErrSTOMPInvalid
+		return
+	}
+	command = string(line)
+	escape := command != "CONNECT" && command != "CONNECTED"
+	headers = make(map[string]string)
+	for {
+		if line, n, err = stompLine(p, n); err != nil {
+			command, headers = "", nil
+			return
+		}
+		if len(line) == 0 {
+			break
+		}
+		i := bytes.IndexByte(line, ':')
+		if i < 1 {
+			command, headers, err = "", nil, ErrSTOMPInvalid
+			return
+		}
+		k, v := string(line[:i]), string(line[i+1:])
+		if escape {
+			k, v = stompUnescaper.Replace(k), stompUnescaper.Replace(v)
+		}
+		if _, has := headers[k]; !has {
+			headers[k] = v
+		}
+	}
+	end := -1
+	if cl, has := headers["content-length"]; has {
+		size, convErr := strconv.Atoi(cl)
+		if convErr != nil || size < 0 {
+			command, headers, err = "", nil, ErrSTOMPInvalid
+			return
+		}
+		if size > len(p)-n-1 { // compare without adding to size, which may be near maxInt
+			command, headers, err = "", nil, io.ErrUnexpectedEOF
+			return
+		}
+		if end = n + size; p[end] != 0 {
+			command, headers, err = "", nil, ErrSTOMPInvalid
+			return
+		}
+	} else if i := bytes.IndexByte(p[n:], 0); i >= 0 {
+		end = n + i
+	} else {
+		command, headers, err = "", nil, io.ErrUnexpectedEOF
+		return
+	}
+	body = make([]byte, end-n)
+	copy(body, p[n:end])
+	buf.Discard(skip + end + 1)
+	return
+}
+
+// stompLine
+// 从 p[off:] 读取一行（去除 \n 或 \r\n），返回该行与下一行的偏移。
+func stompLine(p []byte, off int) (line []byte, next int, err error) {
+	i := bytes.IndexByte(p[off:], '\n')
+	if i < 0 {
+		if bytes.IndexByte(p[off:], 0) >= 0 {
+			err = ErrSTOMPInvalid
+			return
+		}
+		err = io.ErrUnexpectedEOF
+		return
+	}
+	line = p[off : off+i]
+	next = off + i + 1
+	if len(line) > 0 && line[len(line)-1] == '\r' {
+		line = line[:len(line)-1]
+	}
+	return
+}
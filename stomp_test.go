@@ -0,0 +1,77 @@
+package bytebuffers_test
+
+import (
+	"errors"
+	"io"
+	"maps"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestWriteStompFrame(t *testing.T) {
+	frames := []struct {
+		command string
+		headers map[string]string
+		body    []byte
+	}{
+		{"CONNECT", map[string]string{"accept-version": "1.2", "host": "stomp.example.com"}, nil},
+		{"SEND", map[string]string{"destination": "/queue/a", "content-type": "text/plain", "note": "a:b\nc"}, []byte("hello\x00world")},
+		{"SUBSCRIBE", map[string]string{"id": "0", "destination": "/queue/a", "ack": "client"}, nil},
+	}
+	buf := bytebuffers.NewBuffer()
+	for _, f := range frames {
+		if err := bytebuffers.WriteStompFrame(buf, f.command, f.headers, f.body); err != nil {
+			t.Fatal(err)
+		}
+		if p := buf.Peek(buf.Len()); p[len(p)-1] != 0 {
+			t.Fatal("frame is not null terminated")
+		}
+	}
+	for _, f := range frames {
+		command, headers, body, err := bytebuffers.ReadStompFrame(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(f.body) > 0 {
+			delete(headers, "content-length")
+		}
+		if command != f.command || !maps.Equal(headers, f.headers) || string(body) != string(f.body) {
+			t.Fatal("round trip mismatch", command, headers, string(body))
+		}
+	}
+	if buf.Len() != 0 {
+		t.Fatal("unexpected remains", buf.Len())
+	}
+}
+
+func TestReadStompFrame(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	const frame = "\n\nMESSAGE\r\ndestination:/queue/a\r\nmessage-id:007\r\n\r\nhi\x00"
+	_, _ = buf.WriteString(frame)
+	command, headers, body, err := bytebuffers.ReadStompFrame(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if command != "MESSAGE" || headers["message-id"] != "007" || string(body) != "hi" {
+		t.Fatal("unexpected frame", command, headers, string(body))
+	}
+
+	_, _ = buf.WriteString(frame[:len(frame)-1])
+	if _, _, _, err = bytebuffers.ReadStompFrame(buf); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal("expected unexpected EOF, got", err)
+	}
+	if buf.Len() != len(frame)-1 {
+		t.Fatal("incomplete frame consumed")
+	}
+
+	_ = buf.SetString("SEND\ncontent-length:9223372036854775807\n\nhi\x00")
+	if _, _, _, err = bytebuffers.ReadStompFrame(buf); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal("expected unexpected EOF for huge content-length, got", err)
+	}
+
+	_ = buf.SetString("SEND\nbad header\n\n\x00")
+	if _, _, _, err = bytebuffers.ReadStompFrame(buf); !errors.Is(err, bytebuffers.ErrSTOMPInvalid) {
+		t.Fatal("expected invalid, got", err)
+	}
+}
@@ -0,0 +1,50 @@
+package bytebuffers
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+	"time"
+)
+
+func (buf *buffer) WriteTimestamp(t time.Time) (err error) {
+	buf.ur = 0
+	if buf.Borrowing() {
+		err = ErrWriteWhenBorrowing
+		return
+	}
+	if buf.c-buf.w < 8 {
+		if err = buf.grow(8); err != nil {
+			return
+		}
+	}
+	nano := int64(math.MinInt64) // zero time is out of the UnixNano range
+	if !t.IsZero() {
+		nano = t.UnixNano()
+	}
+	binary.BigEndian.PutUint64(buf.b[buf.w:], uint64(nano))
+	buf.w += 8
+	buf.a = buf.w
+	return
+}
+
+func (buf *buffer) ReadTimestamp() (t time.Time, err error) {
+	buf.ur = 0
+	bLen := buf.Len()
+	if bLen == 0 {
+		err = io.EOF
+		return
+	}
+	if bLen < 8 {
+		err = io.ErrUnexpectedEOF
+		return
+	}
+	nano := int64(binary.BigEndian.Uint64(buf.b[buf.r:]))
+	buf.r += 8
+	buf.shrink()
+	if nano == math.MinInt64 {
+		return
+	}
+	t = time.Unix(0, nano).UTC()
+	return
+}
@@ -0,0 +1,48 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestBuffer_WriteTimestamp(t *testing.T) {
+	cases := []time.Time{
+		time.Now(),
+		time.Unix(0, 0),
+		time.Date(2024, 2, 29, 12, 0, 0, 1, time.FixedZone("UTC+8", 8*3600)),
+		{},
+	}
+	buf := bytebuffers.NewBuffer()
+	for _, c := range cases {
+		if err := buf.WriteTimestamp(c); err != nil {
+			t.Fatal(err)
+		}
+		if !c.IsZero() {
+			expected := binary.BigEndian.AppendUint64(nil, uint64(c.UnixNano()))
+			if !bytes.Equal(buf.Peek(8), expected) {
+				t.Fatal("encoding mismatch", buf.Peek(8), expected)
+			}
+		}
+		rt, err := buf.ReadTimestamp()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !rt.Equal(c) {
+			t.Fatal("round trip mismatch", rt, c)
+		}
+		if !c.IsZero() && rt.Location() != time.UTC {
+			t.Fatal("timestamp is not UTC", rt.Location())
+		}
+	}
+
+	_, _ = buf.Write([]byte{1, 2, 3})
+	if _, err := buf.ReadTimestamp(); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal("expected unexpected EOF, got", err)
+	}
+}
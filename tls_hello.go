@@ -0,0 +1,202 @@
+package bytebuffers
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+const (
+	TLSHandshakeClientHello = 1
+
+	tlsHandshakeHeaderLen = 4
+	tlsRandomLen          = 32
+	tlsMaxSessionIDLen    = 32
+)
+
+var (
+	ErrTLSHelloInvalid = errors.New("bytebuffers.TLS: invalid client hello")
+)
+
+// TLSExtension
+// ClientHello 中的扩展，Data 不包含类型与长度。
+type TLSExtension struct {
+	Type uint16
+	Data []byte
+}
+
+// ClientHello
+// TLS ClientHello 消息。
+//
+// RecordVersion 为记录层的版本，为 0 时写入 0x0301；Version 为 legacy_version，TLS 1.3 为 0x0303，实际版本在 supported_versions 扩展中。
+type ClientHello struct {
+	RecordVersion      uint16
+	Version            uint16
+	Random             [tlsRandomLen]byte
+	SessionID          []byte
+	CipherSuites       []uint16
+	CompressionMethods []byte
+	Extensions         []TLSExtension
+}
+
+// WriteTLSHandshakeHello
+// 将 ClientHello 写为一个 Handshake 记录：记录头、Handshake 头（类型 1 与 24 位长度）与 ClientHello 字段。
+//
+// 消息超过单个记录的 2^14 字节时返回 ErrRecordTooLarge。
+func WriteTLSHandshakeHello(buf Buffer, clientHello *ClientHello) (err error) {
+	if clientHello == nil || len(clientHello.SessionID) > tlsMaxSessionIDLen ||
+		len(clientHello.CipherSuites) == 0 || len(clientHello.CipherSuites) > 0x7FFF ||
+		len(clientHello.CompressionMethods) == 0 || len(clientHello.CompressionMethods) > 0xFF {
+		err = ErrTLSHelloInvalid
+		return
+	}
+	extsLen := 0
+	for _, ext := range clientHello.Extensions {
+		if len(ext.Data) > 0xFFFF {
+			err = ErrRecordTooLarge
+			return
+		}
+		extsLen += 4 + len(ext.Data)
+	}
+	bodyLen := 2 + tlsRandomLen + 1 + len(clientHello.SessionID) + 2 + 2*len(clientHello.CipherSuites) + 1 + len(clientHello.CompressionMethods)
+	if len(clientHello.Extensions) > 0 {
+		bodyLen += 2 + extsLen
+	}
+	if tlsHandshakeHeaderLen+bodyLen > tlsMaxPlaintext {
+		err = ErrRecordTooLarge
+		return
+	}
+
+	fragment := make([]byte, 0, tlsHandshakeHeaderLen+bodyLen)
+	fragment = append(fragment, TLSHandshakeClientHello, byte(bodyLen>>16), byte(bodyLen>>8), byte(bodyLen))
+	fragment = binary.BigEndian.AppendUint16(fragment, clientHello.Version)
+	fragment = append(fragment, clientHello.Random[:]...)
+	fragment = append(fragment, byte(len(clientHello.SessionID)))
+	fragment = append(fragment, clientHello.SessionID...)
+	fragment = binary.BigEndian.AppendUint16(fragment, uint16(2*len(clientHello.CipherSuites)))
+	for _, suite := range clientHello.CipherSuites {
+		fragment = binary.BigEndian.AppendUint16(fragment, suite)
+	}
+	fragment = append(fragment, byte(len(clientHello.CompressionMethods)))
+	fragment = append(fragment, clientHello.CompressionMethods...)
+	if len(clientHello.Extensions) > 0 {
+		fragment = binary.BigEndian.AppendUint16(fragment, uint16(extsLen))
+		for _, ext := range clientHello.Extensions {
+			fragment = binary.BigEndian.AppendUint16(fragment, ext.Type)
+			fragment = binary.BigEndian.AppendUint16(fragment, uint16(len(ext.Data)))
+			fragment = append(fragment, ext.Data...)
+		}
+	}
+
+	version := clientHello.RecordVersion
+	if version == 0 {
+		version = 0x0301
+	}
+	err = WriteSSLv3Record(buf, TLSRecordHandshake, version, fragment)
+	return
+}
+
+// ReadTLSHandshakeHello
+// 读取一个包含完整 ClientHello 的 Handshake 记录，不完整或出错时不读掉。
+//
+// 不支持跨多个记录的 ClientHello。
+func ReadTLSHandshakeHello(buf Buffer) (clientHello *ClientHello, err error) {
+	p, peekErr := peekFull(buf, tlsRecordHeaderLen)
+	if peekErr != nil {
+		err = peekErr
+		return
+	}
+	if p[0] != TLSRecordHandshake {
+		err = ErrTLSHelloInvalid
+		return
+	}
+	length := int(binary.BigEndian.Uint16(p[3:]))
+	if length > tlsMaxPlaintext {
+		err = ErrRecordTooLarge
+		return
+	}
+	size := tlsRecordHeaderLen + length
+	if p, err = peekFull(buf, size); err != nil {
+		return
+	}
+	hello := &ClientHello{RecordVersion: binary.BigEndian.Uint16(p[1:])}
+	p = p[tlsRecordHeaderLen:]
+	if len(p) < tlsHandshakeHeaderLen || p[0] != TLSHandshakeClientHello {
+		err = ErrTLSHelloInvalid
+		return
+	}
+	bodyLen := int(p[1])<<16 | int(p[2])<<8 | int(p[3])
+	if bodyLen > len(p)-tlsHandshakeHeaderLen {
+		err = ErrTLSHelloInvalid
+		return
+	}
+	body := p[tlsHandshakeHeaderLen : tlsHandshakeHeaderLen+bodyLen]
+	if len(body) < 2+tlsRandomLen+1 {
+		err = ErrTLSHelloInvalid
+		return
+	}
+	hello.Version = binary.BigEndian.Uint16(body)
+	copy(hello.Random[:], body[2:])
+	body = body[2+tlsRandomLen:]
+
+	var field []byte
+	if field, body, err = tlsVector(body, 1); err != nil || len(field) > tlsMaxSessionIDLen {
+		err = ErrTLSHelloInvalid
+		return
+	}
+	hello.SessionID = append([]byte{}, field...)
+	if field, body, err = tlsVector(body, 2); err != nil || len(field) == 0 || len(field)%2 != 0 {
+		err = ErrTLSHelloInvalid
+		return
+	}
+	hello.CipherSuites = make([]uint16, len(field)/2)
+	for i := range hello.CipherSuites {
+		hello.CipherSuites[i] = binary.BigEndian.Uint16(field[2*i:])
+	}
+	if field, body, err = tlsVector(body, 1); err != nil || len(field) == 0 {
+		err = ErrTLSHelloInvalid
+		return
+	}
+	hello.CompressionMethods = append([]byte{}, field...)
+	if len(body) > 0 {
+		if field, body, err = tlsVector(body, 2); err != nil || len(body) != 0 {
+			err = ErrTLSHelloInvalid
+			return
+		}
+		for len(field) > 0 {
+			if len(field) < 4 {
+				err = ErrTLSHelloInvalid
+				return
+			}
+			ext := TLSExtension{Type: binary.BigEndian.Uint16(field)}
+			var data []byte
+			if data, field, err = tlsVector(field[2:], 2); err != nil {
+				err = ErrTLSHelloInvalid
+				return
+			}
+			ext.Data = append([]byte{}, data...)
+			hello.Extensions = append(hello.Extensions, ext)
+		}
+	}
+	clientHello = hello
+	buf.Discard(size)
+	return
+}
+
+// tlsVector splits a vector with an n-byte length prefix off p.
+func tlsVector(p []byte, n int) (vector, rest []byte, err error) {
+	if len(p) < n {
+		err = io.ErrUnexpectedEOF
+		return
+	}
+	length := 0
+	for _, b := range p[:n] {
+		length = length<<8 | int(b)
+	}
+	if length > len(p)-n {
+		err = io.ErrUnexpectedEOF
+		return
+	}
+	vector, rest = p[n:n+length], p[n+length:]
+	return
+}
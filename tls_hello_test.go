@@ -0,0 +1,80 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+// RFC 8448 section 3, simple 1-RTT handshake
+const rfc8448ClientHello = "16030100c4010000c00303cb34ecb1e78163ba1c38c6dacb196a6dffa21a8d9912ec18a2ef6283024dece70000061301" +
+	"13031302010000910000000b0009000006736572766572ff01000100000a00140012001d001700180019010001010102" +
+	"0103010400230000003300260024001d002099381de560e4bd43d23d8e435a7dbafeb3c06e51c13cae4d5413691e529a" +
+	"af2c002b0003020304000d0020001e040305030603020308040805080604010501060102010402050206020202002d00" +
+	"020101001c00024001"
+
+func TestReadTLSHandshakeHello(t *testing.T) {
+	record, err := hex.DecodeString(rfc8448ClientHello)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := bytebuffers.NewBuffer()
+	_, _ = buf.Write(record)
+
+	hello, err := bytebuffers.ReadTLSHandshakeHello(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Fatal("record must be discarded")
+	}
+	if hello.RecordVersion != 0x0301 || hello.Version != 0x0303 {
+		t.Fatal("unexpected version", hello.RecordVersion, hello.Version)
+	}
+	if !bytes.Equal(hello.Random[:4], []byte{0xcb, 0x34, 0xec, 0xb1}) || len(hello.SessionID) != 0 {
+		t.Fatal("unexpected random or session id", hello.Random, hello.SessionID)
+	}
+	if len(hello.CipherSuites) != 3 || hello.CipherSuites[0] != 0x1301 || hello.CipherSuites[1] != 0x1303 || hello.CipherSuites[2] != 0x1302 {
+		t.Fatal("unexpected cipher suites", hello.CipherSuites)
+	}
+	if !bytes.Equal(hello.CompressionMethods, []byte{0}) {
+		t.Fatal("unexpected compression methods", hello.CompressionMethods)
+	}
+	types := []uint16{0x0000, 0xff01, 0x000a, 0x0023, 0x0033, 0x002b, 0x000d, 0x002d, 0x001c}
+	if len(hello.Extensions) != len(types) {
+		t.Fatal("unexpected extensions", hello.Extensions)
+	}
+	for i, ext := range hello.Extensions {
+		if ext.Type != types[i] {
+			t.Fatal("unexpected extension", i, ext.Type)
+		}
+	}
+	if sni := hello.Extensions[0].Data; !bytes.HasSuffix(sni, []byte("server")) {
+		t.Fatal("unexpected server name", sni)
+	}
+	if versions := hello.Extensions[5].Data; !bytes.Equal(versions, []byte{0x02, 0x03, 0x04}) {
+		t.Fatal("unexpected supported versions", versions)
+	}
+
+	if err = bytebuffers.WriteTLSHandshakeHello(buf, hello); err != nil {
+		t.Fatal(err)
+	}
+	if p := buf.CloneBytes(); !bytes.Equal(p, record) {
+		t.Fatal("unexpected record", hex.EncodeToString(p))
+	}
+	buf.Reset()
+
+	_, _ = buf.Write(record[:100])
+	if _, err = bytebuffers.ReadTLSHandshakeHello(buf); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal("expected unexpected EOF, got", err)
+	}
+	buf.Reset()
+	_, _ = buf.Write([]byte{bytebuffers.TLSRecordApplicationData, 0x03, 0x03, 0x00, 0x00})
+	if _, err = bytebuffers.ReadTLSHandshakeHello(buf); !errors.Is(err, bytebuffers.ErrTLSHelloInvalid) {
+		t.Fatal("expected invalid, got", err)
+	}
+}
@@ -0,0 +1,67 @@
+package bytebuffers
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+const (
+	TLSRecordChangeCipherSpec = 20
+	TLSRecordAlert            = 21
+	TLSRecordHandshake        = 22
+	TLSRecordApplicationData  = 23
+
+	tlsRecordHeaderLen = 5
+	tlsMaxPlaintext    = 1 << 14
+)
+
+var (
+	ErrRecordTooLarge = errors.New("bytebuffers.TLS: record too large")
+)
+
+// WriteSSLv3Record
+// 写入 TLS 记录：ContentType（1）、ProtocolVersion（2，大端）、长度（2，大端）与 fragment。
+//
+// fragment 超过 2^14 字节时返回 ErrRecordTooLarge。
+func WriteSSLv3Record(buf Buffer, contentType byte, version uint16, fragment []byte) (err error) {
+	if len(fragment) > tlsMaxPlaintext {
+		err = ErrRecordTooLarge
+		return
+	}
+	size := tlsRecordHeaderLen + len(fragment)
+	p, borrowErr := buf.Borrow(size)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	p[0] = contentType
+	binary.BigEndian.PutUint16(p[1:], version)
+	binary.BigEndian.PutUint16(p[3:], uint16(len(fragment)))
+	copy(p[tlsRecordHeaderLen:], fragment)
+	buf.Return(size)
+	return
+}
+
+// ReadSSLv3Record
+// 读取 TLS 记录，长度超过 2^14 时返回 ErrRecordTooLarge，不完整时不读掉。
+func ReadSSLv3Record(buf Buffer) (contentType byte, version uint16, fragment []byte, err error) {
+	p, peekErr := peekFull(buf, tlsRecordHeaderLen)
+	if peekErr != nil {
+		err = peekErr
+		return
+	}
+	length := int(binary.BigEndian.Uint16(p[3:]))
+	if length > tlsMaxPlaintext {
+		err = ErrRecordTooLarge
+		return
+	}
+	size := tlsRecordHeaderLen + length
+	if p, err = peekFull(buf, size); err != nil {
+		return
+	}
+	contentType, version = p[0], binary.BigEndian.Uint16(p[1:])
+	fragment = make([]byte, length)
+	copy(fragment, p[tlsRecordHeaderLen:])
+	buf.Discard(size)
+	return
+}
@@ -0,0 +1,53 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestSSLv3Record(t *testing.T) {
+	cases := []struct {
+		contentType byte
+		fragment    []byte
+	}{
+		{bytebuffers.TLSRecordHandshake, []byte{0x01, 0x00, 0x00, 0x00}},
+		{bytebuffers.TLSRecordChangeCipherSpec, []byte{0x01}},
+		{bytebuffers.TLSRecordAlert, []byte{0x02, 0x28}},
+		{bytebuffers.TLSRecordApplicationData, bytes.Repeat([]byte{0xAB}, 1<<14)},
+	}
+	buf := bytebuffers.NewBuffer()
+	for _, c := range cases {
+		if err := bytebuffers.WriteSSLv3Record(buf, c.contentType, tls.VersionTLS12, c.fragment); err != nil {
+			t.Fatal(err)
+		}
+		p := buf.Peek(5)
+		if p[0] != c.contentType || p[1] != 0x03 || p[2] != 0x03 || int(p[3])<<8|int(p[4]) != len(c.fragment) {
+			t.Fatal("unexpected record header", p)
+		}
+		contentType, version, fragment, err := bytebuffers.ReadSSLv3Record(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if contentType != c.contentType || version != tls.VersionTLS12 || !bytes.Equal(fragment, c.fragment) {
+			t.Fatal("unexpected record", contentType, version)
+		}
+	}
+
+	if err := bytebuffers.WriteSSLv3Record(buf, bytebuffers.TLSRecordApplicationData, tls.VersionTLS12, make([]byte, 1<<14+1)); !errors.Is(err, bytebuffers.ErrRecordTooLarge) {
+		t.Fatal("expected record too large, got", err)
+	}
+	_, _ = buf.Write([]byte{bytebuffers.TLSRecordApplicationData, 0x03, 0x03, 0x40, 0x01})
+	if _, _, _, err := bytebuffers.ReadSSLv3Record(buf); !errors.Is(err, bytebuffers.ErrRecordTooLarge) {
+		t.Fatal("expected record too large, got", err)
+	}
+	buf.Reset()
+	_, _ = buf.Write([]byte{bytebuffers.TLSRecordAlert, 0x03, 0x03, 0x00, 0x02, 0x02})
+	if _, _, _, err := bytebuffers.ReadSSLv3Record(buf); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal("expected unexpected EOF, got", err)
+	}
+}
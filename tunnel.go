@@ -0,0 +1,41 @@
+package bytebuffers
+
+import (
+	"errors"
+	"net"
+)
+
+const (
+	IPProtocolIPIP = 4
+	IPProtocolIPv6 = 41
+)
+
+var (
+	ErrTunnelInvalidPacket = errors.New("bytebuffers.Tunnel: invalid inner packet")
+)
+
+// WriteIPIPHeader
+// 以协议号 4 写入外层 IPv4 首部（src 至 dst）及内层的 IPv4 包（RFC 2003）。
+//
+// 内层包的版本不为 4 时返回 ErrTunnelInvalidPacket。
+func WriteIPIPHeader(buf Buffer, src, dst net.IP, inner []byte) (err error) {
+	if len(inner) < ipv4HeaderLen || inner[0]>>4 != 4 {
+		err = ErrTunnelInvalidPacket
+		return
+	}
+	err = WriteIPv4Header(buf, src, dst, IPProtocolIPIP, inner)
+	return
+}
+
+// WriteIPv6inIPv4Header
+// 以协议号 41 写入外层 IPv4 首部（src 至 dst）及内层的 IPv6 包（RFC 4213）。
+//
+// 内层包的版本不为 6 时返回 ErrTunnelInvalidPacket。
+func WriteIPv6inIPv4Header(buf Buffer, src, dst net.IP, inner []byte) (err error) {
+	if len(inner) < ipv6HeaderLen || inner[0]>>4 != 6 {
+		err = ErrTunnelInvalidPacket
+		return
+	}
+	err = WriteIPv4Header(buf, src, dst, IPProtocolIPv6, inner)
+	return
+}
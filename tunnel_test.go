@@ -0,0 +1,60 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestWriteIPIPHeader(t *testing.T) {
+	inner := bytebuffers.NewBuffer()
+	if err := bytebuffers.WriteIPv4Header(inner, net.ParseIP("10.0.0.1"), net.ParseIP("10.0.0.2"), 17, []byte("payload")); err != nil {
+		t.Fatal(err)
+	}
+	packet := inner.CloneBytes()
+
+	outerSrc, outerDst := net.ParseIP("192.0.2.1"), net.ParseIP("198.51.100.1")
+	buf := bytebuffers.NewBuffer()
+	if err := bytebuffers.WriteIPIPHeader(buf, outerSrc, outerDst, packet); err != nil {
+		t.Fatal(err)
+	}
+	p := buf.CloneBytes()
+	if p[9] != bytebuffers.IPProtocolIPIP {
+		t.Fatal("unexpected outer protocol", p[9])
+	}
+	if total := int(binary.BigEndian.Uint16(p[2:])); total != 20+len(packet) || len(p) != total {
+		t.Fatal("unexpected total length", total)
+	}
+	if !net.IP(p[12:16]).Equal(outerSrc) || !net.IP(p[16:20]).Equal(outerDst) || !bytes.Equal(p[20:], packet) {
+		t.Fatal("unexpected encapsulation", p)
+	}
+
+	if err := bytebuffers.WriteIPIPHeader(buf, outerSrc, outerDst, []byte("not a packet")); !errors.Is(err, bytebuffers.ErrTunnelInvalidPacket) {
+		t.Fatal("expected invalid packet, got", err)
+	}
+}
+
+func TestWriteIPv6inIPv4Header(t *testing.T) {
+	inner := bytebuffers.NewBuffer()
+	if err := bytebuffers.WriteIPv6Header(inner, net.ParseIP("2001:db8::1"), net.ParseIP("2001:db8::2"), bytebuffers.IPv6NextHeaderNone, nil); err != nil {
+		t.Fatal(err)
+	}
+	packet := inner.CloneBytes()
+
+	buf := bytebuffers.NewBuffer()
+	if err := bytebuffers.WriteIPv6inIPv4Header(buf, net.ParseIP("192.0.2.1"), net.ParseIP("198.51.100.1"), packet); err != nil {
+		t.Fatal(err)
+	}
+	p := buf.CloneBytes()
+	if p[9] != bytebuffers.IPProtocolIPv6 || len(p) != 20+len(packet) || !bytes.Equal(p[20:], packet) {
+		t.Fatal("unexpected encapsulation", p)
+	}
+
+	if err := bytebuffers.WriteIPv6inIPv4Header(buf, net.ParseIP("192.0.2.1"), net.ParseIP("198.51.100.1"), p); !errors.Is(err, bytebuffers.ErrTunnelInvalidPacket) {
+		t.Fatal("expected invalid packet, got", err)
+	}
+}
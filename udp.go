@@ -0,0 +1,34 @@
+package bytebuffers
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+const (
+	udpHeaderLen = 8
+)
+
+// WriteUDP
+// 写入 8 字节的 UDP 首部及 payload。
+//
+// 校验和为 0，即 IPv4 下不校验（RFC 768）。
+func WriteUDP(buf Buffer, srcPort, dstPort uint16, payload []byte) (err error) {
+	total := udpHeaderLen + len(payload)
+	if total > math.MaxUint16 {
+		err = ErrTooLarge
+		return
+	}
+	p, borrowErr := buf.Borrow(total)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	binary.BigEndian.PutUint16(p[0:], srcPort)
+	binary.BigEndian.PutUint16(p[2:], dstPort)
+	binary.BigEndian.PutUint16(p[4:], uint16(total))
+	binary.BigEndian.PutUint16(p[6:], 0)
+	copy(p[udpHeaderLen:], payload)
+	buf.Return(total)
+	return
+}
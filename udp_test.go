@@ -0,0 +1,63 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestWriteUDP(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	for _, size := range []int{0, 1, 512, 1472} {
+		payload := bytes.Repeat([]byte{0xAB}, size)
+		if err := bytebuffers.WriteUDP(buf, 53, 40000, payload); err != nil {
+			t.Fatal(err)
+		}
+		expected := binary.BigEndian.AppendUint16(nil, 53)
+		expected = binary.BigEndian.AppendUint16(expected, 40000)
+		expected = binary.BigEndian.AppendUint16(expected, uint16(8+size))
+		expected = binary.BigEndian.AppendUint16(expected, 0)
+		expected = append(expected, payload...)
+		if !bytes.Equal(buf.CloneBytes(), expected) {
+			t.Fatal("unexpected datagram for payload size", size)
+		}
+		buf.Reset()
+	}
+
+	if err := bytebuffers.WriteUDP(buf, 1, 2, make([]byte, 65536)); !errors.Is(err, bytebuffers.ErrTooLarge) {
+		t.Fatal("expected too large, got", err)
+	}
+}
+
+func BenchmarkWriteUDP(b *testing.B) {
+	b.ReportAllocs()
+	buf := bytebuffers.Acquire()
+	defer bytebuffers.Release(buf)
+	payload := make([]byte, 512)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = bytebuffers.WriteUDP(buf, 53, 40000, payload)
+		buf.Reset()
+	}
+}
+
+func BenchmarkWriteUDPManual(b *testing.B) {
+	b.ReportAllocs()
+	buf := bytebuffers.Acquire()
+	defer bytebuffers.Release(buf)
+	payload := make([]byte, 512)
+	header := make([]byte, 8)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		binary.BigEndian.PutUint16(header[0:], 53)
+		binary.BigEndian.PutUint16(header[2:], 40000)
+		binary.BigEndian.PutUint16(header[4:], uint16(8+len(payload)))
+		binary.BigEndian.PutUint16(header[6:], 0)
+		_, _ = buf.Write(header)
+		_, _ = buf.Write(payload)
+		buf.Reset()
+	}
+}
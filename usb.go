@@ -0,0 +1,55 @@
+package bytebuffers
+
+import (
+	"encoding/binary"
+)
+
+const (
+	usbSetupPacketLen = 8
+)
+
+// USBSetupPacket
+// USB 控制传输的 8 字节 SETUP 包。
+type USBSetupPacket struct {
+	RequestType byte
+	Request     byte
+	Value       uint16
+	Index       uint16
+	Length      uint16
+}
+
+// WriteUSBControlTransfer
+// 写入 USB 控制传输的 SETUP 包：bmRequestType、bRequest 与小端的 wValue、wIndex、wLength。
+func WriteUSBControlTransfer(buf Buffer, bmRequestType, bRequest byte, wValue, wIndex, wLength uint16) (err error) {
+	p, borrowErr := buf.Borrow(usbSetupPacketLen)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	p[0] = bmRequestType
+	p[1] = bRequest
+	binary.LittleEndian.PutUint16(p[2:], wValue)
+	binary.LittleEndian.PutUint16(p[4:], wIndex)
+	binary.LittleEndian.PutUint16(p[6:], wLength)
+	buf.Return(usbSetupPacketLen)
+	return
+}
+
+// ReadUSBSetupPacket
+// 读取 8 字节的 USB SETUP 包，不完整时不读掉。
+func ReadUSBSetupPacket(buf Buffer) (packet USBSetupPacket, err error) {
+	p, peekErr := peekFull(buf, usbSetupPacketLen)
+	if peekErr != nil {
+		err = peekErr
+		return
+	}
+	packet = USBSetupPacket{
+		RequestType: p[0],
+		Request:     p[1],
+		Value:       binary.LittleEndian.Uint16(p[2:]),
+		Index:       binary.LittleEndian.Uint16(p[4:]),
+		Length:      binary.LittleEndian.Uint16(p[6:]),
+	}
+	buf.Discard(usbSetupPacketLen)
+	return
+}
@@ -0,0 +1,35 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestUSBControlTransfer(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	// GET_DESCRIPTOR(DEVICE), USB 2.0 specification 9.4.3
+	if err := bytebuffers.WriteUSBControlTransfer(buf, 0x80, 0x06, 0x0100, 0, 18); err != nil {
+		t.Fatal(err)
+	}
+	expected := []byte{0x80, 0x06, 0x00, 0x01, 0x00, 0x00, 0x12, 0x00}
+	if p := buf.CloneBytes(); !bytes.Equal(p, expected) {
+		t.Fatal("unexpected setup packet", p)
+	}
+
+	packet, err := bytebuffers.ReadUSBSetupPacket(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if packet != (bytebuffers.USBSetupPacket{RequestType: 0x80, Request: 0x06, Value: 0x0100, Index: 0, Length: 18}) {
+		t.Fatal("unexpected setup packet", packet)
+	}
+
+	_, _ = buf.Write(expected[:7])
+	if _, err = bytebuffers.ReadUSBSetupPacket(buf); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal("expected unexpected EOF, got", err)
+	}
+}
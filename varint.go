@@ -0,0 +1,301 @@
+package bytebuffers
+
+import (
+	"encoding/binary"
+	"io"
+	"math/bits"
+	"sort"
+	"unsafe"
+)
+
+func (buf *buffer) WriteUvarint(v uint64) (err error) {
+	buf.ur = 0
+	if buf.Borrowing() {
+		err = ErrWriteWhenBorrowing
+		return
+	}
+	if n := uvarintLen(v); buf.c-buf.w < n {
+		if err = buf.grow(n); err != nil {
+			return
+		}
+	}
+	buf.w += binary.PutUvarint(buf.b[buf.w:], v)
+	buf.a = buf.w
+	return
+}
+
+// uvarintLen
+// v 以 uvarint 编码后的字节数。
+func uvarintLen(v uint64) int {
+	return (bits.Len64(v|1) + 6) / 7
+}
+
+func (buf *buffer) ReadUvarint() (v uint64, err error) {
+	buf.ur = 0
+	var n int
+	if v, n, err = buf.peekUvarint(); err != nil {
+		return
+	}
+	buf.r += n
+	buf.shrink()
+	return
+}
+
+func (buf *buffer) peekUvarint() (v uint64, n int, err error) {
+	if buf.Len() == 0 {
+		err = io.EOF
+		return
+	}
+	v, n = binary.Uvarint(buf.b[buf.r:buf.w])
+	if n == 0 {
+		err = io.ErrUnexpectedEOF
+		return
+	}
+	if n < 0 {
+		n = 0
+		err = ErrVarintOverflow
+		return
+	}
+	return
+}
+
+func (buf *buffer) WriteZigzagVarint(v int64) (err error) {
+	err = buf.WriteUvarint(uint64(v<<1) ^ uint64(v>>63))
+	return
+}
+
+func (buf *buffer) ReadZigzagVarint() (v int64, err error) {
+	uv, readErr := buf.ReadUvarint()
+	if readErr != nil {
+		err = readErr
+		return
+	}
+	v = int64(uv>>1) ^ -int64(uv&1)
+	return
+}
+
+func (buf *buffer) WriteVarBytes(p []byte) (err error) {
+	buf.ur = 0
+	if buf.Borrowing() {
+		err = ErrWriteWhenBorrowing
+		return
+	}
+	pLen := len(p)
+	if size := uvarintLen(uint64(pLen)) + pLen; buf.c-buf.w < size {
+		if err = buf.grow(size); err != nil {
+			return
+		}
+	}
+	buf.w += binary.PutUvarint(buf.b[buf.w:], uint64(pLen))
+	buf.w += copy(buf.b[buf.w:], p)
+	buf.a = buf.w
+	return
+}
+
+func (buf *buffer) ReadVarBytes() (p []byte, err error) {
+	buf.ur = 0
+	if buf.Len() == 0 {
+		err = io.EOF
+		return
+	}
+	b, n, readErr := readVarBytes(buf.b[buf.r:buf.w])
+	if readErr != nil {
+		err = readErr
+		return
+	}
+	p = make([]byte, len(b))
+	copy(p, b)
+	buf.r += n
+	buf.shrink()
+	return
+}
+
+// readVarBytes
+// 从 p 中解析以 uvarint 长度为前缀的字节，返回 p 的子切片与消耗的字节数。
+func readVarBytes(p []byte) (b []byte, n int, err error) {
+	size, sn := binary.Uvarint(p)
+	if sn == 0 {
+		err = io.ErrUnexpectedEOF
+		return
+	}
+	if sn < 0 {
+		err = ErrVarintOverflow
+		return
+	}
+	if size > uint64(len(p)-sn) {
+		err = io.ErrUnexpectedEOF
+		return
+	}
+	n = sn + int(size)
+	b = p[sn:n]
+	return
+}
+
+func (buf *buffer) WriteVarString(s string) (err error) {
+	p := unsafe.Slice(unsafe.StringData(s), len(s))
+	return buf.WriteVarBytes(p)
+}
+
+func (buf *buffer) ReadVarString() (s string, err error) {
+	p, readErr := buf.ReadVarBytes()
+	if readErr != nil {
+		err = readErr
+		return
+	}
+	// p is a fresh copy owned by nobody else, so it is safe to alias it.
+	s = unsafe.String(unsafe.SliceData(p), len(p))
+	return
+}
+
+func (buf *buffer) WriteMap(m map[string]string) (err error) {
+	buf.ur = 0
+	if buf.Borrowing() {
+		err = ErrWriteWhenBorrowing
+		return
+	}
+	keys := make([]string, 0, len(m))
+	size := uvarintLen(uint64(len(m)))
+	for k, v := range m {
+		keys = append(keys, k)
+		size += uvarintLen(uint64(len(k))) + len(k) + uvarintLen(uint64(len(v))) + len(v)
+	}
+	sort.Strings(keys)
+	if buf.c-buf.w < size {
+		if err = buf.grow(size); err != nil {
+			return
+		}
+	}
+	buf.w += binary.PutUvarint(buf.b[buf.w:], uint64(len(keys)))
+	for _, k := range keys {
+		v := m[k]
+		buf.w += binary.PutUvarint(buf.b[buf.w:], uint64(len(k)))
+		buf.w += copy(buf.b[buf.w:], k)
+		buf.w += binary.PutUvarint(buf.b[buf.w:], uint64(len(v)))
+		buf.w += copy(buf.b[buf.w:], v)
+	}
+	buf.a = buf.w
+	return
+}
+
+func (buf *buffer) ReadMap() (m map[string]string, err error) {
+	buf.ur = 0
+	if buf.Len() == 0 {
+		err = io.EOF
+		return
+	}
+	var n int
+	if m, n, err = readMap(buf.b[buf.r:buf.w]); err != nil {
+		return
+	}
+	buf.r += n
+	buf.shrink()
+	return
+}
+
+// readMap
+// 从 p 中解析 WriteMap 写入的字符串映射，返回消耗的字节数。
+func readMap(p []byte) (m map[string]string, n int, err error) {
+	count, cn := binary.Uvarint(p)
+	if cn == 0 {
+		err = io.ErrUnexpectedEOF
+		return
+	}
+	if cn < 0 {
+		err = ErrVarintOverflow
+		return
+	}
+	if count > uint64(len(p)-cn)/2 { // each entry takes at least two bytes
+		err = io.ErrUnexpectedEOF
+		return
+	}
+	n = cn
+	entries := make(map[string]string, count)
+	for i := uint64(0); i < count; i++ {
+		k, kn, kErr := readVarBytes(p[n:])
+		if kErr != nil {
+			n, err = 0, kErr
+			return
+		}
+		n += kn
+		v, vn, vErr := readVarBytes(p[n:])
+		if vErr != nil {
+			n, err = 0, vErr
+			return
+		}
+		n += vn
+		entries[string(k)] = string(v)
+	}
+	m = entries
+	return
+}
+
+func (buf *buffer) WriteSlice(elems [][]byte) (err error) {
+	buf.ur = 0
+	if buf.Borrowing() {
+		err = ErrWriteWhenBorrowing
+		return
+	}
+	size := uvarintLen(uint64(len(elems)))
+	for _, elem := range elems {
+		size += uvarintLen(uint64(len(elem))) + len(elem)
+	}
+	if buf.c-buf.w < size {
+		if err = buf.grow(size); err != nil {
+			return
+		}
+	}
+	buf.w += binary.PutUvarint(buf.b[buf.w:], uint64(len(elems)))
+	for _, elem := range elems {
+		buf.w += binary.PutUvarint(buf.b[buf.w:], uint64(len(elem)))
+		buf.w += copy(buf.b[buf.w:], elem)
+	}
+	buf.a = buf.w
+	return
+}
+
+func (buf *buffer) ReadSlice() (elems [][]byte, err error) {
+	buf.ur = 0
+	if buf.Len() == 0 {
+		err = io.EOF
+		return
+	}
+	var n int
+	if elems, n, err = readSlice(buf.b[buf.r:buf.w]); err != nil {
+		return
+	}
+	buf.r += n
+	buf.shrink()
+	return
+}
+
+// readSlice
+// 从 p 中解析 WriteSlice 写入的字节切片数组，元素为复制的字节，返回消耗的字节数。
+func readSlice(p []byte) (elems [][]byte, n int, err error) {
+	count, cn := binary.Uvarint(p)
+	if cn == 0 {
+		err = io.ErrUnexpectedEOF
+		return
+	}
+	if cn < 0 {
+		err = ErrVarintOverflow
+		return
+	}
+	if count > uint64(len(p)-cn) { // each element takes at least one byte
+		err = io.ErrUnexpectedEOF
+		return
+	}
+	n = cn
+	values := make([][]byte, count)
+	for i := range values {
+		elem, en, elemErr := readVarBytes(p[n:])
+		if elemErr != nil {
+			n, err = 0, elemErr
+			return
+		}
+		n += en
+		values[i] = make([]byte, len(elem))
+		copy(values[i], elem)
+	}
+	elems = values
+	return
+}
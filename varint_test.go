@@ -0,0 +1,311 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"maps"
+	"math"
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestBuffer_WriteUvarint(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	values := []uint64{0, 1, 127, 128, 300, math.MaxUint32, math.MaxUint64}
+	for _, v := range values {
+		if err := buf.WriteUvarint(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expected := make([]byte, 0, 64)
+	for _, v := range values {
+		expected = binary.AppendUvarint(expected, v)
+	}
+	if !bytes.Equal(buf.CloneBytes(), expected) {
+		t.Fatal("encoding mismatch")
+	}
+	for _, v := range values {
+		rv, err := buf.ReadUvarint()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rv != v {
+			t.Fatal("round trip mismatch", v, rv)
+		}
+	}
+	if _, err := buf.ReadUvarint(); !errors.Is(err, io.EOF) {
+		t.Fatal("expected EOF, got", err)
+	}
+
+	_ = buf.WriteByte(0x80)
+	if _, err := buf.ReadUvarint(); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal("expected unexpected EOF, got", err)
+	}
+	if buf.Len() != 1 {
+		t.Fatal("incomplete uvarint consumed")
+	}
+}
+
+func TestBuffer_WriteUvarintExactSize(t *testing.T) {
+	values := []uint64{0, 127, 128, 1<<14 - 1, 1 << 14, math.MaxUint32, math.MaxUint64}
+	for _, v := range values {
+		size := len(binary.AppendUvarint(nil, v))
+		buffers := []bytebuffers.Buffer{
+			bytebuffers.NewFixedBuffer(size),
+			bytebuffers.NewRingBuffer(size),
+			bytebuffers.NewChainBuffer(size),
+		}
+		for _, buf := range buffers {
+			if err := buf.WriteUvarint(v); err != nil || buf.Len() != size || buf.Capacity() != size {
+				t.Fatalf("%T: unexpected write of %d: %v %d %d", buf, v, err, buf.Len(), buf.Capacity())
+			}
+			if rv, err := buf.ReadUvarint(); err != nil || rv != v {
+				t.Fatalf("%T: round trip mismatch %d %d %v", buf, v, rv, err)
+			}
+		}
+	}
+	buf := bytebuffers.NewFixedBuffer(4)
+	if err := buf.WriteVarBytes([]byte("abc")); err != nil || buf.Len() != 4 {
+		t.Fatal("unexpected write", err, buf.Len())
+	}
+}
+
+func TestBuffer_WriteZigzagVarint(t *testing.T) {
+	cases := []struct {
+		v  int64
+		uv uint64
+	}{
+		{0, 0},
+		{-1, 1},
+		{1, 2},
+		{-2, 3},
+		{math.MaxInt64, math.MaxUint64 - 1},
+		{math.MinInt64, math.MaxUint64},
+	}
+	buf := bytebuffers.NewBuffer()
+	for _, c := range cases {
+		if err := buf.WriteZigzagVarint(c.v); err != nil {
+			t.Fatal(err)
+		}
+		uv, err := buf.ReadUvarint()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if uv != c.uv {
+			t.Fatal("unexpected zigzag mapping", c.v, uv, c.uv)
+		}
+	}
+
+	for i := 0; i < 1000; i++ {
+		v := int64(rand.Uint64())
+		_ = buf.WriteZigzagVarint(v)
+		rv, err := buf.ReadZigzagVarint()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rv != v {
+			t.Fatal("round trip mismatch", v, rv)
+		}
+	}
+}
+
+func TestBuffer_WriteVarBytes(t *testing.T) {
+	cases := [][]byte{
+		{},
+		[]byte("0123456789"),
+		bytes.Repeat([]byte("a"), 64*1024+1),
+	}
+	buf := bytebuffers.NewBuffer()
+	for _, p := range cases {
+		if err := buf.WriteVarBytes(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, p := range cases {
+		rp, err := buf.ReadVarBytes()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(rp, p) {
+			t.Fatal("round trip mismatch", len(p), len(rp))
+		}
+	}
+	if buf.Len() != 0 {
+		t.Fatal("unexpected remains", buf.Len())
+	}
+}
+
+func TestBuffer_ReadVarBytes(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	_ = buf.WriteUvarint(10)
+	_, _ = buf.Write([]byte("01234"))
+	n := buf.Len()
+	if _, err := buf.ReadVarBytes(); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal("expected unexpected EOF, got", err)
+	}
+	if buf.Len() != n {
+		t.Fatal("truncated var bytes consumed")
+	}
+}
+
+func TestBuffer_WriteVarString(t *testing.T) {
+	cases := []struct {
+		s      string
+		prefix int
+	}{
+		{"", 1},
+		{"hello, world", 1},
+		{"字节缓冲池 🚀", 1},
+		{strings.Repeat("a", 127), 1},
+		{strings.Repeat("a", 128), 2},
+	}
+	buf := bytebuffers.NewBuffer()
+	for _, c := range cases {
+		if err := buf.WriteVarString(c.s); err != nil {
+			t.Fatal(err)
+		}
+		if buf.Len() != c.prefix+len(c.s) {
+			t.Fatal("unexpected encoded length", buf.Len(), c.prefix+len(c.s))
+		}
+		s, err := buf.ReadVarString()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s != c.s {
+			t.Fatal("round trip mismatch", s, c.s)
+		}
+	}
+}
+
+func TestBuffer_WriteMap(t *testing.T) {
+	cases := []map[string]string{
+		{},
+		{"key": "value"},
+		{"": "empty", "a=b": "c&d", "换行\n": "\x00\xff", "k": ""},
+	}
+	buf := bytebuffers.NewBuffer()
+	for _, m := range cases {
+		if err := buf.WriteMap(m); err != nil {
+			t.Fatal(err)
+		}
+		rm, err := buf.ReadMap()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !maps.Equal(rm, m) {
+			t.Fatal("round trip mismatch", rm, m)
+		}
+	}
+
+	m := map[string]string{"b": "2", "a": "1", "c": "3"}
+	_ = buf.WriteMap(m)
+	first := buf.CloneBytes()
+	buf.Reset()
+	_ = buf.WriteMap(m)
+	if !bytes.Equal(first, buf.CloneBytes()) {
+		t.Fatal("encoding is not deterministic")
+	}
+
+	// only the exact encoded size is reserved
+	m = map[string]string{"a": strings.Repeat("1", 200), "b": "2"}
+	size := 1 + 1 + 1 + 2 + 200 + 1 + 1 + 1 + 1
+	if err := bytebuffers.NewFixedBuffer(size).WriteMap(m); err != nil {
+		t.Fatal(err)
+	}
+	if err := bytebuffers.NewFixedBuffer(size - 1).WriteMap(m); !errors.Is(err, bytebuffers.ErrBufferFull) {
+		t.Fatal("expected buffer full, got", err)
+	}
+}
+
+func TestBuffer_ReadMap(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	_ = buf.WriteMap(map[string]string{"a": "1", "b": "2"})
+	encoded := buf.CloneBytes()
+	_ = buf.Set(encoded[:len(encoded)-1])
+	if _, err := buf.ReadMap(); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal("expected unexpected EOF, got", err)
+	}
+	if buf.Len() != len(encoded)-1 {
+		t.Fatal("truncated map consumed")
+	}
+
+	_ = buf.Set(nil)
+	_ = buf.WriteUvarint(3)
+	_ = buf.WriteVarString("a")
+	_ = buf.WriteVarString("1")
+	if _, err := buf.ReadMap(); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal("expected unexpected EOF, got", err)
+	}
+}
+
+func TestBuffer_WriteSlice(t *testing.T) {
+	small := make([][]byte, 100)
+	for i := range small {
+		small[i] = []byte(strings.Repeat("x", i))
+	}
+	cases := [][][]byte{
+		{},
+		small,
+		{bytes.Repeat([]byte("a"), 64*1024+1)},
+	}
+	buf := bytebuffers.NewBuffer()
+	for _, elems := range cases {
+		if err := buf.WriteSlice(elems); err != nil {
+			t.Fatal(err)
+		}
+		relems, err := buf.ReadSlice()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(relems) != len(elems) {
+			t.Fatal("count mismatch", len(relems), len(elems))
+		}
+		for i := range elems {
+			if !bytes.Equal(relems[i], elems[i]) {
+				t.Fatal("element mismatch at", i)
+			}
+		}
+	}
+
+	// only the exact encoded size is reserved
+	elems := [][]byte{[]byte("a"), bytes.Repeat([]byte("b"), 128)}
+	size := 1 + 1 + 1 + 2 + 128
+	if err := bytebuffers.NewFixedBuffer(size).WriteSlice(elems); err != nil {
+		t.Fatal(err)
+	}
+	if err := bytebuffers.NewFixedBuffer(size - 1).WriteSlice(elems); !errors.Is(err, bytebuffers.ErrBufferFull) {
+		t.Fatal("expected buffer full, got", err)
+	}
+}
+
+func TestBuffer_ReadSlice(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	_ = buf.WriteSlice([][]byte{[]byte("a"), []byte("bc")})
+	twoElems := buf.Len()
+	_ = buf.WriteVarBytes([]byte("def"))
+	encoded := buf.CloneBytes()
+	encoded[0] = 3
+
+	for _, size := range []int{twoElems, len(encoded) - 1} {
+		_ = buf.Set(encoded[:size])
+		if _, err := buf.ReadSlice(); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Fatal("expected unexpected EOF, got", err)
+		}
+		if buf.Len() != size {
+			t.Fatal("truncated slice consumed")
+		}
+	}
+	_ = buf.Set(encoded)
+	elems, err := buf.ReadSlice()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(elems) != 3 || string(elems[2]) != "def" {
+		t.Fatal("unexpected elements", elems)
+	}
+}
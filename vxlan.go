@@ -0,0 +1,53 @@
+package bytebuffers
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+const (
+	VXLANFlagVNI = 0x08
+	VXLANMaxVNI  = 0xFFFFFF
+
+	vxlanHeaderLen = 8
+)
+
+var (
+	ErrVXLANInvalidVNI = errors.New("bytebuffers.VXLAN: invalid vni")
+	ErrVXLANInvalid    = errors.New("bytebuffers.VXLAN: invalid header")
+)
+
+// WriteVXLANHeader
+// 写入 8 字节的 VXLAN 头：I 标志（0x08）、3 字节保留、24 位 VNI 与 1 字节保留。
+func WriteVXLANHeader(buf Buffer, vni uint32) (err error) {
+	if vni > VXLANMaxVNI {
+		err = ErrVXLANInvalidVNI
+		return
+	}
+	p, borrowErr := buf.Borrow(vxlanHeaderLen)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	binary.BigEndian.PutUint32(p, VXLANFlagVNI<<24)
+	binary.BigEndian.PutUint32(p[4:], vni<<8)
+	buf.Return(vxlanHeaderLen)
+	return
+}
+
+// ReadVXLANHeader
+// 读取 8 字节的 VXLAN 头并校验 I 标志，返回 VNI，不完整时不读掉。
+func ReadVXLANHeader(buf Buffer) (vni uint32, err error) {
+	p, peekErr := peekFull(buf, vxlanHeaderLen)
+	if peekErr != nil {
+		err = peekErr
+		return
+	}
+	if p[0]&VXLANFlagVNI == 0 {
+		err = ErrVXLANInvalid
+		return
+	}
+	vni = binary.BigEndian.Uint32(p[4:]) >> 8
+	buf.Discard(vxlanHeaderLen)
+	return
+}
@@ -0,0 +1,51 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestVXLANHeader(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	if err := bytebuffers.WriteVXLANHeader(buf, 12345); err != nil {
+		t.Fatal(err)
+	}
+	p := buf.CloneBytes()
+	if p[0] != 0x08 {
+		t.Fatal("I flag must be set", p[0])
+	}
+	if !bytes.Equal(p[4:7], []byte{0x00, 0x30, 0x39}) {
+		t.Fatal("unexpected vni", p[4:7])
+	}
+	if !bytes.Equal(p, []byte{0x08, 0, 0, 0, 0x00, 0x30, 0x39, 0}) {
+		t.Fatal("unexpected header", p)
+	}
+
+	vni, err := bytebuffers.ReadVXLANHeader(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vni != 12345 {
+		t.Fatal("unexpected vni", vni)
+	}
+
+	if err = bytebuffers.WriteVXLANHeader(buf, 0x1000000); !errors.Is(err, bytebuffers.ErrVXLANInvalidVNI) {
+		t.Fatal("expected invalid vni, got", err)
+	}
+	if buf.Len() != 0 {
+		t.Fatal("nothing must be written on error")
+	}
+	_, _ = buf.Write(p[:7])
+	if _, err = bytebuffers.ReadVXLANHeader(buf); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal("expected unexpected EOF, got", err)
+	}
+	buf.Reset()
+	_, _ = buf.Write([]byte{0, 0, 0, 0, 0x00, 0x30, 0x39, 0})
+	if _, err = bytebuffers.ReadVXLANHeader(buf); !errors.Is(err, bytebuffers.ErrVXLANInvalid) {
+		t.Fatal("expected invalid header, got", err)
+	}
+}
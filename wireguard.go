@@ -0,0 +1,94 @@
+package bytebuffers
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+const (
+	WireGuardMessageInitiation  = 1
+	WireGuardMessageResponse    = 2
+	WireGuardMessageCookieReply = 3
+	WireGuardMessageTransport   = 4
+
+	wireGuardKeyLen                = 32
+	wireGuardEncryptedStaticLen    = wireGuardKeyLen + 16
+	wireGuardEncryptedTimestampLen = 12 + 16
+	wireGuardInitiationLen         = 4 + 4 + wireGuardKeyLen + wireGuardEncryptedStaticLen + wireGuardEncryptedTimestampLen + 16 + 16
+	wireGuardResponseLen           = 92
+	wireGuardCookieReplyLen        = 64
+	wireGuardMinTransportLen       = 32
+)
+
+var (
+	ErrWireGuardInvalid = errors.New("bytebuffers.WireGuard: invalid message")
+)
+
+// WriteWireGuardInitiation
+// 写入 148 字节的 WireGuard 握手发起消息：类型（1，小端 4 字节含保留）、发送方索引（小端）、临时公钥（32）、加密的静态公钥（48）、加密的时间戳（28）、MAC1 与 MAC2。
+func WriteWireGuardInitiation(buf Buffer, senderIndex uint32, ephemeral, encryptedStatic, encryptedTimestamp []byte, mac1, mac2 [16]byte) (err error) {
+	if len(ephemeral) != wireGuardKeyLen || len(encryptedStatic) != wireGuardEncryptedStaticLen || len(encryptedTimestamp) != wireGuardEncryptedTimestampLen {
+		err = ErrWireGuardInvalid
+		return
+	}
+	p, borrowErr := buf.Borrow(wireGuardInitiationLen)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	binary.LittleEndian.PutUint32(p, WireGuardMessageInitiation)
+	binary.LittleEndian.PutUint32(p[4:], senderIndex)
+	n := 8
+	n += copy(p[n:], ephemeral)
+	n += copy(p[n:], encryptedStatic)
+	n += copy(p[n:], encryptedTimestamp)
+	n += copy(p[n:], mac1[:])
+	copy(p[n:], mac2[:])
+	buf.Return(wireGuardInitiationLen)
+	return
+}
+
+// ReadWireGuardMessage
+// 将整个可读内容作为一个 WireGuard 消息读取，返回类型与类型之后的内容，并按类型校验长度，成功后全部读掉，出错时不读掉。
+func ReadWireGuardMessage(buf Buffer) (msgType uint32, remaining []byte, err error) {
+	n := buf.Len()
+	if n == 0 {
+		err = io.EOF
+		return
+	}
+	p := buf.Peek(n)
+	if len(p) < 4 {
+		err = ErrWireGuardInvalid
+		return
+	}
+	typ := binary.LittleEndian.Uint32(p)
+	switch typ {
+	case WireGuardMessageInitiation:
+		if len(p) != wireGuardInitiationLen {
+			err = ErrWireGuardInvalid
+		}
+	case WireGuardMessageResponse:
+		if len(p) != wireGuardResponseLen {
+			err = ErrWireGuardInvalid
+		}
+	case WireGuardMessageCookieReply:
+		if len(p) != wireGuardCookieReplyLen {
+			err = ErrWireGuardInvalid
+		}
+	case WireGuardMessageTransport:
+		if len(p) < wireGuardMinTransportLen {
+			err = ErrWireGuardInvalid
+		}
+	default:
+		err = ErrWireGuardInvalid
+	}
+	if err != nil {
+		return
+	}
+	msgType = typ
+	remaining = make([]byte, len(p)-4)
+	copy(remaining, p[4:])
+	buf.Discard(n)
+	return
+}
@@ -0,0 +1,59 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestWireGuardInitiation(t *testing.T) {
+	ephemeral := bytes.Repeat([]byte{0xE1}, 32)
+	encryptedStatic := bytes.Repeat([]byte{0x5A}, 48)
+	encryptedTimestamp := bytes.Repeat([]byte{0x7D}, 28)
+	var mac1, mac2 [16]byte
+	copy(mac1[:], bytes.Repeat([]byte{0x11}, 16))
+
+	buf := bytebuffers.NewBuffer()
+	if err := bytebuffers.WriteWireGuardInitiation(buf, 0x04030201, ephemeral, encryptedStatic, encryptedTimestamp, mac1, mac2); err != nil {
+		t.Fatal(err)
+	}
+	p := buf.CloneBytes()
+	// whitepaper section 5.4.2: type, reserved, sender, ephemeral, static, timestamp, mac1, mac2
+	if len(p) != 148 {
+		t.Fatal("unexpected length", len(p))
+	}
+	if !bytes.Equal(p[:4], []byte{1, 0, 0, 0}) || !bytes.Equal(p[4:8], []byte{1, 2, 3, 4}) {
+		t.Fatal("unexpected type or sender index", p[:8])
+	}
+	if !bytes.Equal(p[8:40], ephemeral) || !bytes.Equal(p[40:88], encryptedStatic) || !bytes.Equal(p[88:116], encryptedTimestamp) {
+		t.Fatal("unexpected handshake fields")
+	}
+	if !bytes.Equal(p[116:132], mac1[:]) || !bytes.Equal(p[132:148], mac2[:]) {
+		t.Fatal("unexpected macs")
+	}
+
+	msgType, remaining, err := bytebuffers.ReadWireGuardMessage(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msgType != bytebuffers.WireGuardMessageInitiation || binary.LittleEndian.Uint32(remaining) != 0x04030201 || !bytes.Equal(remaining, p[4:]) {
+		t.Fatal("unexpected message", msgType, remaining)
+	}
+	if buf.Len() != 0 {
+		t.Fatal("message must be discarded")
+	}
+
+	if err = bytebuffers.WriteWireGuardInitiation(buf, 1, ephemeral[:31], encryptedStatic, encryptedTimestamp, mac1, mac2); !errors.Is(err, bytebuffers.ErrWireGuardInvalid) {
+		t.Fatal("expected invalid, got", err)
+	}
+	_, _ = buf.Write(p[:147])
+	if _, _, err = bytebuffers.ReadWireGuardMessage(buf); !errors.Is(err, bytebuffers.ErrWireGuardInvalid) {
+		t.Fatal("expected invalid, got", err)
+	}
+	if buf.Len() != 147 {
+		t.Fatal("invalid message must not be discarded")
+	}
+}
@@ -0,0 +1,39 @@
+package bytebuffers
+
+import (
+	"crypto/x509"
+	"errors"
+)
+
+var (
+	ErrX509NoRaw = errors.New("bytebuffers.X509: certificate has no raw der")
+)
+
+// WriteX509Cert
+// 写入证书的 DER 字节（cert.Raw）。
+func WriteX509Cert(buf Buffer, cert *x509.Certificate) (err error) {
+	if cert == nil || len(cert.Raw) == 0 {
+		err = ErrX509NoRaw
+		return
+	}
+	_, err = buf.Write(cert.Raw)
+	return
+}
+
+// ReadX509Cert
+// 读取一个 DER 编码的证书，只读掉该证书的字节，失败时不读掉。
+func ReadX509Cert(buf Buffer) (cert *x509.Certificate, err error) {
+	_, n, peekErr := peekDER(buf, derTagSequence)
+	if peekErr != nil {
+		err = peekErr
+		return
+	}
+	// the certificate keeps referencing its Raw, so parse a copy
+	raw := make([]byte, n)
+	copy(raw, buf.Peek(n))
+	if cert, err = x509.ParseCertificate(raw); err != nil {
+		return
+	}
+	buf.Discard(n)
+	return
+}
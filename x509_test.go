@@ -0,0 +1,77 @@
+package bytebuffers_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func testCertificate(t *testing.T, cn string) *x509.Certificate {
+	t.Helper()
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	tpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: cn, Organization: []string{"brickingsoft"}},
+		NotBefore:    time.Now().Truncate(time.Second).UTC(),
+		NotAfter:     time.Now().Add(time.Hour).Truncate(time.Second).UTC(),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, tpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+func TestWriteX509Cert(t *testing.T) {
+	certs := []*x509.Certificate{testCertificate(t, "a.test"), testCertificate(t, "b.test")}
+	buf := bytebuffers.NewBuffer()
+	for _, cert := range certs {
+		if err := bytebuffers.WriteX509Cert(buf, cert); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, cert := range certs {
+		rcert, err := bytebuffers.ReadX509Cert(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rcert.Subject.String() != cert.Subject.String() {
+			t.Fatal("subject mismatch", rcert.Subject, cert.Subject)
+		}
+		if !rcert.NotBefore.Equal(cert.NotBefore) {
+			t.Fatal("not before mismatch", rcert.NotBefore, cert.NotBefore)
+		}
+		if !rcert.PublicKey.(*ecdsa.PublicKey).Equal(cert.PublicKey) {
+			t.Fatal("public key mismatch")
+		}
+	}
+	if buf.Len() != 0 {
+		t.Fatal("certificates not consumed", buf.Len())
+	}
+}
+
+func TestReadX509Cert(t *testing.T) {
+	cert := testCertificate(t, "a.test")
+	buf := bytebuffers.NewBuffer()
+	_, _ = buf.Write(cert.Raw[:len(cert.Raw)-1])
+	if _, err := bytebuffers.ReadX509Cert(buf); err == nil {
+		t.Fatal("expected error on truncated certificate")
+	}
+	if buf.Len() != len(cert.Raw)-1 {
+		t.Fatal("truncated certificate consumed")
+	}
+	if err := bytebuffers.WriteX509Cert(buf, &x509.Certificate{}); err == nil {
+		t.Fatal("expected error on certificate without raw")
+	}
+}
@@ -0,0 +1,88 @@
+package bytebuffers
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+)
+
+var (
+	ErrXMLInvalidTag      = errors.New("bytebuffers.XML: invalid tag")
+	ErrXMLElementNotFound = errors.New("bytebuffers.XML: element not found")
+)
+
+// WriteXMLElement
+// 写入 <tag>value</tag>，value 会经过 XML 转义。
+func WriteXMLElement(buf Buffer, tag, value string) (err error) {
+	if tag == "" || strings.ContainsAny(tag, "<>&'\"/= \t\r\n") {
+		err = ErrXMLInvalidTag
+		return
+	}
+	if err = buf.WriteByte('<'); err != nil {
+		return
+	}
+	if _, err = buf.WriteString(tag); err != nil {
+		return
+	}
+	if err = buf.WriteByte('>'); err != nil {
+		return
+	}
+	if err = xml.EscapeText(buf, []byte(value)); err != nil {
+		return
+	}
+	if _, err = buf.WriteString("</"); err != nil {
+		return
+	}
+	if _, err = buf.WriteString(tag); err != nil {
+		return
+	}
+	err = buf.WriteByte('>')
+	return
+}
+
+// ReadXMLElement
+// 读取第一个名为 tag 的元素的文本内容（忽略其子元素），并读掉至该元素结束。
+//
+// 未找到时返回 ErrXMLElementNotFound，元素不完整时返回解析错误，均不读掉。
+func ReadXMLElement(buf Buffer, tag string) (value string, err error) {
+	d := xml.NewDecoder(bytes.NewReader(buf.Peek(buf.Len())))
+	for {
+		token, tokenErr := d.Token()
+		if tokenErr != nil {
+			if errors.Is(tokenErr, io.EOF) {
+				err = ErrXMLElementNotFound
+				return
+			}
+			err = tokenErr
+			return
+		}
+		if start, ok := token.(xml.StartElement); ok && start.Name.Local == tag {
+			break
+		}
+	}
+	sb := strings.Builder{}
+	for {
+		token, tokenErr := d.Token()
+		if tokenErr != nil {
+			if errors.Is(tokenErr, io.EOF) {
+				tokenErr = io.ErrUnexpectedEOF
+			}
+			err = tokenErr
+			return
+		}
+		switch t := token.(type) {
+		case xml.CharData:
+			sb.Write(t)
+		case xml.StartElement:
+			if err = d.Skip(); err != nil {
+				return
+			}
+		case xml.EndElement:
+			value = sb.String()
+			buf.Discard(int(d.InputOffset()))
+			return
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package bytebuffers_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestWriteXMLElement(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	value := `a < b > c & "d" 'e'`
+	if err := bytebuffers.WriteXMLElement(buf, "body", value); err != nil {
+		t.Fatal(err)
+	}
+	if s := string(buf.CloneBytes()); s != "<body>a &lt; b &gt; c &amp; &#34;d&#34; &#39;e&#39;</body>" {
+		t.Fatal("unexpected element", s)
+	}
+	_ = bytebuffers.WriteXMLElement(buf, "next", "1")
+
+	rv, err := bytebuffers.ReadXMLElement(buf, "body")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rv != value {
+		t.Fatal("round trip mismatch", rv)
+	}
+	if s := string(buf.CloneBytes()); s != "<next>1</next>" {
+		t.Fatal("unexpected remains", s)
+	}
+
+	if err = bytebuffers.WriteXMLElement(buf, "a b", ""); !errors.Is(err, bytebuffers.ErrXMLInvalidTag) {
+		t.Fatal("expected invalid tag, got", err)
+	}
+}
+
+func TestReadXMLElement(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	_, _ = buf.WriteString(`<message><to>romeo</to><body>hi <b>there</b>!</body></message>`)
+	rv, err := bytebuffers.ReadXMLElement(buf, "body")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rv != "hi !" {
+		t.Fatal("unexpected value", rv)
+	}
+
+	_ = buf.SetString("<to>romeo</to>")
+	if _, err = bytebuffers.ReadXMLElement(buf, "body"); !errors.Is(err, bytebuffers.ErrXMLElementNotFound) {
+		t.Fatal("expected not found, got", err)
+	}
+	_ = buf.SetString("<body>incomplete")
+	if _, err = bytebuffers.ReadXMLElement(buf, "body"); err == nil {
+		t.Fatal("expected error on incomplete element")
+	}
+	if buf.Len() != len("<body>incomplete") {
+		t.Fatal("incomplete element consumed")
+	}
+}
@@ -0,0 +1,128 @@
+package bytebuffers
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+)
+
+const (
+	xmppStreamNS = "http://etherx.jabber.org/streams"
+	xmppClientNS = "jabber:client"
+)
+
+var (
+	ErrXMPPInvalidStream = errors.New("bytebuffers.XMPP: invalid stream header")
+)
+
+// WriteXMPPOpenStream
+// 写入客户端的 XMPP 流头：XML 声明与 <stream:stream>，to、from、lang 为空时不写入对应属性。
+func WriteXMPPOpenStream(buf Buffer, to, from, lang string) (err error) {
+	err = writeXMPPStream(buf, to, from, "", lang)
+	return
+}
+
+// WriteXMPPResponseStream
+// 写入接收方（服务端）的 XMPP 流头，带有流 id。
+func WriteXMPPResponseStream(buf Buffer, to, from, id, lang string) (err error) {
+	err = writeXMPPStream(buf, to, from, id, lang)
+	return
+}
+
+func writeXMPPStream(buf Buffer, to, from, id, lang string) (err error) {
+	if _, err = buf.WriteString("<?xml version='1.0'?><stream:stream"); err != nil {
+		return
+	}
+	attrs := [...][2]string{{"to", to}, {"from", from}, {"id", id}, {"version", "1.0"}, {"xml:lang", lang}}
+	for _, attr := range attrs {
+		if attr[1] == "" {
+			continue
+		}
+		if err = writeXMPPAttr(buf, attr[0], attr[1]); err != nil {
+			return
+		}
+	}
+	if err = writeXMPPAttr(buf, "xmlns", xmppClientNS); err != nil {
+		return
+	}
+	if err = writeXMPPAttr(buf, "xmlns:stream", xmppStreamNS); err != nil {
+		return
+	}
+	err = buf.WriteByte('>')
+	return
+}
+
+func writeXMPPAttr(buf Buffer, name, value string) (err error) {
+	if err = buf.WriteByte(' '); err != nil {
+		return
+	}
+	if _, err = buf.WriteString(name); err != nil {
+		return
+	}
+	if _, err = buf.WriteString("='"); err != nil {
+		return
+	}
+	if err = xml.EscapeText(buf, []byte(value)); err != nil {
+		return
+	}
+	err = buf.WriteByte('\'')
+	return
+}
+
+// ReadXMPPStreamHeader
+// 读取 XMPP 流头（可带 XML 声明），返回 <stream:stream> 的 to、from、id 与 version 属性，并读掉至流头结束。
+//
+// 第一个元素不是流元素时返回 ErrXMPPInvalidStream，不完整时返回 io.ErrUnexpectedEOF，均不读掉。
+func ReadXMPPStreamHeader(buf Buffer) (to, from, id, version string, err error) {
+	p := buf.Peek(buf.Len())
+	if len(p) == 0 {
+		err = io.EOF
+		return
+	}
+	d := xml.NewDecoder(bytes.NewReader(p))
+	for {
+		token, tokenErr := d.RawToken()
+		if tokenErr != nil {
+			var syntaxErr *xml.SyntaxError
+			if errors.Is(tokenErr, io.EOF) || (errors.As(tokenErr, &syntaxErr) && syntaxErr.Msg == "unexpected EOF") {
+				tokenErr = io.ErrUnexpectedEOF
+			}
+			err = tokenErr
+			return
+		}
+		switch t := token.(type) {
+		case xml.ProcInst, xml.Comment, xml.CharData:
+			continue
+		case xml.StartElement:
+			if t.Name.Space != "stream" || t.Name.Local != "stream" {
+				err = ErrXMPPInvalidStream
+				return
+			}
+			streamNS := false
+			for _, attr := range t.Attr {
+				switch {
+				case attr.Name.Space == "" && attr.Name.Local == "to":
+					to = attr.Value
+				case attr.Name.Space == "" && attr.Name.Local == "from":
+					from = attr.Value
+				case attr.Name.Space == "" && attr.Name.Local == "id":
+					id = attr.Value
+				case attr.Name.Space == "" && attr.Name.Local == "version":
+					version = attr.Value
+				case attr.Name.Space == "xmlns" && attr.Name.Local == "stream":
+					streamNS = attr.Value == xmppStreamNS
+				}
+			}
+			if !streamNS {
+				to, from, id, version, err = "", "", "", "", ErrXMPPInvalidStream
+				return
+			}
+			buf.Discard(int(d.InputOffset()))
+			return
+		default:
+			err = ErrXMPPInvalidStream
+			return
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package bytebuffers_test
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestWriteXMPPOpenStream(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	if err := bytebuffers.WriteXMPPOpenStream(buf, "im.example.com", "juliet@im.example.com", "en"); err != nil {
+		t.Fatal(err)
+	}
+	expected := "<?xml version='1.0'?><stream:stream to='im.example.com' from='juliet@im.example.com' version='1.0' xml:lang='en'" +
+		" xmlns='jabber:client' xmlns:stream='http://etherx.jabber.org/streams'>"
+	if s := string(buf.Peek(buf.Len())); s != expected {
+		t.Fatalf("unexpected stream header %q", s)
+	}
+
+	to, from, id, version, err := bytebuffers.ReadXMPPStreamHeader(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if to != "im.example.com" || from != "juliet@im.example.com" || id != "" || version != "1.0" {
+		t.Fatal("unexpected stream header", to, from, id, version)
+	}
+	if buf.Len() != 0 {
+		t.Fatal("stream header must be discarded")
+	}
+}
+
+func TestReadXMPPStreamHeader(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	if err := bytebuffers.WriteXMPPResponseStream(buf, "juliet@im.example.com", "im.example.com", "t7AMCin9zjMNwQKDnplntZPIDEI=", "en"); err != nil {
+		t.Fatal(err)
+	}
+	_, _ = buf.WriteString("<stream:features/>")
+	p := buf.CloneBytes()
+
+	_, _, id, _, err := bytebuffers.ReadXMPPStreamHeader(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != "t7AMCin9zjMNwQKDnplntZPIDEI=" {
+		t.Fatal("unexpected id", id)
+	}
+	if s := string(buf.Peek(buf.Len())); s != "<stream:features/>" {
+		t.Fatalf("only the stream header must be discarded, left %q", s)
+	}
+	buf.Reset()
+
+	_, _ = buf.Write(p[:40])
+	if _, _, _, _, err = bytebuffers.ReadXMPPStreamHeader(buf); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal("expected unexpected EOF, got", err)
+	}
+	buf.Reset()
+	_, _ = buf.WriteString("<message/>")
+	if _, _, _, _, err = bytebuffers.ReadXMPPStreamHeader(buf); !errors.Is(err, bytebuffers.ErrXMPPInvalidStream) {
+		t.Fatal("expected invalid stream, got", err)
+	}
+}
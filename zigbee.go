@@ -0,0 +1,147 @@
+package bytebuffers
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+const (
+	ZigBeeAPSFrameData         = 0x00
+	ZigBeeAPSFrameCommand      = 0x01
+	ZigBeeAPSFrameAck          = 0x02
+	ZigBeeAPSDeliveryUnicast   = 0x00
+	ZigBeeAPSDeliveryBroadcast = 0x08
+	ZigBeeAPSDeliveryGroup     = 0x0C
+	ZigBeeAPSAckFormat         = 0x10
+	ZigBeeAPSSecurity          = 0x20
+	ZigBeeAPSAckRequest        = 0x40
+	ZigBeeAPSExtendedHeader    = 0x80
+)
+
+var (
+	ErrZigBeeInvalid = errors.New("bytebuffers.ZigBee: invalid aps frame")
+)
+
+// APSFrameHeader
+// ZigBee APS 帧头，不存在的字段为 0。
+type APSFrameHeader struct {
+	FrameControl         byte
+	DestEndpoint         byte
+	GroupAddress         uint16
+	ClusterID            uint16
+	ProfileID            uint16
+	SrcEndpoint          byte
+	Counter              byte
+	ExtendedFrameControl byte
+	BlockNumber          byte
+	AckBitfield          byte
+}
+
+// FrameType
+// 帧类型（数据、命令或确认）。
+func (h APSFrameHeader) FrameType() byte {
+	return h.FrameControl & 0x03
+}
+
+// DeliveryMode
+// 投递模式（单播、广播或组播）。
+func (h APSFrameHeader) DeliveryMode() byte {
+	return h.FrameControl & 0x0C
+}
+
+// WriteZigBeeAPSFrame
+// 写入最小的单播 APS 数据帧：帧控制（0x00）、目的端点、簇 ID、Profile ID（小端）、源端点、APS 计数与 payload。
+func WriteZigBeeAPSFrame(buf Buffer, destEndpoint, srcEndpoint byte, cluster, profile uint16, counter byte, payload []byte) (err error) {
+	const headerLen = 8
+	if len(payload) > maxInt-headerLen {
+		err = ErrTooLarge
+		return
+	}
+	size := headerLen + len(payload)
+	p, borrowErr := buf.Borrow(size)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	p[0] = ZigBeeAPSFrameData | ZigBeeAPSDeliveryUnicast
+	p[1] = destEndpoint
+	binary.LittleEndian.PutUint16(p[2:], cluster)
+	binary.LittleEndian.PutUint16(p[4:], profile)
+	p[6] = srcEndpoint
+	p[7] = counter
+	copy(p[headerLen:], payload)
+	buf.Return(size)
+	return
+}
+
+// ReadZigBeeAPSHeader
+// 读取变长的 APS 帧头，只读掉帧头，不完整时不读掉。
+//
+// 带安全标志时辅助安全头不做解析，仍留在缓冲中。
+func ReadZigBeeAPSHeader(buf Buffer) (header APSFrameHeader, err error) {
+	p := buf.Peek(buf.Len())
+	if len(p) == 0 {
+		err = io.EOF
+		return
+	}
+	fc := p[0]
+	frameType, delivery := fc&0x03, fc&0x0C
+	if frameType == 0x03 || delivery == 0x04 {
+		err = ErrZigBeeInvalid
+		return
+	}
+	header.FrameControl = fc
+	n := 1
+	if frameType == ZigBeeAPSFrameData || (frameType == ZigBeeAPSFrameAck && fc&ZigBeeAPSAckFormat == 0) {
+		addrLen := 1
+		if delivery == ZigBeeAPSDeliveryGroup {
+			addrLen = 2
+		}
+		if len(p) < n+addrLen+5 {
+			header, err = APSFrameHeader{}, io.ErrUnexpectedEOF
+			return
+		}
+		if delivery == ZigBeeAPSDeliveryGroup {
+			header.GroupAddress = binary.LittleEndian.Uint16(p[n:])
+		} else {
+			header.DestEndpoint = p[n]
+		}
+		n += addrLen
+		header.ClusterID = binary.LittleEndian.Uint16(p[n:])
+		header.ProfileID = binary.LittleEndian.Uint16(p[n+2:])
+		header.SrcEndpoint = p[n+4]
+		n += 5
+	}
+	extLen := 0
+	if fc&ZigBeeAPSExtendedHeader != 0 {
+		extLen = 1
+	}
+	if len(p) < n+1+extLen {
+		header, err = APSFrameHeader{}, io.ErrUnexpectedEOF
+		return
+	}
+	header.Counter = p[n]
+	n++
+	if extLen > 0 {
+		header.ExtendedFrameControl = p[n]
+		n++
+		if header.ExtendedFrameControl&0x03 != 0 { // fragmentation
+			fragLen := 1
+			if frameType == ZigBeeAPSFrameAck {
+				fragLen = 2
+			}
+			if len(p) < n+fragLen {
+				header, err = APSFrameHeader{}, io.ErrUnexpectedEOF
+				return
+			}
+			header.BlockNumber = p[n]
+			if frameType == ZigBeeAPSFrameAck {
+				header.AckBitfield = p[n+1]
+			}
+			n += fragLen
+		}
+	}
+	buf.Discard(n)
+	return
+}
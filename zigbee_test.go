@@ -0,0 +1,67 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestWriteZigBeeAPSFrame(t *testing.T) {
+	// ZHA (profile 0x0104) On/Off cluster (0x0006), ZCL cluster specific "On" command
+	zcl := []byte{0x01, 0x2A, 0x01}
+	buf := bytebuffers.NewBuffer()
+	if err := bytebuffers.WriteZigBeeAPSFrame(buf, 0x01, 0x01, 0x0006, 0x0104, 0x10, zcl); err != nil {
+		t.Fatal(err)
+	}
+	expected := append([]byte{0x00, 0x01, 0x06, 0x00, 0x04, 0x01, 0x01, 0x10}, zcl...)
+	if p := buf.CloneBytes(); !bytes.Equal(p, expected) {
+		t.Fatal("unexpected frame", p)
+	}
+
+	header, err := bytebuffers.ReadZigBeeAPSHeader(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.FrameType() != bytebuffers.ZigBeeAPSFrameData || header.DeliveryMode() != bytebuffers.ZigBeeAPSDeliveryUnicast {
+		t.Fatal("unexpected frame control", header.FrameControl)
+	}
+	if header.DestEndpoint != 1 || header.ClusterID != 0x0006 || header.ProfileID != 0x0104 || header.SrcEndpoint != 1 || header.Counter != 0x10 {
+		t.Fatal("unexpected header", header)
+	}
+	if !bytes.Equal(buf.Peek(buf.Len()), zcl) {
+		t.Fatal("only the header must be discarded")
+	}
+}
+
+func TestReadZigBeeAPSHeader(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	// group delivery with extended header and fragmentation
+	_, _ = buf.Write([]byte{0x8C, 0x34, 0x12, 0x06, 0x00, 0x04, 0x01, 0x01, 0x20, 0x01, 0x03})
+	header, err := bytebuffers.ReadZigBeeAPSHeader(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.DeliveryMode() != bytebuffers.ZigBeeAPSDeliveryGroup || header.GroupAddress != 0x1234 || header.DestEndpoint != 0 {
+		t.Fatal("unexpected group header", header)
+	}
+	if header.Counter != 0x20 || header.ExtendedFrameControl != 0x01 || header.BlockNumber != 0x03 || buf.Len() != 0 {
+		t.Fatal("unexpected extended header", header)
+	}
+
+	// command frame has only frame control and counter
+	_, _ = buf.Write([]byte{0x01, 0x05})
+	if header, err = bytebuffers.ReadZigBeeAPSHeader(buf); err != nil || header.FrameType() != bytebuffers.ZigBeeAPSFrameCommand || header.Counter != 0x05 {
+		t.Fatal("unexpected command header", header, err)
+	}
+
+	_, _ = buf.Write([]byte{0x00, 0x01, 0x06})
+	if _, err = bytebuffers.ReadZigBeeAPSHeader(buf); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal("expected unexpected EOF, got", err)
+	}
+	if buf.Len() != 3 {
+		t.Fatal("incomplete header must not be discarded")
+	}
+}
@@ -0,0 +1,77 @@
+package bytebuffers
+
+import (
+	"bytes"
+	"errors"
+)
+
+const (
+	ZMTPMechanismNull  = "NULL"
+	ZMTPMechanismPlain = "PLAIN"
+	ZMTPMechanismCurve = "CURVE"
+
+	zmtpGreetingLen  = 64
+	zmtpMechanismLen = 20
+	zmtpVersionMajor = 3
+	zmtpVersionMinor = 0
+)
+
+var (
+	ErrZMTPInvalid   = errors.New("bytebuffers.ZMTP: invalid greeting")
+	ErrZMTPVersion   = errors.New("bytebuffers.ZMTP: unsupported version")
+	ErrZMTPMechanism = errors.New("bytebuffers.ZMTP: invalid mechanism")
+)
+
+// WriteZMTPGreeting
+// 写入 64 字节的 ZMTP 3.0 问候：签名（10）、版本（2）、以 NUL 填充的机制名（20）、as-server（1）与填充（31）。
+func WriteZMTPGreeting(buf Buffer, mechanism string, asServer bool) (err error) {
+	if len(mechanism) == 0 || len(mechanism) > zmtpMechanismLen {
+		err = ErrZMTPMechanism
+		return
+	}
+	p, borrowErr := buf.Borrow(zmtpGreetingLen)
+	if borrowErr != nil {
+		err = borrowErr
+		return
+	}
+	clear(p[:zmtpGreetingLen])
+	p[0], p[9] = 0xFF, 0x7F
+	p[10], p[11] = zmtpVersionMajor, zmtpVersionMinor
+	copy(p[12:12+zmtpMechanismLen], mechanism)
+	if asServer {
+		p[32] = 1
+	}
+	buf.Return(zmtpGreetingLen)
+	return
+}
+
+// ReadZMTPGreeting
+// 读取 64 字节的 ZMTP 问候，返回去除 NUL 的机制名与 as-server 标志，不完整时不读掉。
+//
+// 主版本低于 3 时返回 ErrZMTPVersion。
+func ReadZMTPGreeting(buf Buffer) (mechanism string, asServer bool, err error) {
+	p, peekErr := peekFull(buf, zmtpGreetingLen)
+	if peekErr != nil {
+		err = peekErr
+		return
+	}
+	if p[0] != 0xFF || p[9] != 0x7F {
+		err = ErrZMTPInvalid
+		return
+	}
+	if p[10] < zmtpVersionMajor {
+		err = ErrZMTPVersion
+		return
+	}
+	name := p[12 : 12+zmtpMechanismLen]
+	if i := bytes.IndexByte(name, 0); i >= 0 {
+		name = name[:i]
+	}
+	if len(name) == 0 {
+		err = ErrZMTPMechanism
+		return
+	}
+	mechanism, asServer = string(name), p[32] == 1
+	buf.Discard(zmtpGreetingLen)
+	return
+}
@@ -0,0 +1,49 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestZMTPGreeting(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	if err := bytebuffers.WriteZMTPGreeting(buf, bytebuffers.ZMTPMechanismPlain, true); err != nil {
+		t.Fatal(err)
+	}
+	p := buf.CloneBytes()
+	if len(p) != 64 {
+		t.Fatal("unexpected greeting length", len(p))
+	}
+	if !bytes.Equal(p[:10], []byte{0xFF, 0, 0, 0, 0, 0, 0, 0, 0, 0x7F}) {
+		t.Fatal("unexpected signature", p[:10])
+	}
+	if p[10] != 3 || p[11] != 0 {
+		t.Fatal("unexpected version", p[10:12])
+	}
+	if !bytes.Equal(p[12:32], append([]byte("PLAIN"), make([]byte, 15)...)) {
+		t.Fatal("unexpected mechanism", p[12:32])
+	}
+	if p[32] != 1 || !bytes.Equal(p[33:], make([]byte, 31)) {
+		t.Fatal("unexpected as-server or filler", p[32:])
+	}
+
+	mechanism, asServer, err := bytebuffers.ReadZMTPGreeting(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mechanism != bytebuffers.ZMTPMechanismPlain || !asServer {
+		t.Fatal("unexpected greeting", mechanism, asServer)
+	}
+
+	if err = bytebuffers.WriteZMTPGreeting(buf, "THIS-MECHANISM-IS-TOO-LONG", false); !errors.Is(err, bytebuffers.ErrZMTPMechanism) {
+		t.Fatal("expected invalid mechanism, got", err)
+	}
+	p[10] = 2
+	_, _ = buf.Write(p)
+	if _, _, err = bytebuffers.ReadZMTPGreeting(buf); !errors.Is(err, bytebuffers.ErrZMTPVersion) {
+		t.Fatal("expected unsupported version, got", err)
+	}
+}
@@ -0,0 +1,72 @@
+package bytebuffers
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+var (
+	ErrZstdInvalidLevel = errors.New("bytebuffers.Zstd: invalid level")
+)
+
+const (
+	zstdMinLevel = 1
+	zstdMaxLevel = 22
+)
+
+var (
+	zstdEncoders     [zstd.SpeedBestCompression + 1]*zstd.Encoder
+	zstdEncodersOnce [zstd.SpeedBestCompression + 1]sync.Once
+	zstdDecoder      *zstd.Decoder
+	zstdDecoderOnce  sync.Once
+)
+
+// zstdEncoder
+// 获取 level 对应的常驻编码器，EncodeAll 可并发调用。
+func zstdEncoder(level zstd.EncoderLevel) (enc *zstd.Encoder, err error) {
+	zstdEncodersOnce[level].Do(func() {
+		zstdEncoders[level], _ = zstd.NewWriter(nil, zstd.WithEncoderLevel(level))
+	})
+	enc = zstdEncoders[level]
+	if enc == nil {
+		err = ErrZstdInvalidLevel
+	}
+	return
+}
+
+// CompressZstd
+// 以 Zstandard（RFC 8878）压缩可读内容并替换之。
+//
+// level 为 zstd 的压缩级别 1 至 22，以 zstd.EncoderLevelFromZstd 映射为 zstd.SpeedFastest、zstd.SpeedDefault、
+// zstd.SpeedBetterCompression 或 zstd.SpeedBestCompression。
+func CompressZstd(buf Buffer, level int) (err error) {
+	if level < zstdMinLevel || level > zstdMaxLevel {
+		err = ErrZstdInvalidLevel
+		return
+	}
+	enc, encErr := zstdEncoder(zstd.EncoderLevelFromZstd(level))
+	if encErr != nil {
+		err = encErr
+		return
+	}
+	dst := enc.EncodeAll(buf.Peek(buf.Len()), nil)
+	err = buf.Set(dst)
+	return
+}
+
+// DecompressZstd
+// 解压 Zstandard 格式的可读内容并替换之，失败时可读内容不变。
+func DecompressZstd(buf Buffer) (err error) {
+	zstdDecoderOnce.Do(func() {
+		zstdDecoder, _ = zstd.NewReader(nil)
+	})
+	dst, decErr := zstdDecoder.DecodeAll(buf.Peek(buf.Len()), nil)
+	if decErr != nil {
+		err = decErr
+		return
+	}
+	err = buf.Set(dst)
+	return
+}
@@ -0,0 +1,54 @@
+package bytebuffers_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/brickingsoft/bytebuffers"
+)
+
+func TestCompressZstd(t *testing.T) {
+	src := bytes.NewBuffer(nil)
+	for i := 0; i < 4096; i++ {
+		_, _ = fmt.Fprintf(src, "line %d: %x\n", i, i*i*7919)
+	}
+	sizes := make(map[int]int)
+	for _, level := range []int{1, 3, 22} {
+		buf := bytebuffers.NewBuffer()
+		_, _ = buf.Write(src.Bytes())
+		if err := bytebuffers.CompressZstd(buf, level); err != nil {
+			t.Fatal(err)
+		}
+		sizes[level] = buf.Len()
+		if err := bytebuffers.DecompressZstd(buf); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(buf.CloneBytes(), src.Bytes()) {
+			t.Fatal("round trip mismatch at level", level)
+		}
+	}
+	t.Log(src.Len(), sizes)
+	if sizes[1] <= sizes[22] {
+		t.Fatal("level does not affect compressed size", sizes)
+	}
+
+	buf := bytebuffers.NewBuffer()
+	for _, level := range []int{0, 23} {
+		if err := bytebuffers.CompressZstd(buf, level); !errors.Is(err, bytebuffers.ErrZstdInvalidLevel) {
+			t.Fatal("expected invalid level, got", err)
+		}
+	}
+}
+
+func TestDecompressZstd(t *testing.T) {
+	buf := bytebuffers.NewBuffer()
+	_, _ = buf.Write([]byte("not a zstd frame"))
+	if err := bytebuffers.DecompressZstd(buf); err == nil {
+		t.Fatal("expected error")
+	}
+	if string(buf.CloneBytes()) != "not a zstd frame" {
+		t.Fatal("buffer changed on failure")
+	}
+}